@@ -10,6 +10,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/upgrade"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 )
@@ -29,6 +30,9 @@ type Client interface {
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
 	Upgrades(context.Context, ...rpc.Option) (*upgrade.Config, error)
 	Uptime(context.Context, ...rpc.Option) (*UptimeResponse, error)
+	// GetTxFee returns the transaction fees at [timestamp]. If [timestamp] is
+	// nil, the fees at the current time are returned.
+	GetTxFee(ctx context.Context, timestamp *time.Time, options ...rpc.Option) (*GetTxFeeResponse, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
 }
 
@@ -110,6 +114,17 @@ func (c *client) Uptime(ctx context.Context, options ...rpc.Option) (*UptimeResp
 	return res, err
 }
 
+func (c *client) GetTxFee(ctx context.Context, timestamp *time.Time, options ...rpc.Option) (*GetTxFeeResponse, error) {
+	args := &GetTxFeeArgs{}
+	if timestamp != nil {
+		unixTime := json.Uint64(timestamp.Unix())
+		args.Timestamp = &unixTime
+	}
+	res := &GetTxFeeResponse{}
+	err := c.requester.SendRequest(ctx, "info.getTxFee", args, res, options...)
+	return res, err
+}
+
 func (c *client) GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID][]string, error) {
 	res := &GetVMsReply{}
 	err := c.requester.SendRequest(ctx, "info.getVMs", struct{}{}, res, options...)
@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"net/http"
 	"net/netip"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -25,92 +26,19 @@ import (
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
-	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
-)
 
-var (
-	errNoChainProvided = errors.New("argument 'chain' not given")
-
-	mainnetGetTxFeeResponse = GetTxFeeResponse{
-		CreateSubnetTxFee:             json.Uint64(1 * units.Avax),
-		TransformSubnetTxFee:          json.Uint64(10 * units.Avax),
-		CreateBlockchainTxFee:         json.Uint64(1 * units.Avax),
-		AddPrimaryNetworkValidatorFee: json.Uint64(0),
-		AddPrimaryNetworkDelegatorFee: json.Uint64(0),
-		AddSubnetValidatorFee:         json.Uint64(units.MilliAvax),
-		AddSubnetDelegatorFee:         json.Uint64(units.MilliAvax),
-	}
-	flareGetTxFeeResponse = GetTxFeeResponse{
-		CreateSubnetTxFee:             json.Uint64(100 * units.MilliAvax),
-		TransformSubnetTxFee:          json.Uint64(100 * units.MilliAvax),
-		CreateBlockchainTxFee:         json.Uint64(100 * units.MilliAvax),
-		AddPrimaryNetworkValidatorFee: json.Uint64(0),
-		AddPrimaryNetworkDelegatorFee: json.Uint64(0),
-		AddSubnetValidatorFee:         json.Uint64(units.MilliAvax),
-		AddSubnetDelegatorFee:         json.Uint64(units.MilliAvax),
-	}
-	costwoGetTxFeeResponse = GetTxFeeResponse{
-		CreateSubnetTxFee:             json.Uint64(100 * units.MilliAvax),
-		TransformSubnetTxFee:          json.Uint64(100 * units.MilliAvax),
-		CreateBlockchainTxFee:         json.Uint64(100 * units.MilliAvax),
-		AddPrimaryNetworkValidatorFee: json.Uint64(0),
-		AddPrimaryNetworkDelegatorFee: json.Uint64(0),
-		AddSubnetValidatorFee:         json.Uint64(units.MilliAvax),
-		AddSubnetDelegatorFee:         json.Uint64(units.MilliAvax),
-	}
-	localFlareGetTxFeeResponse = GetTxFeeResponse{
-		CreateSubnetTxFee:             json.Uint64(100 * units.MilliAvax),
-		TransformSubnetTxFee:          json.Uint64(100 * units.MilliAvax),
-		CreateBlockchainTxFee:         json.Uint64(100 * units.MilliAvax),
-		AddPrimaryNetworkValidatorFee: json.Uint64(0),
-		AddPrimaryNetworkDelegatorFee: json.Uint64(0),
-		AddSubnetValidatorFee:         json.Uint64(units.MilliAvax),
-		AddSubnetDelegatorFee:         json.Uint64(units.MilliAvax),
-	}
-	songbirdGetTxFeeResponse = GetTxFeeResponse{
-		CreateSubnetTxFee:             json.Uint64(units.MilliAvax),
-		TransformSubnetTxFee:          json.Uint64(units.MilliAvax),
-		CreateBlockchainTxFee:         json.Uint64(units.MilliAvax),
-		AddPrimaryNetworkValidatorFee: json.Uint64(0),
-		AddPrimaryNetworkDelegatorFee: json.Uint64(0),
-		AddSubnetValidatorFee:         json.Uint64(units.MilliAvax),
-		AddSubnetDelegatorFee:         json.Uint64(units.MilliAvax),
-	}
-	costonGetTxFeeResponse = GetTxFeeResponse{
-		CreateSubnetTxFee:             json.Uint64(100 * units.MilliAvax),
-		TransformSubnetTxFee:          json.Uint64(100 * units.MilliAvax),
-		CreateBlockchainTxFee:         json.Uint64(100 * units.MilliAvax),
-		AddPrimaryNetworkValidatorFee: json.Uint64(0),
-		AddPrimaryNetworkDelegatorFee: json.Uint64(0),
-		AddSubnetValidatorFee:         json.Uint64(units.MilliAvax),
-		AddSubnetDelegatorFee:         json.Uint64(units.MilliAvax),
-	}
-	localGetTxFeeResponse = GetTxFeeResponse{
-		CreateSubnetTxFee:             json.Uint64(100 * units.MilliAvax),
-		TransformSubnetTxFee:          json.Uint64(100 * units.MilliAvax),
-		CreateBlockchainTxFee:         json.Uint64(100 * units.MilliAvax),
-		AddPrimaryNetworkValidatorFee: json.Uint64(0),
-		AddPrimaryNetworkDelegatorFee: json.Uint64(0),
-		AddSubnetValidatorFee:         json.Uint64(units.MilliAvax),
-		AddSubnetDelegatorFee:         json.Uint64(units.MilliAvax),
-	}
-	defaultGetTxFeeResponse = GetTxFeeResponse{
-		CreateSubnetTxFee:             json.Uint64(100 * units.MilliAvax),
-		TransformSubnetTxFee:          json.Uint64(100 * units.MilliAvax),
-		CreateBlockchainTxFee:         json.Uint64(100 * units.MilliAvax),
-		AddPrimaryNetworkValidatorFee: json.Uint64(0),
-		AddPrimaryNetworkDelegatorFee: json.Uint64(0),
-		AddSubnetValidatorFee:         json.Uint64(units.MilliAvax),
-		AddSubnetDelegatorFee:         json.Uint64(units.MilliAvax),
-	}
+	txfee "github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 )
 
+var errNoChainProvided = errors.New("argument 'chain' not given")
+
 // Info is the API service for unprivileged info on a node
 type Info struct {
 	Parameters
@@ -133,6 +61,9 @@ type Parameters struct {
 
 	TxFee            uint64
 	CreateAssetTxFee uint64
+	DynamicFeeConfig gas.Config
+	// Optional, provides the P-chain's dynamic fee state
+	FeeStateReader gas.LockedStateReader
 }
 
 func NewService(
@@ -465,6 +396,16 @@ func (i *Info) Acps(_ *http.Request, _ *struct{}, reply *ACPsReply) error {
 	return nil
 }
 
+// GetTxFeeArgs are the arguments for calling GetTxFee
+type GetTxFeeArgs struct {
+	// Unix time, in seconds, to report the fees at. Defaults to the current
+	// time.
+	Timestamp *json.Uint64 `json:"timestamp"`
+}
+
+// GetTxFeeResponse are the results from calling GetTxFee. Once Etna is active,
+// the P-chain fees are the fees of the smallest possible transaction of each
+// type at the current gas price.
 type GetTxFeeResponse struct {
 	TxFee                         json.Uint64 `json:"txFee"`
 	CreateAssetTxFee              json.Uint64 `json:"createAssetTxFee"`
@@ -475,38 +416,109 @@ type GetTxFeeResponse struct {
 	AddPrimaryNetworkDelegatorFee json.Uint64 `json:"addPrimaryNetworkDelegatorFee"`
 	AddSubnetValidatorFee         json.Uint64 `json:"addSubnetValidatorFee"`
 	AddSubnetDelegatorFee         json.Uint64 `json:"addSubnetDelegatorFee"`
+
+	// Only set once Etna is active
+	DynamicFeeConfig *gas.Config `json:"dynamicFeeConfig,omitempty"`
+	// The P-chain fee state the fees were calculated with. Not set if the
+	// state isn't available, in which case the minimum gas price is used.
+	FeeState *gas.State  `json:"feeState,omitempty"`
+	GasPrice json.Uint64 `json:"gasPrice"`
+
+	// Time the fee schedule next changes. Not set if no change is scheduled.
+	NextChangeTime *time.Time `json:"nextChangeTime,omitempty"`
 }
 
-// GetTxFee returns the transaction fee in nAVAX.
-func (i *Info) GetTxFee(_ *http.Request, _ *struct{}, reply *GetTxFeeResponse) error {
-	i.log.Warn("deprecated API called",
+// GetTxFee returns the transaction fees, in nAVAX, at the requested time.
+func (i *Info) GetTxFee(_ *http.Request, args *GetTxFeeArgs, reply *GetTxFeeResponse) error {
+	i.log.Debug("API called",
 		zap.String("service", "info"),
 		zap.String("method", "getTxFee"),
 	)
 
-	switch i.NetworkID {
-	case constants.MainnetID:
-		*reply = mainnetGetTxFeeResponse
-	case constants.FlareID:
-		*reply = flareGetTxFeeResponse
-	case constants.CostwoID:
-		*reply = costwoGetTxFeeResponse
-	case constants.LocalFlareID:
-		*reply = localFlareGetTxFeeResponse
-	case constants.SongbirdID:
-		*reply = songbirdGetTxFeeResponse
-	case constants.CostonID:
-		*reply = costonGetTxFeeResponse
-	case constants.LocalID:
-		*reply = localGetTxFeeResponse
-	default:
-		*reply = defaultGetTxFeeResponse
+	timestamp := time.Now()
+	if args.Timestamp != nil {
+		timestamp = time.Unix(int64(*args.Timestamp), 0)
 	}
+
 	reply.TxFee = json.Uint64(i.TxFee)
 	reply.CreateAssetTxFee = json.Uint64(i.CreateAssetTxFee)
+
+	if !i.Parameters.Upgrades.IsEtnaActivated(timestamp) {
+		// The P-chain charges the same fee for every transaction before Etna.
+		for _, fee := range []*json.Uint64{
+			&reply.CreateSubnetTxFee,
+			&reply.TransformSubnetTxFee,
+			&reply.CreateBlockchainTxFee,
+			&reply.AddPrimaryNetworkValidatorFee,
+			&reply.AddPrimaryNetworkDelegatorFee,
+			&reply.AddSubnetValidatorFee,
+			&reply.AddSubnetDelegatorFee,
+		} {
+			*fee = json.Uint64(txfee.PreEtnaTxFee)
+		}
+
+		if etnaTime := i.Parameters.Upgrades.EtnaTime; !etnaTime.Equal(upgrade.UnscheduledActivationTime) {
+			reply.NextChangeTime = &etnaTime
+		}
+		return nil
+	}
+
+	config := i.DynamicFeeConfig
+	reply.DynamicFeeConfig = &config
+	price := config.MinPrice
+	if feeState, ok := i.getFeeState(timestamp); ok {
+		reply.FeeState = &feeState
+		price = gas.CalculatePrice(config.MinPrice, feeState.Excess, config.ExcessConversionConstant)
+	}
+	reply.GasPrice = json.Uint64(price)
+
+	// TransformSubnetTx is not permitted once Etna is active.
+	fees := []struct {
+		fee        *json.Uint64
+		complexity gas.Dimensions
+	}{
+		{&reply.CreateSubnetTxFee, txfee.IntrinsicCreateSubnetTxComplexities},
+		{&reply.CreateBlockchainTxFee, txfee.IntrinsicCreateChainTxComplexities},
+		{&reply.AddPrimaryNetworkValidatorFee, txfee.IntrinsicAddPermissionlessValidatorTxComplexities},
+		{&reply.AddPrimaryNetworkDelegatorFee, txfee.IntrinsicAddPermissionlessDelegatorTxComplexities},
+		{&reply.AddSubnetValidatorFee, txfee.IntrinsicAddSubnetValidatorTxComplexities},
+		{&reply.AddSubnetDelegatorFee, txfee.IntrinsicAddPermissionlessDelegatorTxComplexities},
+	}
+	for _, f := range fees {
+		gasUsed, err := f.complexity.ToGas(config.Weights)
+		if err != nil {
+			return fmt.Errorf("couldn't calculate gas: %w", err)
+		}
+		fee, err := gasUsed.Cost(price)
+		if err != nil {
+			return fmt.Errorf("couldn't calculate fee: %w", err)
+		}
+		*f.fee = json.Uint64(fee)
+	}
 	return nil
 }
 
+// getFeeState returns the P-chain fee state at [timestamp], assuming no gas is
+// consumed after the last accepted block. Returns false if the state isn't
+// available or [timestamp] is before the last accepted block.
+func (i *Info) getFeeState(timestamp time.Time) (gas.State, bool) {
+	if i.FeeStateReader == nil {
+		return gas.State{}, false
+	}
+	feeState, chainTime, err := i.FeeStateReader.FeeState()
+	if err != nil || timestamp.Before(chainTime) {
+		return gas.State{}, false
+	}
+
+	config := i.DynamicFeeConfig
+	return feeState.AdvanceTime(
+		config.MaxCapacity,
+		config.MaxPerSecond,
+		config.TargetPerSecond,
+		uint64(timestamp.Sub(chainTime)/time.Second),
+	), true
+}
+
 // GetVMsReply contains the response metadata for GetVMs
 type GetVMsReply struct {
 	VMs map[ids.ID][]string `json:"vms"`
@@ -5,14 +5,23 @@ package info
 
 import (
 	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/upgrade"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/vmsmock"
+
+	txfee "github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 )
 
 var errTest = errors.New("non-nil error")
@@ -94,3 +103,133 @@ func TestGetVMsGetAliasesFails(t *testing.T) {
 	err := resources.info.GetVMs(nil, nil, &reply)
 	require.ErrorIs(t, err, errTest)
 }
+
+type testFeeState struct {
+	timestamp time.Time
+	state     gas.State
+}
+
+func (s *testFeeState) GetTimestamp() time.Time {
+	return s.timestamp
+}
+
+func (s *testFeeState) GetFeeState() gas.State {
+	return s.state
+}
+
+func TestGetTxFee(t *testing.T) {
+	var (
+		etnaTime      = time.Unix(1_000_000, 0)
+		preEtnaTime   = json.Uint64(etnaTime.Unix() - 1)
+		postEtnaTime  = json.Uint64(etnaTime.Unix() + 10)
+		chainTime     = etnaTime
+		dynamicConfig = gas.Config{
+			Weights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    1,
+				gas.DBWrite:   1,
+				gas.Compute:   1,
+			},
+			MaxCapacity:              1_000_000,
+			MaxPerSecond:             100_000,
+			TargetPerSecond:          50_000,
+			MinPrice:                 1,
+			ExcessConversionConstant: 2_164_043,
+		}
+	)
+
+	bootstrapped := utils.Atomic[bool]{}
+	bootstrapped.Set(true)
+	feeStateReader := gas.NewLockedStateReader()
+	feeStateReader.SetStateReader(&bootstrapped, &sync.Mutex{}, &testFeeState{
+		timestamp: chainTime,
+		state: gas.State{
+			Capacity: 0,
+			Excess:   1_000_000,
+		},
+	})
+
+	minCreateSubnetGas, err := txfee.IntrinsicCreateSubnetTxComplexities.ToGas(dynamicConfig.Weights)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		etnaTime          time.Time
+		timestamp         *json.Uint64
+		feeStateReader    gas.LockedStateReader
+		expectedSubnetFee json.Uint64
+		expectedFeeState  *gas.State
+		expectedNextTime  *time.Time
+	}{
+		{
+			name:              "pre-etna",
+			etnaTime:          etnaTime,
+			timestamp:         &preEtnaTime,
+			feeStateReader:    feeStateReader,
+			expectedSubnetFee: json.Uint64(txfee.PreEtnaTxFee),
+			expectedNextTime:  &etnaTime,
+		},
+		{
+			name:              "etna unscheduled",
+			etnaTime:          upgrade.UnscheduledActivationTime,
+			timestamp:         &postEtnaTime,
+			feeStateReader:    feeStateReader,
+			expectedSubnetFee: json.Uint64(txfee.PreEtnaTxFee),
+		},
+		{
+			name:              "post-etna without fee state",
+			etnaTime:          etnaTime,
+			timestamp:         &postEtnaTime,
+			expectedSubnetFee: json.Uint64(minCreateSubnetGas),
+		},
+		{
+			name:           "post-etna with fee state",
+			etnaTime:       etnaTime,
+			timestamp:      &postEtnaTime,
+			feeStateReader: feeStateReader,
+			expectedFeeState: &gas.State{
+				Capacity: 1_000_000,
+				Excess:   500_000,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			service := &Info{
+				Parameters: Parameters{
+					Upgrades: upgrade.Config{
+						EtnaTime: test.etnaTime,
+					},
+					TxFee:            units.MilliAvax,
+					CreateAssetTxFee: 10 * units.MilliAvax,
+					DynamicFeeConfig: dynamicConfig,
+					FeeStateReader:   test.feeStateReader,
+				},
+				log: logging.NoLog{},
+			}
+
+			reply := GetTxFeeResponse{}
+			require.NoError(service.GetTxFee(nil, &GetTxFeeArgs{Timestamp: test.timestamp}, &reply))
+			require.Equal(json.Uint64(units.MilliAvax), reply.TxFee)
+			require.Equal(json.Uint64(10*units.MilliAvax), reply.CreateAssetTxFee)
+			require.Equal(test.expectedFeeState, reply.FeeState)
+			require.Equal(test.expectedNextTime, reply.NextChangeTime)
+			if test.expectedFeeState == nil {
+				require.Equal(test.expectedSubnetFee, reply.CreateSubnetTxFee)
+				return
+			}
+
+			price := gas.CalculatePrice(
+				dynamicConfig.MinPrice,
+				test.expectedFeeState.Excess,
+				dynamicConfig.ExcessConversionConstant,
+			)
+			expectedFee, err := minCreateSubnetGas.Cost(price)
+			require.NoError(err)
+			require.Equal(json.Uint64(price), reply.GasPrice)
+			require.Equal(json.Uint64(expectedFee), reply.CreateSubnetTxFee)
+		})
+	}
+}
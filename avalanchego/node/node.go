@@ -75,6 +75,7 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/registry"
@@ -325,6 +326,9 @@ type Node struct {
 
 	uptimeCalculator uptime.LockedCalculator
 
+	// Provides the P-chain's dynamic fee state to the info API
+	feeStateReader gas.LockedStateReader
+
 	// dispatcher for events as they happen in consensus
 	BlockAcceptorGroup  snow.AcceptorGroup
 	TxAcceptorGroup     snow.AcceptorGroup
@@ -1200,6 +1204,8 @@ func (n *Node) initVMs() error {
 		vdrs = validators.NewManager()
 	}
 
+	n.feeStateReader = gas.NewLockedStateReader()
+
 	// Register the VMs that Avalanche supports
 	err := errors.Join(
 		n.VMManager.RegisterFactory(context.TODO(), constants.PlatformVMID, &platformvm.Factory{
@@ -1207,6 +1213,7 @@ func (n *Node) initVMs() error {
 				Chains:                    n.chainManager,
 				Validators:                vdrs,
 				UptimeLockedCalculator:    n.uptimeCalculator,
+				FeeStateReader:            n.feeStateReader,
 				SybilProtectionEnabled:    n.Config.SybilProtectionEnabled,
 				PartialSyncPrimaryNetwork: n.Config.PartialSyncPrimaryNetwork,
 				TrackedSubnets:            n.Config.TrackedSubnets,
@@ -1395,6 +1402,8 @@ func (n *Node) initInfoAPI() error {
 
 			TxFee:            n.Config.TxFee,
 			CreateAssetTxFee: n.Config.CreateAssetTxFee,
+			DynamicFeeConfig: n.Config.DynamicFeeConfig,
+			FeeStateReader:   n.feeStateReader,
 		},
 		n.Log,
 		n.vdrs,
@@ -0,0 +1,267 @@
+package upgrade
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// MaxFutureStartTime is the maximum amount of time a staker can be
+	// scheduled to start in the future.
+	MaxFutureStartTime = 24 * 7 * 2 * time.Hour
+	// DefaultMaxValidatorWeightFactor is the default maximum ratio between a
+	// validator's total weight and its own stake.
+	DefaultMaxValidatorWeightFactor = 5
+
+	// defaultMinFutureStartTimeOffset is the default window before
+	// MaxFutureStartTime in which stakers must be scheduled to start, letting
+	// them start at any time up to MaxFutureStartTime.
+	defaultMinFutureStartTimeOffset = MaxFutureStartTime
+
+	maxDelegationFee = 1_000_000
+)
+
+var (
+	FlareStakingSchedule = StakingSchedule{
+		{
+			// Phase 1
+			StartTime:                ZeroTime,
+			MinValidatorStake:        10 * units.MegaAvax,
+			MaxValidatorStake:        50 * units.MegaAvax,
+			MinDelegatorStake:        1 * units.KiloAvax,
+			MinDelegationFee:         0,
+			MinStakeDuration:         2 * 7 * 24 * time.Hour,
+			MinDelegateDuration:      2 * 7 * 24 * time.Hour,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			MinFutureStartTimeOffset: 3 * 24 * time.Hour,
+			MaxValidatorWeightFactor: DefaultMaxValidatorWeightFactor,
+			MinStakeStartTime:        time.Date(2023, time.July, 5, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			// Phase 2
+			StartTime:                time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+			MinValidatorStake:        1 * units.MegaAvax,
+			MaxValidatorStake:        200 * units.MegaAvax,
+			MinDelegatorStake:        50 * units.KiloAvax,
+			MinDelegationFee:         0,
+			MinStakeDuration:         60 * 24 * time.Hour,
+			MinDelegateDuration:      2 * 7 * 24 * time.Hour,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			MinFutureStartTimeOffset: defaultMinFutureStartTimeOffset,
+			MaxValidatorWeightFactor: 15,
+			MinStakeStartTime:        time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	CostwoStakingSchedule = StakingSchedule{
+		{
+			// Phase 1
+			StartTime:                ZeroTime,
+			MinValidatorStake:        100 * units.KiloAvax,
+			MaxValidatorStake:        50 * units.MegaAvax,
+			MinDelegatorStake:        1 * units.KiloAvax,
+			MinDelegationFee:         0,
+			MinStakeDuration:         2 * 7 * 24 * time.Hour,
+			MinDelegateDuration:      2 * 7 * 24 * time.Hour,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			MinFutureStartTimeOffset: defaultMinFutureStartTimeOffset,
+			MaxValidatorWeightFactor: DefaultMaxValidatorWeightFactor,
+			MinStakeStartTime:        time.Date(2023, time.May, 25, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			// Phase 2
+			StartTime:                time.Date(2023, time.September, 7, 0, 0, 0, 0, time.UTC),
+			MinValidatorStake:        1 * units.MegaAvax,
+			MaxValidatorStake:        200 * units.MegaAvax,
+			MinDelegatorStake:        50 * units.KiloAvax,
+			MinDelegationFee:         0,
+			MinStakeDuration:         60 * 24 * time.Hour,
+			MinDelegateDuration:      2 * 7 * 24 * time.Hour,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			MinFutureStartTimeOffset: defaultMinFutureStartTimeOffset,
+			MaxValidatorWeightFactor: 15,
+			MinStakeStartTime:        time.Date(2023, time.September, 7, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	LocalFlareStakingSchedule = StakingSchedule{
+		{
+			// Phase 1
+			StartTime:                ZeroTime,
+			MinValidatorStake:        10 * units.KiloAvax,
+			MaxValidatorStake:        50 * units.MegaAvax,
+			MinDelegatorStake:        10 * units.KiloAvax,
+			MinDelegationFee:         0,
+			MinStakeDuration:         2 * 7 * 24 * time.Hour,
+			MinDelegateDuration:      1 * time.Hour,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			MinFutureStartTimeOffset: defaultMinFutureStartTimeOffset,
+			MaxValidatorWeightFactor: DefaultMaxValidatorWeightFactor,
+			MinStakeStartTime:        time.Date(2023, time.April, 10, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			// Phase 2
+			StartTime:                time.Date(2023, time.August, 1, 0, 0, 0, 0, time.UTC),
+			MinValidatorStake:        10 * units.KiloAvax,
+			MaxValidatorStake:        9_000 * units.MegaAvax,
+			MinDelegatorStake:        10 * units.KiloAvax,
+			MinDelegationFee:         0,
+			MinStakeDuration:         1 * time.Hour,
+			MinDelegateDuration:      30 * time.Minute,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			MinFutureStartTimeOffset: defaultMinFutureStartTimeOffset,
+			MaxValidatorWeightFactor: DefaultMaxValidatorWeightFactor,
+			MinStakeStartTime:        time.Date(2023, time.April, 10, 15, 0, 0, 0, time.UTC),
+		},
+	}
+	// Before the first phase of the schedules below, the staking rules
+	// are derived from the node's staking configuration.
+	SongbirdStakingSchedule = StakingSchedule{
+		{
+			// Phase 2
+			StartTime:                time.Date(2000, time.March, 1, 0, 0, 0, 0, time.UTC),
+			MinValidatorStake:        1 * units.MegaAvax,
+			MaxValidatorStake:        200 * units.MegaAvax,
+			MinDelegatorStake:        50 * units.KiloAvax,
+			MinDelegationFee:         0,
+			MinStakeDuration:         60 * 24 * time.Hour,
+			MinDelegateDuration:      2 * 7 * 24 * time.Hour,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			MinFutureStartTimeOffset: defaultMinFutureStartTimeOffset,
+			MaxValidatorWeightFactor: 15,
+			MinStakeStartTime:        time.Date(2024, time.November, 19, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	CostonStakingSchedule = StakingSchedule{
+		{
+			StartTime:                time.Date(2000, time.March, 1, 0, 0, 0, 0, time.UTC),
+			MinValidatorStake:        100 * units.KiloAvax,
+			MaxValidatorStake:        1000 * units.MegaAvax,
+			MinDelegatorStake:        10 * units.KiloAvax,
+			MinDelegationFee:         0,
+			MinStakeDuration:         24 * time.Hour,
+			MinDelegateDuration:      1 * time.Hour,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			MinFutureStartTimeOffset: defaultMinFutureStartTimeOffset,
+			MaxValidatorWeightFactor: 15,
+			MinStakeStartTime:        time.Date(2024, time.July, 30, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	LocalStakingSchedule = StakingSchedule{
+		{
+			StartTime:                time.Date(2000, time.March, 1, 0, 0, 0, 0, time.UTC),
+			MinValidatorStake:        10 * units.KiloAvax,
+			MaxValidatorStake:        50 * units.MegaAvax,
+			MinDelegatorStake:        10 * units.KiloAvax,
+			MinDelegationFee:         0,
+			MinStakeDuration:         2 * time.Hour,
+			MinDelegateDuration:      20 * time.Minute,
+			MaxStakeDuration:         365 * 24 * time.Hour,
+			MinFutureStartTimeOffset: defaultMinFutureStartTimeOffset,
+			MaxValidatorWeightFactor: 15,
+			MinStakeStartTime:        time.Date(2024, time.April, 22, 15, 0, 0, 0, time.UTC),
+		},
+	}
+
+	ErrInvalidStakingSchedule = errors.New("invalid staking schedule")
+)
+
+// StakingPhase holds the primary network staking rules in effect from
+// [StartTime] until the start of the next phase.
+type StakingPhase struct {
+	StartTime                time.Time     `json:"startTime"`
+	MinValidatorStake        uint64        `json:"minValidatorStake"`
+	MaxValidatorStake        uint64        `json:"maxValidatorStake"`
+	MinDelegatorStake        uint64        `json:"minDelegatorStake"`
+	MinDelegationFee         uint32        `json:"minDelegationFee"`
+	MinStakeDuration         time.Duration `json:"minStakeDuration"`
+	MinDelegateDuration      time.Duration `json:"minDelegateDuration"`
+	MaxStakeDuration         time.Duration `json:"maxStakeDuration"`
+	MinFutureStartTimeOffset time.Duration `json:"minFutureStartTimeOffset"` // Not checked for AddPermissionlessValidatorTx
+	MaxValidatorWeightFactor uint64        `json:"maxValidatorWeightFactor"`
+	MinStakeStartTime        time.Time     `json:"minStakeStartTime"`
+}
+
+func (p *StakingPhase) Validate() error {
+	switch {
+	case p.MinValidatorStake > p.MaxValidatorStake:
+		return errors.New("minValidatorStake is above maxValidatorStake")
+	case p.MinDelegationFee > maxDelegationFee:
+		return errors.New("minDelegationFee is above 100%")
+	case p.MinStakeDuration <= 0 || p.MinDelegateDuration <= 0:
+		return errors.New("minimum stake durations must be positive")
+	case p.MaxStakeDuration < p.MinStakeDuration || p.MaxStakeDuration < p.MinDelegateDuration:
+		return errors.New("maxStakeDuration is below the minimum stake durations")
+	case p.MinFutureStartTimeOffset < 0:
+		return errors.New("minFutureStartTimeOffset is negative")
+	case p.MaxValidatorWeightFactor == 0 || p.MaxValidatorWeightFactor > math.MaxUint8:
+		return fmt.Errorf("maxValidatorWeightFactor must be in [1, %d]", math.MaxUint8)
+	}
+	return nil
+}
+
+// StakingSchedule is a list of staking phases ordered by start time.
+type StakingSchedule []StakingPhase
+
+func (s StakingSchedule) Validate() error {
+	for i := range s {
+		if err := s[i].Validate(); err != nil {
+			return fmt.Errorf("%w: phase %d: %w", ErrInvalidStakingSchedule, i, err)
+		}
+		if i > 0 && !s[i-1].StartTime.Before(s[i].StartTime) {
+			return fmt.Errorf("%w: phase %d (%s) does not start after phase %d (%s)",
+				ErrInvalidStakingSchedule,
+				i,
+				s[i].StartTime,
+				i-1,
+				s[i-1].StartTime,
+			)
+		}
+	}
+	return nil
+}
+
+// PhaseAt returns the phase in effect at [t]. Returns false if [t] is before
+// the first phase.
+func (s StakingSchedule) PhaseAt(t time.Time) (StakingPhase, bool) {
+	for i := len(s) - 1; i >= 0; i-- {
+		if !t.Before(s[i].StartTime) {
+			return s[i], true
+		}
+	}
+	return StakingPhase{}, false
+}
+
+// NextPhaseTime returns the start time of the first phase starting after [t].
+// Returns false if no further phase is scheduled.
+func (s StakingSchedule) NextPhaseTime(t time.Time) (time.Time, bool) {
+	for _, phase := range s {
+		if phase.StartTime.After(t) {
+			return phase.StartTime, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func GetStakingSchedule(networkID uint32) StakingSchedule {
+	switch networkID {
+	case constants.FlareID:
+		return FlareStakingSchedule
+	case constants.SongbirdID:
+		return SongbirdStakingSchedule
+	case constants.CostwoID:
+		return CostwoStakingSchedule
+	case constants.CostonID:
+		return CostonStakingSchedule
+	case constants.LocalFlareID:
+		return LocalFlareStakingSchedule
+	case constants.LocalID:
+		return LocalStakingSchedule
+
+	default:
+		return nil
+	}
+}
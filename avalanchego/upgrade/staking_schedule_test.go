@@ -0,0 +1,101 @@
+package upgrade
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestValidDefaultStakingSchedules(t *testing.T) {
+	for _, scheduleTest := range []struct {
+		name     string
+		schedule StakingSchedule
+	}{
+		{
+			name:     "Flare",
+			schedule: FlareStakingSchedule,
+		},
+		{
+			name:     "Costwo",
+			schedule: CostwoStakingSchedule,
+		},
+		{
+			name:     "LocalFlare",
+			schedule: LocalFlareStakingSchedule,
+		},
+		{
+			name:     "Songbird",
+			schedule: SongbirdStakingSchedule,
+		},
+		{
+			name:     "Coston",
+			schedule: CostonStakingSchedule,
+		},
+		{
+			name:     "Local",
+			schedule: LocalStakingSchedule,
+		},
+	} {
+		t.Run(scheduleTest.name, func(t *testing.T) {
+			require := require.New(t)
+			require.NoError(scheduleTest.schedule.Validate())
+		})
+	}
+}
+
+func TestInvalidStakingSchedule(t *testing.T) {
+	require := require.New(t)
+
+	unordered := StakingSchedule{FlareStakingSchedule[1], FlareStakingSchedule[0]}
+	require.ErrorIs(unordered.Validate(), ErrInvalidStakingSchedule)
+
+	phase := FlareStakingSchedule[1]
+	phase.MaxValidatorWeightFactor = 256
+	require.ErrorIs(StakingSchedule{phase}.Validate(), ErrInvalidStakingSchedule)
+
+	phase = FlareStakingSchedule[1]
+	phase.MinValidatorStake = phase.MaxValidatorStake + 1
+	require.ErrorIs(StakingSchedule{phase}.Validate(), ErrInvalidStakingSchedule)
+}
+
+func TestStakingSchedulePhaseAt(t *testing.T) {
+	require := require.New(t)
+
+	phase2Start := time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	phase, ok := FlareStakingSchedule.PhaseAt(phase2Start.Add(-time.Second))
+	require.True(ok)
+	require.Equal(10*units.MegaAvax, phase.MinValidatorStake)
+	next, ok := FlareStakingSchedule.NextPhaseTime(phase2Start.Add(-time.Second))
+	require.True(ok)
+	require.Equal(phase2Start, next)
+
+	phase, ok = FlareStakingSchedule.PhaseAt(phase2Start)
+	require.True(ok)
+	require.Equal(1*units.MegaAvax, phase.MinValidatorStake)
+	_, ok = FlareStakingSchedule.NextPhaseTime(phase2Start)
+	require.False(ok)
+
+	// Songbird uses the node's staking configuration before its first phase
+	_, ok = SongbirdStakingSchedule.PhaseAt(time.Date(1999, time.January, 1, 0, 0, 0, 0, time.UTC))
+	require.False(ok)
+}
+
+func TestStakingScheduleJSON(t *testing.T) {
+	require := require.New(t)
+
+	bytes, err := json.Marshal(LocalFlareStakingSchedule)
+	require.NoError(err)
+
+	var schedule StakingSchedule
+	require.NoError(json.Unmarshal(bytes, &schedule))
+	require.Len(schedule, len(LocalFlareStakingSchedule))
+	for i := range schedule {
+		require.True(LocalFlareStakingSchedule[i].StartTime.Equal(schedule[i].StartTime))
+		require.Equal(LocalFlareStakingSchedule[i].MinDelegateDuration, schedule[i].MinDelegateDuration)
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gas
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+var (
+	errStillBootstrapping = errors.New("still bootstrapping")
+
+	_ LockedStateReader = (*lockedStateReader)(nil)
+)
+
+// StateReader provides the dynamic fee state of a chain.
+type StateReader interface {
+	GetTimestamp() time.Time
+	GetFeeState() State
+}
+
+// LockedStateReader allows the fee state of a chain to be read from outside of
+// the chain once the chain has registered its [StateReader].
+type LockedStateReader interface {
+	// FeeState returns the last accepted fee state and the time it was
+	// recorded at.
+	FeeState() (State, time.Time, error)
+
+	SetStateReader(isBootstrapped *utils.Atomic[bool], lock sync.Locker, r StateReader)
+}
+
+type lockedStateReader struct {
+	lock           sync.RWMutex
+	isBootstrapped *utils.Atomic[bool]
+	readerLock     sync.Locker
+	r              StateReader
+}
+
+func NewLockedStateReader() LockedStateReader {
+	return &lockedStateReader{}
+}
+
+func (l *lockedStateReader) FeeState() (State, time.Time, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if l.isBootstrapped == nil || !l.isBootstrapped.Get() {
+		return State{}, time.Time{}, errStillBootstrapping
+	}
+
+	l.readerLock.Lock()
+	defer l.readerLock.Unlock()
+
+	return l.r.GetFeeState(), l.r.GetTimestamp(), nil
+}
+
+func (l *lockedStateReader) SetStateReader(isBootstrapped *utils.Atomic[bool], lock sync.Locker, r StateReader) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.isBootstrapped = isBootstrapped
+	l.readerLock = lock
+	l.r = r
+}
@@ -113,6 +113,10 @@ type Client interface {
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
+	// GetCurrentStakingRules returns the primary network staking rules in
+	// effect at [timestamp], or at the current chain time if nil, and the
+	// start of the next scheduled staking phase if there is one.
+	GetCurrentStakingRules(ctx context.Context, timestamp *time.Time, options ...rpc.Option) (*GetCurrentStakingRulesReply, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided
 	// subnet at the specified height or at proposerVM height if set to
 	// [platformapi.ProposedHeight]
@@ -562,6 +566,17 @@ func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.
 	return res.Timestamp, err
 }
 
+func (c *client) GetCurrentStakingRules(ctx context.Context, timestamp *time.Time, options ...rpc.Option) (*GetCurrentStakingRulesReply, error) {
+	args := &GetStakingSettingsArgs{}
+	if timestamp != nil {
+		unix := json.Uint64(timestamp.Unix())
+		args.Timestamp = &unix
+	}
+	res := &GetCurrentStakingRulesReply{}
+	err := c.requester.SendRequest(ctx, "platform.getCurrentStakingRules", args, res, options...)
+	return res, err
+}
+
 func (c *client) GetValidatorsAt(
 	ctx context.Context,
 	subnetID ids.ID,
@@ -42,6 +42,10 @@ type Internal struct {
 	// Provides access to the uptime manager as a thread safe data structure
 	UptimeLockedCalculator uptime.LockedCalculator
 
+	// Provides access to the dynamic fee state as a thread safe data
+	// structure. Optional.
+	FeeStateReader gas.LockedStateReader
+
 	// True if the node is being run with staking enabled
 	SybilProtectionEnabled bool
 
@@ -22,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
@@ -1780,6 +1781,99 @@ func (s *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, reply *Ge
 	return nil
 }
 
+// GetStakingSettingsArgs are the arguments for calling GetStakingSettings.
+type GetStakingSettingsArgs struct {
+	// Unix timestamp, in seconds, at which to evaluate the staking schedule.
+	// Defaults to the current chain time.
+	Timestamp *avajson.Uint64 `json:"timestamp"`
+}
+
+// StakingSettings are the primary network staking rules in effect at
+// [Timestamp]. Durations are in seconds.
+type StakingSettings struct {
+	Timestamp                time.Time      `json:"timestamp"`
+	MinValidatorStake        avajson.Uint64 `json:"minValidatorStake"`
+	MaxValidatorStake        avajson.Uint64 `json:"maxValidatorStake"`
+	MinDelegatorStake        avajson.Uint64 `json:"minDelegatorStake"`
+	MinDelegationFee         avajson.Uint32 `json:"minDelegationFee"`
+	MinStakeDuration         avajson.Uint64 `json:"minStakeDuration"`
+	MinDelegateDuration      avajson.Uint64 `json:"minDelegateDuration"`
+	MaxStakeDuration         avajson.Uint64 `json:"maxStakeDuration"`
+	MinFutureStartTimeOffset avajson.Uint64 `json:"minFutureStartTimeOffset"`
+	MaxValidatorWeightFactor avajson.Uint64 `json:"maxValidatorWeightFactor"`
+	MinStakeStartTime        time.Time      `json:"minStakeStartTime"`
+}
+
+// getStakingSettings returns the staking rules in effect at the requested unix
+// [timestamp], or at the current chain time if it is nil.
+func (s *Service) getStakingSettings(timestamp *avajson.Uint64) StakingSettings {
+	var (
+		t        = s.stakingTimestamp(timestamp)
+		settings = executor.GetInflationSettings(t, s.vm.ctx.NetworkID, &s.vm.Internal)
+	)
+	return StakingSettings{
+		Timestamp:                t,
+		MinValidatorStake:        avajson.Uint64(settings.MinValidatorStake),
+		MaxValidatorStake:        avajson.Uint64(settings.MaxValidatorStake),
+		MinDelegatorStake:        avajson.Uint64(settings.MinDelegatorStake),
+		MinDelegationFee:         avajson.Uint32(settings.MinDelegationFee),
+		MinStakeDuration:         avajson.Uint64(settings.MinStakeDuration / time.Second),
+		MinDelegateDuration:      avajson.Uint64(settings.MinDelegateDuration / time.Second),
+		MaxStakeDuration:         avajson.Uint64(settings.MaxStakeDuration / time.Second),
+		MinFutureStartTimeOffset: avajson.Uint64(settings.MinFutureStartTimeOffset / time.Second),
+		MaxValidatorWeightFactor: avajson.Uint64(settings.MaxValidatorWeightFactor),
+		MinStakeStartTime:        settings.MinStakeStartTime,
+	}
+}
+
+// GetStakingSettings returns the primary network staking rules in effect at
+// the requested time.
+func (s *Service) GetStakingSettings(_ *http.Request, args *GetStakingSettingsArgs, reply *StakingSettings) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getStakingSettings"),
+	)
+
+	*reply = s.getStakingSettings(args.Timestamp)
+	return nil
+}
+
+// GetCurrentStakingRulesReply is the response from calling GetCurrentStakingRules.
+type GetCurrentStakingRulesReply struct {
+	StakingSettings
+	// Start of the next scheduled staking phase, if any
+	NextPhaseTime *time.Time `json:"nextPhaseTime,omitempty"`
+}
+
+// GetCurrentStakingRules returns the primary network staking rules in effect
+// at the requested time, defaulting to the current chain time, along with the
+// start of the next scheduled staking phase.
+func (s *Service) GetCurrentStakingRules(_ *http.Request, args *GetStakingSettingsArgs, reply *GetCurrentStakingRulesReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getCurrentStakingRules"),
+	)
+
+	reply.StakingSettings = s.getStakingSettings(args.Timestamp)
+	if next, ok := upgrade.GetStakingSchedule(s.vm.ctx.NetworkID).NextPhaseTime(reply.Timestamp); ok {
+		reply.NextPhaseTime = &next
+	}
+	return nil
+}
+
+// stakingTimestamp returns the requested unix [timestamp], or the current
+// chain time if it is nil.
+func (s *Service) stakingTimestamp(timestamp *avajson.Uint64) time.Time {
+	if timestamp != nil {
+		return time.Unix(int64(*timestamp), 0).UTC()
+	}
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	return s.vm.state.GetTimestamp()
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -27,6 +27,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/upgrade/upgradetest"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
@@ -931,6 +932,35 @@ func TestGetTimestamp(t *testing.T) {
 	require.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestGetCurrentStakingRules(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+
+	phase2Start := upgrade.FlareStakingSchedule[1].StartTime
+	service.vm.ctx.NetworkID = constants.FlareID
+
+	// Defaults to the chain time
+	reply := GetCurrentStakingRulesReply{}
+	require.NoError(service.GetCurrentStakingRules(nil, &GetStakingSettingsArgs{}, &reply))
+	service.vm.ctx.Lock.Lock()
+	require.Equal(service.vm.state.GetTimestamp(), reply.Timestamp)
+	service.vm.ctx.Lock.Unlock()
+
+	timestamp := avajson.Uint64(phase2Start.Add(-time.Second).Unix())
+	reply = GetCurrentStakingRulesReply{}
+	require.NoError(service.GetCurrentStakingRules(nil, &GetStakingSettingsArgs{Timestamp: &timestamp}, &reply))
+	require.Equal(avajson.Uint64(upgrade.FlareStakingSchedule[0].MinValidatorStake), reply.MinValidatorStake)
+	require.Equal(avajson.Uint64(upgrade.FlareStakingSchedule[0].MinStakeDuration/time.Second), reply.MinStakeDuration)
+	require.NotNil(reply.NextPhaseTime)
+	require.Equal(phase2Start, *reply.NextPhaseTime)
+
+	timestamp = avajson.Uint64(phase2Start.Unix())
+	reply = GetCurrentStakingRulesReply{}
+	require.NoError(service.GetCurrentStakingRules(nil, &GetStakingSettingsArgs{Timestamp: &timestamp}, &reply))
+	require.Equal(avajson.Uint64(upgrade.FlareStakingSchedule[1].MaxValidatorWeightFactor), reply.MaxValidatorWeightFactor)
+	require.Nil(reply.NextPhaseTime)
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
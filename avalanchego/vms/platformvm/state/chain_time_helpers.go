@@ -141,7 +141,7 @@ func getNextL1ValidatorEvictionTime(
 func PickFeeCalculator(config *config.Internal, state Chain) txfee.Calculator {
 	timestamp := state.GetTimestamp()
 	if !config.UpgradeConfig.IsEtnaActivated(timestamp) {
-		return txfee.NewSimpleCalculator(0)
+		return txfee.NewSimpleCalculator(txfee.PreEtnaTxFee)
 	}
 
 	feeState := state.GetFeeState()
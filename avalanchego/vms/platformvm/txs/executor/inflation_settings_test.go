@@ -0,0 +1,67 @@
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+)
+
+func TestGetInflationSettings(t *testing.T) {
+	cfg := &config.Internal{
+		MinValidatorStake: 5 * units.KiloAvax,
+		MaxValidatorStake: 10 * units.MegaAvax,
+		MinDelegatorStake: 25 * units.Avax,
+		MinDelegationFee:  20_000,
+		MinStakeDuration:  24 * time.Hour,
+		MaxStakeDuration:  365 * 24 * time.Hour,
+	}
+
+	tests := []struct {
+		name      string
+		timestamp time.Time
+		expected  InflationSettings
+	}{
+		{
+			name:      "before first phase",
+			timestamp: time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC),
+			expected: InflationSettings{
+				MinValidatorStake:        5 * units.KiloAvax,
+				MaxValidatorStake:        10 * units.MegaAvax,
+				MinDelegatorStake:        25 * units.Avax,
+				MinDelegationFee:         20_000,
+				MinStakeDuration:         24 * time.Hour,
+				MinDelegateDuration:      24 * time.Hour,
+				MaxStakeDuration:         365 * 24 * time.Hour,
+				MinFutureStartTimeOffset: MaxFutureStartTime,
+				MaxValidatorWeightFactor: MaxValidatorWeightFactor,
+				MinStakeStartTime:        time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:      "scheduled phase",
+			timestamp: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+			expected: InflationSettings{
+				MinValidatorStake:        1 * units.MegaAvax,
+				MaxValidatorStake:        200 * units.MegaAvax,
+				MinDelegatorStake:        50 * units.KiloAvax,
+				MinDelegationFee:         0,
+				MinStakeDuration:         60 * 24 * time.Hour,
+				MinDelegateDuration:      2 * 7 * 24 * time.Hour,
+				MaxStakeDuration:         365 * 24 * time.Hour,
+				MinFutureStartTimeOffset: MaxFutureStartTime,
+				MaxValidatorWeightFactor: 15,
+				MinStakeStartTime:        time.Date(2024, time.November, 19, 12, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, GetInflationSettings(test.timestamp, constants.SongbirdID, cfg))
+		})
+	}
+}
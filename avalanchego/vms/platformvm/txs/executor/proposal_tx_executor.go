@@ -10,6 +10,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
@@ -21,12 +22,12 @@ import (
 
 const (
 	// Maximum future start time for staking/delegating
-	MaxFutureStartTime = 24 * 7 * 2 * time.Hour
+	MaxFutureStartTime = upgrade.MaxFutureStartTime
 
 	// SyncBound is the synchrony bound used for safe decision making
 	SyncBound = 10 * time.Second
 
-	MaxValidatorWeightFactor = 5
+	MaxValidatorWeightFactor = upgrade.DefaultMaxValidatorWeightFactor
 )
 
 var (
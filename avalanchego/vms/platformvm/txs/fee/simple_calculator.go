@@ -5,6 +5,10 @@ package fee
 
 import "github.com/ava-labs/avalanchego/vms/platformvm/txs"
 
+// PreEtnaTxFee is the fee of every transaction before dynamic fees are
+// activated in Etna.
+const PreEtnaTxFee = 0
+
 var _ Calculator = (*SimpleCalculator)(nil)
 
 type SimpleCalculator struct {
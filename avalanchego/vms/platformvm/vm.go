@@ -155,6 +155,9 @@ func (vm *VM) Initialize(
 	utxoVerifier := utxo.NewVerifier(vm.ctx, &vm.clock, vm.fx)
 	vm.uptimeManager = uptime.NewManager(vm.state, &vm.clock)
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &chainCtx.Lock, vm.uptimeManager)
+	if vm.FeeStateReader != nil {
+		vm.FeeStateReader.SetStateReader(&vm.bootstrapped, &chainCtx.Lock, vm.state)
+	}
 
 	txExecutorBackend := &txexecutor.Backend{
 		Config:       &vm.Internal,
@@ -0,0 +1,126 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+// replay re-executes an exported range of blocks on top of the pre-state in a
+// local database and reports the receipts, logs and state roots that differ
+// from the ones in the range.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ava-labs/coreth/cmd/utils"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/internal/flags"
+	"github.com/ava-labs/coreth/params"
+)
+
+var (
+	dataDirFlag = &cli.StringFlag{
+		Name:     "datadir",
+		Usage:    "Path to the database with the pre-state of the range",
+		Required: true,
+	}
+	dbEngineFlag = &cli.StringFlag{
+		Name:  "db.engine",
+		Usage: "Backing database implementation (leveldb or pebble, default = detected)",
+	}
+	cacheFlag = &cli.IntFlag{
+		Name:  "cache",
+		Usage: "Megabytes of memory allocated to the database cache",
+		Value: 512,
+	}
+	networkIDFlag = &cli.UintFlag{
+		Name:  "networkid",
+		Usage: "Network ID whose upgrade schedule overrides the one stored in the database (default = stored schedule)",
+	}
+	avaxAssetIDFlag = &cli.StringFlag{
+		Name:  "avax-asset-id",
+		Usage: "Asset ID of the native asset of the network, required to replay blocks with atomic txs",
+	}
+)
+
+var app = flags.NewApp("Offline block range re-execution checker")
+
+func init() {
+	app.Name = "replay"
+	app.ArgsUsage = "<blocks.rlp[.gz]>"
+	app.Flags = []cli.Flag{
+		dataDirFlag,
+		dbEngineFlag,
+		cacheFlag,
+		networkIDFlag,
+		avaxAssetIDFlag,
+	}
+	app.Action = replay
+}
+
+func replay(c *cli.Context) error {
+	if c.NArg() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	blocks, err := readBlocks(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("failed to read blocks: %w", err)
+	}
+	if len(blocks) == 0 {
+		return errors.New("no blocks to replay")
+	}
+	ctx := &snow.Context{NetworkID: uint32(c.Uint(networkIDFlag.Name))}
+	if c.IsSet(avaxAssetIDFlag.Name) {
+		if ctx.AVAXAssetID, err = ids.FromString(c.String(avaxAssetIDFlag.Name)); err != nil {
+			return fmt.Errorf("invalid AVAX asset ID: %w", err)
+		}
+	}
+
+	db, err := rawdb.Open(rawdb.OpenOptions{
+		Type:      c.String(dbEngineFlag.Name),
+		Directory: c.String(dataDirFlag.Name),
+		Cache:     c.Int(cacheFlag.Name),
+		Handles:   256,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	genesisHash := rawdb.ReadCanonicalHash(db, 0)
+	config := rawdb.ReadChainConfig(db, genesisHash)
+	if config == nil {
+		return fmt.Errorf("chain config of genesis %s not found", genesisHash)
+	}
+	if c.IsSet(networkIDFlag.Name) {
+		config.NetworkUpgrades = params.GetNetworkUpgrades(uint32(c.Uint(networkIDFlag.Name)))
+	}
+
+	log.Info("Replaying blocks", "first", blocks[0].NumberU64(), "last", blocks[len(blocks)-1].NumberU64())
+	divergences, err := newReplayer(config, ctx, db).replay(blocks)
+	for _, divergence := range divergences {
+		fmt.Println(divergence)
+	}
+	if err != nil {
+		return err
+	}
+	if len(divergences) > 0 {
+		return fmt.Errorf("found %d divergences", len(divergences))
+	}
+	log.Info("No divergences found", "blocks", len(blocks))
+	return nil
+}
+
+func main() {
+	log.SetDefault(log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelInfo, true)))
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
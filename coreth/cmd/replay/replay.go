@@ -0,0 +1,392 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ava-labs/coreth/consensus"
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/core/vm"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm"
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ava-labs/coreth/triedb"
+)
+
+var errMissingAVAXAssetID = errors.New("the AVAX asset ID is required to replay atomic txs")
+
+// Divergence is a difference between the result of re-executing a block and
+// the block (or the receipts stored for it) in the exported range.
+type Divergence struct {
+	Number uint64
+	Hash   common.Hash
+	// TxIndex is the index of the transaction the divergence was found in,
+	// or -1 for block level divergences.
+	TxIndex int
+	Field   string
+	Want    string
+	Have    string
+}
+
+func (d Divergence) String() string {
+	if d.TxIndex < 0 {
+		return fmt.Sprintf("block %d (%s): %s mismatch: want %s, have %s", d.Number, d.Hash.TerminalString(), d.Field, d.Want, d.Have)
+	}
+	return fmt.Sprintf("block %d (%s) tx %d: %s mismatch: want %s, have %s", d.Number, d.Hash.TerminalString(), d.TxIndex, d.Field, d.Want, d.Have)
+}
+
+// readBlocks decodes the RLP encoded blocks in [path], as written by
+// export tools. Files ending in .gz are decompressed.
+func readBlocks(path string) ([]*types.Block, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var reader io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	var (
+		stream = rlp.NewStream(reader, 0)
+		blocks []*types.Block
+	)
+	for {
+		var block types.Block
+		if err := stream.Decode(&block); err != nil {
+			if errors.Is(err, io.EOF) {
+				return blocks, nil
+			}
+			return nil, fmt.Errorf("block %d: failed to decode: %w", len(blocks), err)
+		}
+		blocks = append(blocks, &block)
+	}
+}
+
+// overlayDatabase serves reads from the pre-state database and keeps all
+// writes in memory, so that replaying never modifies the snapshot.
+type overlayDatabase struct {
+	ethdb.Database
+	mem *memorydb.Database
+}
+
+func newOverlayDatabase(db ethdb.Database) *overlayDatabase {
+	return &overlayDatabase{
+		Database: db,
+		mem:      memorydb.New(),
+	}
+}
+
+func (db *overlayDatabase) Has(key []byte) (bool, error) {
+	if ok, _ := db.mem.Has(key); ok {
+		return true, nil
+	}
+	return db.Database.Has(key)
+}
+
+func (db *overlayDatabase) Get(key []byte) ([]byte, error) {
+	if value, err := db.mem.Get(key); err == nil {
+		return value, nil
+	}
+	return db.Database.Get(key)
+}
+
+func (db *overlayDatabase) Put(key []byte, value []byte) error { return db.mem.Put(key, value) }
+
+func (db *overlayDatabase) Delete(key []byte) error { return db.mem.Delete(key) }
+
+func (db *overlayDatabase) NewBatch() ethdb.Batch { return db.mem.NewBatch() }
+
+func (db *overlayDatabase) NewBatchWithSize(size int) ethdb.Batch {
+	return db.mem.NewBatchWithSize(size)
+}
+
+// headerChain serves the headers of the replayed range and, before it, the
+// headers in the pre-state database. It is used to resolve BLOCKHASH.
+type headerChain struct {
+	config  *params.ChainConfig
+	db      ethdb.Reader
+	engine  consensus.Engine
+	headers map[common.Hash]*types.Header
+	current *types.Header
+}
+
+func (hc *headerChain) Engine() consensus.Engine { return hc.engine }
+
+func (hc *headerChain) Config() *params.ChainConfig { return hc.config }
+
+func (hc *headerChain) CurrentHeader() *types.Header { return hc.current }
+
+func (hc *headerChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header, ok := hc.headers[hash]; ok && header.Number.Uint64() == number {
+		return header
+	}
+	return rawdb.ReadHeader(hc.db, hash, number)
+}
+
+func (hc *headerChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	if header, ok := hc.headers[hash]; ok {
+		return header
+	}
+	number := rawdb.ReadHeaderNumber(hc.db, hash)
+	if number == nil {
+		return nil
+	}
+	return rawdb.ReadHeader(hc.db, hash, *number)
+}
+
+func (hc *headerChain) GetHeaderByNumber(number uint64) *types.Header {
+	for header := hc.current; header != nil; header = hc.headers[header.ParentHash] {
+		if header.Number.Uint64() == number {
+			return header
+		}
+	}
+	hash := rawdb.ReadCanonicalHash(hc.db, number)
+	if hash == (common.Hash{}) {
+		return nil
+	}
+	return rawdb.ReadHeader(hc.db, hash, number)
+}
+
+// replayer re-executes blocks on top of a pre-state database.
+type replayer struct {
+	config   *params.ChainConfig
+	ctx      *snow.Context
+	db       ethdb.Database
+	triedb   *triedb.Database
+	statedb  state.Database
+	chain    *headerChain
+	vmConfig vm.Config
+}
+
+// newReplayer returns a replayer of blocks on top of [db]. [ctx] provides the
+// AVAX asset ID used to apply the atomic txs of the replayed blocks.
+func newReplayer(config *params.ChainConfig, ctx *snow.Context, db ethdb.Database) *replayer {
+	var (
+		overlay = newOverlayDatabase(db)
+		tdb     = triedb.NewDatabase(overlay, triedb.HashDefaults)
+	)
+	return &replayer{
+		config:  config,
+		ctx:     ctx,
+		db:      db,
+		triedb:  tdb,
+		statedb: state.NewDatabaseWithNodeDB(overlay, tdb),
+		chain: &headerChain{
+			config:  config,
+			db:      db,
+			engine:  dummy.NewFaker(),
+			headers: make(map[common.Hash]*types.Header),
+		},
+	}
+}
+
+// replay re-executes [blocks] in order, starting from the state of the parent
+// of the first block, and returns the divergences found. Replaying stops with
+// an error if the state to continue from is not in the pre-state database
+// after a state root divergence.
+// The atomic txs carried in the extra data of the blocks are applied to the
+// state as the VM does, without verifying them against shared memory.
+func (r *replayer) replay(blocks []*types.Block) ([]Divergence, error) {
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	if r.ctx.AVAXAssetID == ids.Empty {
+		for _, block := range blocks {
+			if len(block.ExtData()) > 0 {
+				return nil, fmt.Errorf("%w: block %d (%s)", errMissingAVAXAssetID, block.NumberU64(), block.Hash())
+			}
+		}
+	}
+	first := blocks[0]
+	parent := rawdb.ReadHeader(r.db, first.ParentHash(), first.NumberU64()-1)
+	if parent == nil {
+		return nil, fmt.Errorf("parent %s of block %d not found in the pre-state database", first.ParentHash(), first.NumberU64())
+	}
+	r.chain.current = parent
+
+	var (
+		divergences []Divergence
+		replayed    common.Hash
+	)
+	for _, block := range blocks {
+		if block.ParentHash() != parent.Hash() {
+			return divergences, fmt.Errorf("block %d is not a child of block %d (%s)", block.NumberU64(), parent.Number, parent.Hash())
+		}
+		statedb, err := state.New(parent.Root, r.statedb, nil)
+		if err != nil {
+			// After a divergence, replaying continues from the state in the
+			// database if it is there.
+			return divergences, fmt.Errorf("cannot continue from block %d: state %s is not available: %w", parent.Number, parent.Root, err)
+		}
+		blockDivergences, root, err := r.replayBlock(block, parent, statedb)
+		divergences = append(divergences, blockDivergences...)
+		if err != nil {
+			return divergences, fmt.Errorf("block %d: %w", block.NumberU64(), err)
+		}
+		// Only keep the state of the last replayed block in memory.
+		r.triedb.Reference(root, common.Hash{})
+		if replayed != (common.Hash{}) {
+			r.triedb.Dereference(replayed)
+		}
+		replayed = root
+
+		header := block.Header()
+		r.chain.headers[header.Hash()] = header
+		r.chain.current = header
+		parent = header
+	}
+	return divergences, nil
+}
+
+// replayBlock executes [block] on [statedb] and compares the results with the
+// header of [block] and the receipts stored for it, if any. It returns the
+// divergences and the state root after the block.
+func (r *replayer) replayBlock(block *types.Block, parent *types.Header, statedb *state.StateDB) ([]Divergence, common.Hash, error) {
+	var (
+		header   = block.Header()
+		context  = core.NewEVMBlockContext(header, r.chain, nil)
+		gp       = new(core.GasPool).AddGas(block.GasLimit())
+		usedGas  = new(uint64)
+		receipts types.Receipts
+	)
+	if err := core.ApplyUpgrades(r.config, &parent.Time, block, statedb); err != nil {
+		return nil, common.Hash{}, fmt.Errorf("failed to apply upgrades: %w", err)
+	}
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		vmenv := vm.NewEVM(context, vm.TxContext{}, statedb, r.config, r.vmConfig)
+		core.ProcessBeaconBlockRoot(*beaconRoot, vmenv, statedb)
+	}
+	for i, tx := range block.Transactions() {
+		statedb.SetTxContext(tx.Hash(), i)
+		receipt, err := core.ApplyTransaction(r.config, r.chain, context, gp, statedb, header, tx, usedGas, r.vmConfig)
+		if err != nil {
+			return nil, common.Hash{}, fmt.Errorf("could not apply tx %d [%v]: %w", i, tx.Hash().Hex(), err)
+		}
+		receipts = append(receipts, receipt)
+	}
+	_, extDataGasUsed, err := evm.ApplyAtomicTxs(r.ctx, r.config, block, parent, statedb)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("could not apply atomic txs: %w", err)
+	}
+
+	divergences := compareBlock(r.config, block, *usedGas, extDataGasUsed, receipts)
+	if stored := rawdb.ReadRawReceipts(r.db, block.Hash(), block.NumberU64()); stored != nil {
+		divergences = append(divergences, compareReceipts(block, stored, receipts)...)
+	}
+
+	// Committing only writes to the trie database and the in-memory overlay.
+	root, err := statedb.Commit(block.NumberU64(), r.config.IsEIP158(block.Number()))
+	if err != nil {
+		return divergences, common.Hash{}, fmt.Errorf("failed to commit state: %w", err)
+	}
+	if root != block.Root() {
+		divergences = append(divergences, newDivergence(block, -1, "state root", block.Root().Hex(), root.Hex()))
+	}
+	return divergences, root, nil
+}
+
+func newDivergence(block *types.Block, txIndex int, field string, want, have any) Divergence {
+	return Divergence{
+		Number:  block.NumberU64(),
+		Hash:    block.Hash(),
+		TxIndex: txIndex,
+		Field:   field,
+		Want:    fmt.Sprint(want),
+		Have:    fmt.Sprint(have),
+	}
+}
+
+// compareBlock compares the results of executing [block] with its header.
+func compareBlock(config *params.ChainConfig, block *types.Block, usedGas uint64, extDataGasUsed *big.Int, receipts types.Receipts) []Divergence {
+	var divergences []Divergence
+	if usedGas != block.GasUsed() {
+		divergences = append(divergences, newDivergence(block, -1, "gas used", block.GasUsed(), usedGas))
+	}
+	if config.IsApricotPhase4(block.Time()) {
+		if extDataGasUsed == nil {
+			extDataGasUsed = new(big.Int)
+		}
+		if want := block.ExtDataGasUsed(); want == nil || want.Cmp(extDataGasUsed) != 0 {
+			divergences = append(divergences, newDivergence(block, -1, "ext data gas used", want, extDataGasUsed))
+		}
+	}
+	if bloom := types.CreateBloom(receipts); bloom != block.Bloom() {
+		divergences = append(divergences, newDivergence(block, -1, "logs bloom", common.Bytes2Hex(block.Bloom().Bytes()), common.Bytes2Hex(bloom.Bytes())))
+	}
+	if receiptHash := types.DeriveSha(receipts, trie.NewStackTrie(nil)); receiptHash != block.ReceiptHash() {
+		divergences = append(divergences, newDivergence(block, -1, "receipts root", block.ReceiptHash().Hex(), receiptHash.Hex()))
+	}
+	return divergences
+}
+
+// compareReceipts compares the receipts of executing [block] with the
+// receipts stored for it, to find the transactions and logs that diverge.
+func compareReceipts(block *types.Block, want types.Receipts, have types.Receipts) []Divergence {
+	if len(want) != len(have) {
+		return []Divergence{newDivergence(block, -1, "receipt count", len(want), len(have))}
+	}
+	var divergences []Divergence
+	for i := range want {
+		if want[i].Status != have[i].Status {
+			divergences = append(divergences, newDivergence(block, i, "status", want[i].Status, have[i].Status))
+		}
+		if want[i].CumulativeGasUsed != have[i].CumulativeGasUsed {
+			divergences = append(divergences, newDivergence(block, i, "cumulative gas used", want[i].CumulativeGasUsed, have[i].CumulativeGasUsed))
+		}
+		if len(want[i].Logs) != len(have[i].Logs) {
+			divergences = append(divergences, newDivergence(block, i, "log count", len(want[i].Logs), len(have[i].Logs)))
+			continue
+		}
+		for j := range want[i].Logs {
+			if !equalLogs(want[i].Logs[j], have[i].Logs[j]) {
+				divergences = append(divergences, newDivergence(block, i, fmt.Sprintf("log %d", j), formatLog(want[i].Logs[j]), formatLog(have[i].Logs[j])))
+			}
+		}
+	}
+	return divergences
+}
+
+// equalLogs compares the consensus fields of two logs.
+func equalLogs(a, b *types.Log) bool {
+	if a.Address != b.Address || len(a.Topics) != len(b.Topics) || !bytes.Equal(a.Data, b.Data) {
+		return false
+	}
+	for i := range a.Topics {
+		if a.Topics[i] != b.Topics[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func formatLog(l *types.Log) string {
+	return fmt.Sprintf("{address: %s, topics: %v, data: %x}", l.Address, l.Topics, l.Data)
+}
@@ -0,0 +1,255 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"compress/gzip"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm/atomic"
+	"github.com/ava-labs/coreth/plugin/evm/upgrade/ap3"
+	"github.com/ava-labs/coreth/triedb"
+)
+
+// logCode is the init code of a contract that emits a log with 42 as data.
+var logCode = common.FromHex("602a60005260206000a000")
+
+// newTestChain returns a database with the genesis state and the two blocks
+// following it, the first of which emits a log.
+func newTestChain(t *testing.T) (ethdb.Database, []*types.Block, []types.Receipts) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &core.Genesis{
+			Config:  params.TestFlareChainConfig,
+			Alloc:   types.GenesisAlloc{addr: {Balance: new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))}},
+			BaseFee: big.NewInt(ap3.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	db := rawdb.NewMemoryDatabase()
+	tdb := triedb.NewDatabase(db, triedb.HashDefaults)
+	gspec.MustCommit(db, tdb)
+	require.NoError(t, tdb.Close())
+
+	_, blocks, receipts, err := core.GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 2, 10, func(i int, b *core.BlockGen) {
+		var tx *types.Transaction
+		switch i {
+		case 0:
+			tx = types.NewContractCreation(0, common.Big0, 100000, big.NewInt(ap3.InitialBaseFee), logCode)
+		case 1:
+			tx = types.NewTransaction(1, common.HexToAddress("0x1234"), common.Big1, 21000, big.NewInt(ap3.InitialBaseFee), nil)
+		}
+		tx, err := types.SignTx(tx, signer, key)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	})
+	require.NoError(t, err)
+	require.Len(t, receipts[0][0].Logs, 1)
+	return db, blocks, receipts
+}
+
+func TestReadBlocks(t *testing.T) {
+	_, blocks, _ := newTestChain(t)
+	dir := t.TempDir()
+
+	write := func(name string, compress bool) string {
+		path := filepath.Join(dir, name)
+		f, err := os.Create(path)
+		require.NoError(t, err)
+		defer f.Close()
+
+		var w io.Writer = f
+		if compress {
+			gz := gzip.NewWriter(f)
+			defer gz.Close()
+			w = gz
+		}
+		for _, block := range blocks {
+			require.NoError(t, rlp.Encode(w, block))
+		}
+		return path
+	}
+	for _, path := range []string{write("blocks.rlp", false), write("blocks.rlp.gz", true)} {
+		read, err := readBlocks(path)
+		require.NoError(t, err)
+		require.Len(t, read, len(blocks))
+		for i := range blocks {
+			require.Equal(t, blocks[i].Hash(), read[i].Hash())
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	db, blocks, receipts := newTestChain(t)
+	for i, block := range blocks {
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+	}
+
+	divergences, err := newReplayer(params.TestFlareChainConfig, &snow.Context{}, db).replay(blocks)
+	require.NoError(t, err)
+	require.Empty(t, divergences)
+}
+
+// TestReplayAtomicTxs checks that the atomic txs in the extra data of the
+// replayed blocks are applied to the state.
+func TestReplayAtomicTxs(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ctx   = &snow.Context{AVAXAssetID: ids.GenerateTestID()}
+		to    = common.HexToAddress("0x1234")
+		gspec = &core.Genesis{
+			Config:  params.TestFlareChainConfig,
+			BaseFee: big.NewInt(ap3.InitialBaseFee),
+		}
+		importTx = &atomic.Tx{UnsignedAtomicTx: &atomic.UnsignedImportTx{
+			ImportedInputs: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+				Asset:  avax.Asset{ID: ctx.AVAXAssetID},
+				In: &secp256k1fx.TransferInput{
+					Amt:   units.Avax,
+					Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+				},
+			}},
+			Outs: []atomic.EVMOutput{{
+				Address: to,
+				Amount:  units.Avax / 2,
+				AssetID: ctx.AVAXAssetID,
+			}},
+		}}
+	)
+	require.NoError(importTx.Sign(atomic.Codec, nil))
+	extData, err := atomic.Codec.Marshal(atomic.CodecVersion, []*atomic.Tx{importTx})
+	require.NoError(err)
+
+	db := rawdb.NewMemoryDatabase()
+	tdb := triedb.NewDatabase(db, triedb.HashDefaults)
+	gspec.MustCommit(db, tdb)
+	require.NoError(tdb.Close())
+
+	// The first block imports the funds of [importTx] to [to].
+	engine := dummy.NewFakerWithCallbacks(dummy.ConsensusCallbacks{
+		OnFinalizeAndAssemble: func(header *types.Header, _ *types.Header, state *state.StateDB, _ []*types.Transaction) ([]byte, *big.Int, *big.Int, error) {
+			if header.Number.Uint64() != 1 {
+				return nil, nil, nil, nil
+			}
+			if err := importTx.UnsignedAtomicTx.EVMStateTransfer(ctx, state); err != nil {
+				return nil, nil, nil, err
+			}
+			contribution, gasUsed, err := importTx.BlockFeeContribution(true, ctx.AVAXAssetID, header.BaseFee)
+			return extData, contribution, gasUsed, err
+		},
+	})
+	_, blocks, _, err := core.GenerateChainWithGenesis(gspec, engine, 2, 10, func(int, *core.BlockGen) {})
+	require.NoError(err)
+	require.Equal(extData, blocks[0].ExtData())
+
+	divergences, err := newReplayer(params.TestFlareChainConfig, ctx, db).replay(blocks)
+	require.NoError(err)
+	require.Empty(divergences)
+
+	// Atomic txs cannot be replayed without the AVAX asset ID.
+	_, err = newReplayer(params.TestFlareChainConfig, &snow.Context{}, db).replay(blocks)
+	require.ErrorIs(err, errMissingAVAXAssetID)
+}
+
+func TestReplayDivergences(t *testing.T) {
+	tests := []struct {
+		name string
+		// modify changes the blocks or the receipts stored for them.
+		modify     func(db ethdb.Database, blocks []*types.Block, receipts []types.Receipts) []*types.Block
+		wantFields []string
+		wantErr    bool
+	}{
+		{
+			name: "receipts root",
+			modify: func(_ ethdb.Database, blocks []*types.Block, _ []types.Receipts) []*types.Block {
+				header := blocks[1].Header()
+				header.ReceiptHash = common.Hash{1}
+				return []*types.Block{blocks[0], blocks[1].WithSeal(header)}
+			},
+			wantFields: []string{"receipts root"},
+		},
+		{
+			name: "state root of last block",
+			modify: func(_ ethdb.Database, blocks []*types.Block, _ []types.Receipts) []*types.Block {
+				header := blocks[1].Header()
+				header.Root = common.Hash{1}
+				return []*types.Block{blocks[0], blocks[1].WithSeal(header)}
+			},
+			wantFields: []string{"state root"},
+		},
+		{
+			name: "state root not in database",
+			modify: func(_ ethdb.Database, blocks []*types.Block, _ []types.Receipts) []*types.Block {
+				header := blocks[0].Header()
+				header.Root = common.Hash{1}
+				first := blocks[0].WithSeal(header)
+				header = blocks[1].Header()
+				header.ParentHash = first.Hash()
+				return []*types.Block{first, blocks[1].WithSeal(header)}
+			},
+			wantFields: []string{"state root"},
+			wantErr:    true,
+		},
+		{
+			name: "stored log",
+			modify: func(db ethdb.Database, blocks []*types.Block, receipts []types.Receipts) []*types.Block {
+				receipts[0][0].Logs[0].Data = common.Hash{1}.Bytes()
+				rawdb.WriteReceipts(db, blocks[0].Hash(), blocks[0].NumberU64(), receipts[0])
+				return blocks
+			},
+			wantFields: []string{"log 0"},
+		},
+		{
+			name: "stored status",
+			modify: func(db ethdb.Database, blocks []*types.Block, receipts []types.Receipts) []*types.Block {
+				receipts[1][0].Status = types.ReceiptStatusFailed
+				rawdb.WriteReceipts(db, blocks[1].Hash(), blocks[1].NumberU64(), receipts[1])
+				return blocks
+			},
+			wantFields: []string{"status"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, blocks, receipts := newTestChain(t)
+			blocks = test.modify(db, blocks, receipts)
+
+			divergences, err := newReplayer(params.TestFlareChainConfig, &snow.Context{}, db).replay(blocks)
+			if test.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			fields := make([]string, len(divergences))
+			for i, divergence := range divergences {
+				fields[i] = divergence.Field
+			}
+			require.Equal(t, test.wantFields, fields)
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+// segments exports ranges of accepted C-chain blocks, with their receipts and
+// atomic txs, from a stopped node's database into checksummed chunk files, and
+// imports them into another stopped node's database, verifying every block.
+// Both commands resume where an interrupted run stopped.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli/v2"
+
+	avalancheatomic "github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/pebbledb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/upgrade"
+	"github.com/ava-labs/avalanchego/utils/logging"
+
+	"github.com/ava-labs/coreth/cmd/utils"
+	"github.com/ava-labs/coreth/internal/flags"
+	"github.com/ava-labs/coreth/plugin/evm"
+)
+
+var (
+	// vmDBPrefix and sharedMemoryPrefix are the prefixes under which the node
+	// stores the databases of its chains and its shared memory.
+	vmDBPrefix         = []byte("vm")
+	sharedMemoryPrefix = []byte("shared memory")
+)
+
+var (
+	dbDirFlag = &cli.StringFlag{
+		Name:     "db-dir",
+		Usage:    "Path to the versioned database directory of the stopped node",
+		Required: true,
+	}
+	dbEngineFlag = &cli.StringFlag{
+		Name:  "db.engine",
+		Usage: "Backing database implementation of the node (leveldb or pebbledb)",
+		Value: leveldb.Name,
+	}
+	ancientDirFlag = &cli.StringFlag{
+		Name:  "ancient-dir",
+		Usage: "Root directory of the ancient freezer of the C-chain, if it is enabled (by default the ancient directory in its chain data directory)",
+	}
+	chainIDFlag = &cli.StringFlag{
+		Name:     "chain-id",
+		Usage:    "Blockchain ID of the C-chain",
+		Required: true,
+	}
+	fromFlag = &cli.Uint64Flag{
+		Name:  "from",
+		Usage: "First block to export",
+		Value: 1,
+	}
+	toFlag = &cli.Uint64Flag{
+		Name:     "to",
+		Usage:    "Last block to export",
+		Required: true,
+	}
+	chunkSizeFlag = &cli.Uint64Flag{
+		Name:  "chunk-size",
+		Usage: "Number of blocks in each chunk file",
+		Value: 10_000,
+	}
+	commitIntervalFlag = &cli.Uint64Flag{
+		Name:  "commit-interval",
+		Usage: "Atomic trie commit interval of the node",
+		Value: 4096,
+	}
+	networkIDFlag = &cli.UintFlag{
+		Name:     "network-id",
+		Usage:    "Network ID of the node",
+		Required: true,
+	}
+	avaxAssetIDFlag = &cli.StringFlag{
+		Name:     "avax-asset-id",
+		Usage:    "Asset ID of the native asset of the network",
+		Required: true,
+	}
+	genesisFlag = &cli.StringFlag{
+		Name:     "genesis",
+		Usage:    "Path to the C-chain genesis file",
+		Required: true,
+	}
+	upgradeFlag = &cli.StringFlag{
+		Name:  "upgrade",
+		Usage: "Path to the C-chain upgrade file",
+	}
+)
+
+var app = flags.NewApp("Offline C-chain segment export and import")
+
+func init() {
+	app.Name = "segments"
+	app.Commands = []*cli.Command{
+		{
+			Name:      "export",
+			Usage:     "Export a range of accepted blocks into a directory",
+			ArgsUsage: "<dir>",
+			Flags: []cli.Flag{
+				dbDirFlag,
+				dbEngineFlag,
+				ancientDirFlag,
+				chainIDFlag,
+				fromFlag,
+				toFlag,
+				chunkSizeFlag,
+				commitIntervalFlag,
+			},
+			Action: exportSegments,
+		},
+		{
+			Name:      "import",
+			Usage:     "Import the blocks exported into a directory",
+			ArgsUsage: "<dir>",
+			Flags: []cli.Flag{
+				dbDirFlag,
+				dbEngineFlag,
+				ancientDirFlag,
+				chainIDFlag,
+				networkIDFlag,
+				avaxAssetIDFlag,
+				genesisFlag,
+				upgradeFlag,
+			},
+			Action: importSegments,
+		},
+	}
+}
+
+func exportSegments(c *cli.Context) error {
+	if c.NArg() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	db, chainID, err := openDatabase(c)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return evm.ExportSegments(vmDatabase(db, chainID), c.Args().First(), evm.SegmentExportConfig{
+		From:             c.Uint64(fromFlag.Name),
+		To:               c.Uint64(toFlag.Name),
+		ChunkSize:        c.Uint64(chunkSizeFlag.Name),
+		CommitInterval:   c.Uint64(commitIntervalFlag.Name),
+		AncientDirectory: c.String(ancientDirFlag.Name),
+	})
+}
+
+func importSegments(c *cli.Context) error {
+	if c.NArg() != 1 {
+		utils.Fatalf("This command requires an argument.")
+	}
+	avaxAssetID, err := ids.FromString(c.String(avaxAssetIDFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid AVAX asset ID: %w", err)
+	}
+	genesis, err := os.ReadFile(c.String(genesisFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to read genesis: %w", err)
+	}
+	var upgradeBytes []byte
+	if path := c.String(upgradeFlag.Name); path != "" {
+		if upgradeBytes, err = os.ReadFile(path); err != nil {
+			return fmt.Errorf("failed to read upgrade: %w", err)
+		}
+	}
+	db, chainID, err := openDatabase(c)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	networkID := uint32(c.Uint(networkIDFlag.Name))
+	sharedMemory := avalancheatomic.NewMemory(prefixdb.New(sharedMemoryPrefix, db)).NewSharedMemory(chainID)
+	return evm.ImportSegments(vmDatabase(db, chainID), sharedMemory, c.Args().First(), evm.SegmentImportConfig{
+		SnowCtx: &snow.Context{
+			NetworkID:       networkID,
+			ChainID:         chainID,
+			AVAXAssetID:     avaxAssetID,
+			NetworkUpgrades: upgrade.GetConfig(networkID),
+		},
+		Genesis:          genesis,
+		Upgrade:          upgradeBytes,
+		AncientDirectory: c.String(ancientDirFlag.Name),
+	})
+}
+
+// openDatabase opens the node database and parses the C-chain ID.
+func openDatabase(c *cli.Context) (database.Database, ids.ID, error) {
+	chainID, err := ids.FromString(c.String(chainIDFlag.Name))
+	if err != nil {
+		return nil, ids.Empty, fmt.Errorf("invalid chain ID: %w", err)
+	}
+	var db database.Database
+	switch engine := c.String(dbEngineFlag.Name); engine {
+	case leveldb.Name:
+		db, err = leveldb.New(c.String(dbDirFlag.Name), nil, logging.NoLog{}, prometheus.NewRegistry())
+	case pebbledb.Name:
+		db, err = pebbledb.New(c.String(dbDirFlag.Name), nil, logging.NoLog{}, prometheus.NewRegistry())
+	default:
+		return nil, ids.Empty, fmt.Errorf("unknown database engine %q", engine)
+	}
+	if err != nil {
+		return nil, ids.Empty, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, chainID, nil
+}
+
+// vmDatabase returns the database of the VM of [chainID] in the node database.
+func vmDatabase(db database.Database, chainID ids.ID) database.Database {
+	return prefixdb.New(vmDBPrefix, prefixdb.New(chainID[:], db))
+}
+
+func main() {
+	log.SetDefault(log.NewLogger(log.NewTerminalHandlerWithLevel(os.Stderr, log.LevelInfo, true)))
+
+	if err := app.Run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
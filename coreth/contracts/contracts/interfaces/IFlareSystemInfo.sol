@@ -0,0 +1,51 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+struct ValidatorWeight {
+  bytes20 nodeID;
+  uint64 weight;
+  uint64 totalWeight;
+  uint64 pChainHeight;
+}
+
+struct DaemonMintState {
+  address daemon;
+  uint256 lastMintRequest;
+  uint256 lastMinted;
+  uint256 totalMinted;
+  uint64 lastMintBlock;
+}
+
+interface IFlareSystemInfo {
+  // getVerifiedValidatorWeight returns the pre-verified P-chain validator weight
+  // in the predicate storage slots at [index].
+  // The weight of [nodeID] and the total weight of the validator set are verified
+  // at [pChainHeight] before the block is executed, so a node that is not a
+  // validator has a weight of zero.
+  // If the predicate exists and passes verification, returns the verified weight
+  // and true.
+  // Otherwise, returns false and the empty value for the weight.
+  function getVerifiedValidatorWeight(
+    uint32 index
+  ) external view returns (ValidatorWeight calldata validatorWeight, bool valid);
+
+  // getUpgradeActivationTime returns the activation timestamp of the network upgrade
+  // configured by the chain config field [upgrade], such as "durangoBlockTimestamp",
+  // and true if the upgrade is activated at the current block.
+  // Upgrades that are not activated yet return false and a zero timestamp, as their
+  // schedule may still change.
+  // Reverts if [upgrade] is not a known network upgrade.
+  function getUpgradeActivationTime(
+    string calldata upgrade
+  ) external view returns (uint64 timestamp, bool activated);
+
+  // getDaemonMintState returns the daemon contract, the last non-zero mint
+  // request and the amount minted for it, the amount minted since this
+  // precompile was activated, and the number of the block of the last daemon
+  // invocation requesting a mint.
+  function getDaemonMintState() external view returns (DaemonMintState memory state);
+}
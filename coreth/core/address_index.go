@@ -0,0 +1,214 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// recordsCallParticipants returns whether blocks must be processed recording
+// the participants of internal calls for the address index.
+func (bc *BlockChain) recordsCallParticipants() bool {
+	return bc.cacheConfig.AddressIndexing && bc.cacheConfig.AddressIndexInternalCalls
+}
+
+// addressTxEntries returns the address index entries of [b], ordered by
+// transaction index and address. [participants] holds the participants of the
+// internal calls of each transaction, if they were recorded.
+func (bc *BlockChain) addressTxEntries(b *types.Block, participants map[int][]common.Address) []rawdb.AddressTxEntry {
+	var (
+		signer  = types.MakeSigner(bc.chainConfig, b.Number(), b.Time())
+		entries []rawdb.AddressTxEntry
+	)
+	for i, tx := range b.Transactions() {
+		roles := make(map[common.Address]rawdb.AddressRole)
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			log.Error("Failed to derive sender of accepted transaction", "hash", tx.Hash(), "err", err)
+		} else {
+			roles[from] |= rawdb.AddressRoleSender
+		}
+		switch {
+		case tx.To() != nil:
+			roles[*tx.To()] |= rawdb.AddressRoleRecipient
+		case err == nil:
+			roles[crypto.CreateAddress(from, tx.Nonce())] |= rawdb.AddressRoleRecipient
+		}
+		for _, addr := range participants[i] {
+			roles[addr] |= rawdb.AddressRoleInternal
+		}
+		for _, addr := range slices.SortedFunc(maps.Keys(roles), common.Address.Cmp) {
+			entries = append(entries, rawdb.AddressTxEntry{
+				Address:     addr,
+				BlockNumber: b.NumberU64(),
+				TxIndex:     uint32(i),
+				Roles:       roles[addr],
+			})
+		}
+	}
+	return entries
+}
+
+// initAddressIndex makes sure the address index covers a contiguous range of
+// blocks ending with the acceptor tip, so that the blocks accepted from then on
+// can be appended to it. This is checked before any accepted blocks are
+// re-processed, which appends their entries the same way.
+// An index ahead of the acceptor tip is rolled back to it, and an index behind
+// it, because indexing was disabled for a while, is extended with the blocks
+// accepted since. The index is only restarted after the acceptor tip if it was
+// never initialised, was built with a different internal call setting, or
+// cannot be extended.
+func (bc *BlockChain) initAddressIndex(lastAcceptedHash common.Hash) error {
+	tipHash, err := rawdb.ReadAcceptorTip(bc.db)
+	if err != nil {
+		return fmt.Errorf("%w: unable to get acceptor tip", err)
+	}
+	if tipHash == (common.Hash{}) {
+		tipHash = lastAcceptedHash
+	}
+	if tipHash == (common.Hash{}) {
+		tipHash = bc.genesisBlock.Hash()
+	}
+	tip := rawdb.ReadHeaderNumber(bc.db, tipHash)
+	if tip == nil {
+		return fmt.Errorf("acceptor tip %s not found", tipHash)
+	}
+
+	var (
+		tail     = rawdb.ReadAddressIndexTail(bc.db)
+		head     = rawdb.ReadAddressIndexHead(bc.db)
+		internal = rawdb.ReadAddressIndexInternal(bc.db)
+		history  = bc.cacheConfig.TransactionHistory
+	)
+	switch {
+	case tail == nil || head == nil:
+		log.Info("Initialising address index", "tail", *tip+1, "internal", bc.cacheConfig.AddressIndexInternalCalls)
+	case internal != bc.cacheConfig.AddressIndexInternalCalls:
+		log.Warn("Address index internal call setting changed, restarting it", "old", internal, "new", bc.cacheConfig.AddressIndexInternalCalls)
+	case *head > *tip:
+		log.Warn("Address index is ahead of the acceptor tip, rolling it back", "head", *head, "tip", *tip)
+		return bc.rollbackAddressIndex(*tail, *head, *tip)
+	case *head < *tip && history != 0 && *tip-*head >= history:
+		log.Warn("Address index is behind the acceptor tip by more than the transaction history, restarting it", "head", *head, "tip", *tip)
+	case *head < *tip:
+		log.Warn("Address index is behind the acceptor tip, indexing the missing blocks", "head", *head, "tip", *tip)
+		extended, err := bc.extendAddressIndex(*head, *tip)
+		if err != nil || extended {
+			return err
+		}
+		log.Warn("Missing blocks to extend the address index, restarting it", "head", *head, "tip", *tip)
+	default:
+		log.Info("Loaded address index", "tail", *tail, "head", *head, "internal", internal)
+		return nil
+	}
+	return bc.resetAddressIndex(*tip)
+}
+
+// rollbackAddressIndex removes the address index entries of the blocks after
+// [tip], moving the head of the index from [head] back to [tip].
+func (bc *BlockChain) rollbackAddressIndex(tail, head, tip uint64) error {
+	batch := bc.db.NewBatch()
+	for number := max(tail, tip+1); number <= head; number++ {
+		rawdb.DeleteAddressTxEntries(batch, number, rawdb.ReadAddressBlockEntries(bc.db, number))
+	}
+	if tail > tip {
+		rawdb.WriteAddressIndexTail(batch, tip+1)
+	}
+	rawdb.WriteAddressIndexHead(batch, tip)
+	return batch.Write()
+}
+
+// extendAddressIndex appends the address index entries of the accepted blocks
+// after [head] up to [tip], moving the head of the index forward. As the blocks
+// are not executed, the participants of their internal calls are not indexed.
+// Returns false if an accepted block is missing.
+func (bc *BlockChain) extendAddressIndex(head, tip uint64) (bool, error) {
+	if bc.cacheConfig.AddressIndexInternalCalls {
+		log.Warn("Internal calls of the blocks accepted while address indexing was disabled are not indexed", "from", head+1, "to", tip)
+	}
+	batch := bc.db.NewBatch()
+	for number := head + 1; number <= tip; number++ {
+		block := rawdb.ReadBlock(bc.db, rawdb.ReadCanonicalHash(bc.db, number), number)
+		if block == nil {
+			return false, nil
+		}
+		rawdb.WriteAddressTxEntries(batch, number, bc.addressTxEntries(block, nil))
+		if batch.ValueSize() > ethdb.IdealBatchSize || number == tip {
+			rawdb.WriteAddressIndexHead(batch, number)
+			if err := batch.Write(); err != nil {
+				return false, fmt.Errorf("failed to write address index batch: %w", err)
+			}
+			batch.Reset()
+		}
+	}
+	log.Info("Extended address index", "head", tip)
+	return true, nil
+}
+
+// resetAddressIndex removes all address index entries and restarts the index
+// after the block at [number].
+func (bc *BlockChain) resetAddressIndex(number uint64) error {
+	bc.txIndexTailLock.Lock()
+	defer bc.txIndexTailLock.Unlock()
+
+	if rawdb.ReadAddressIndexTail(bc.db) != nil {
+		if err := rawdb.DeleteAddressIndex(bc.db); err != nil {
+			return fmt.Errorf("failed to delete address index: %w", err)
+		}
+	}
+	batch := bc.db.NewBatch()
+	rawdb.WriteAddressIndexTail(batch, number+1)
+	rawdb.WriteAddressIndexHead(batch, number)
+	rawdb.WriteAddressIndexInternal(batch, bc.cacheConfig.AddressIndexInternalCalls)
+	return batch.Write()
+}
+
+// unindexAddresses removes the address index entries of the blocks before
+// [newTail], moving the tail of the index forward. It returns early if [stop]
+// is closed. The caller must hold [txIndexTailLock].
+// It is run by the transaction indexer, which is running whenever the index is
+// bounded by a non-zero TransactionHistory. Starting from the tail recorded by
+// the index, it also removes the entries left behind by a larger history.
+func (bc *BlockChain) unindexAddresses(newTail uint64, stop chan struct{}) {
+	tail, head := rawdb.ReadAddressIndexTail(bc.db), rawdb.ReadAddressIndexHead(bc.db)
+	if tail == nil || head == nil {
+		return
+	}
+	newTail = min(newTail, *head+1)
+	if *tail >= newTail {
+		return
+	}
+
+	batch := bc.db.NewBatch()
+	flush := func(tail uint64) {
+		rawdb.WriteAddressIndexTail(batch, tail)
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed to write address unindexing batch", "err", err)
+		}
+		batch.Reset()
+	}
+	for number := *tail; number < newTail; number++ {
+		select {
+		case <-stop:
+			flush(number)
+			return
+		default:
+		}
+		rawdb.DeleteAddressTxEntries(batch, number, rawdb.ReadAddressBlockEntries(bc.db, number))
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			flush(number + 1)
+		}
+	}
+	flush(newTail)
+	log.Debug("Unindexed address transactions", "tail", newTail)
+}
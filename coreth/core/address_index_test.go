@@ -0,0 +1,256 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	addressIndexKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	addressIndexAddr   = crypto.PubkeyToAddress(addressIndexKey.PublicKey)
+
+	// addressIndexCaller holds a contract calling addressIndexCallee with all its gas.
+	addressIndexCaller = common.HexToAddress("0xaaaa")
+	addressIndexCallee = common.HexToAddress("0xbbbb")
+	addressIndexOther  = common.HexToAddress("0xcccc")
+	addressIndexCreate = crypto.CreateAddress(addressIndexAddr, 2)
+)
+
+// addressIndexConfig returns a pruning cache config with the address index
+// enabled.
+func addressIndexConfig(internal bool, history uint64) *CacheConfig {
+	config := *pruningConfig
+	config.AddressIndexing = true
+	config.AddressIndexInternalCalls = internal
+	config.TransactionHistory = history
+	return &config
+}
+
+// newAddressIndexChain returns the genesis and the blocks of a chain where:
+//   - block 1 calls addressIndexCaller, which calls addressIndexCallee
+//   - block 2 transfers to addressIndexOther
+//   - block 3 creates a contract
+//   - block 4 transfers to addressIndexCallee
+func newAddressIndexChain(t *testing.T) (*Genesis, []*types.Block) {
+	gspec := &Genesis{
+		Config: params.TestFlareChainConfig,
+		Alloc: types.GenesisAlloc{
+			addressIndexAddr: {Balance: big.NewInt(params.Ether)},
+			// CALL(gas, 0xbbbb, 0, 0, 0, 0, 0)
+			addressIndexCaller: {Code: common.FromHex("6000600060006000600061bbbb5af100")},
+		},
+	}
+	signer := types.LatestSigner(gspec.Config)
+	_, blocks, _, err := GenerateChainWithGenesis(gspec, dummy.NewFakerWithCallbacks(TestCallbacks), 4, 10, func(i int, b *BlockGen) {
+		var to *common.Address
+		switch i {
+		case 0:
+			to = &addressIndexCaller
+		case 1:
+			to = &addressIndexOther
+		case 3:
+			to = &addressIndexCallee
+		}
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    b.TxNonce(addressIndexAddr),
+			To:       to,
+			Value:    big.NewInt(1000),
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+		}), signer, addressIndexKey)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	})
+	require.NoError(t, err)
+	return gspec, blocks
+}
+
+// addressTxs returns all address index entries of [addr].
+func addressTxs(db ethdb.Iteratee, addr common.Address) []rawdb.AddressTxEntry {
+	return rawdb.ReadAddressTxEntries(db, addr, 0, 0, math.MaxUint64, math.MaxInt)
+}
+
+// requireAddressIndex checks the address index of the chain built by
+// newAddressIndexChain.
+func requireAddressIndex(t *testing.T, db ethdb.Database, internal bool) {
+	entry := func(addr common.Address, number uint64, roles rawdb.AddressRole) rawdb.AddressTxEntry {
+		return rawdb.AddressTxEntry{Address: addr, BlockNumber: number, Roles: roles}
+	}
+	sent := make([]rawdb.AddressTxEntry, 0, 4)
+	for number := uint64(1); number <= 4; number++ {
+		sent = append(sent, entry(addressIndexAddr, number, rawdb.AddressRoleSender))
+	}
+	require.Equal(t, sent, addressTxs(db, addressIndexAddr))
+	require.Equal(t, []rawdb.AddressTxEntry{entry(addressIndexOther, 2, rawdb.AddressRoleRecipient)}, addressTxs(db, addressIndexOther))
+	require.Equal(t, []rawdb.AddressTxEntry{entry(addressIndexCreate, 3, rawdb.AddressRoleRecipient)}, addressTxs(db, addressIndexCreate))
+
+	if internal {
+		require.Equal(t, []rawdb.AddressTxEntry{
+			entry(addressIndexCaller, 1, rawdb.AddressRoleRecipient|rawdb.AddressRoleInternal),
+		}, addressTxs(db, addressIndexCaller))
+		require.Equal(t, []rawdb.AddressTxEntry{
+			entry(addressIndexCallee, 1, rawdb.AddressRoleInternal),
+			entry(addressIndexCallee, 4, rawdb.AddressRoleRecipient),
+		}, addressTxs(db, addressIndexCallee))
+	} else {
+		require.Equal(t, []rawdb.AddressTxEntry{entry(addressIndexCaller, 1, rawdb.AddressRoleRecipient)}, addressTxs(db, addressIndexCaller))
+		require.Equal(t, []rawdb.AddressTxEntry{entry(addressIndexCallee, 4, rawdb.AddressRoleRecipient)}, addressTxs(db, addressIndexCallee))
+	}
+}
+
+func TestAddressIndex(t *testing.T) {
+	for _, internal := range []bool{false, true} {
+		gspec, blocks := newAddressIndexChain(t)
+		db := rawdb.NewMemoryDatabase()
+		chain, err := createBlockChain(db, addressIndexConfig(internal, 0), gspec, common.Hash{})
+		require.NoError(t, err)
+
+		_, err = chain.InsertChain(blocks)
+		require.NoError(t, err)
+		for _, block := range blocks {
+			require.NoError(t, chain.Accept(block))
+		}
+		chain.DrainAcceptorQueue()
+		chain.Stop()
+		requireAddressIndex(t, db, internal)
+
+		// Restarting with the same settings keeps the index.
+		lastAccepted := blocks[len(blocks)-1].Hash()
+		chain, err = createBlockChain(db, addressIndexConfig(internal, 0), gspec, lastAccepted)
+		require.NoError(t, err)
+		chain.Stop()
+		requireAddressIndex(t, db, internal)
+		require.Equal(t, uint64(1), *rawdb.ReadAddressIndexTail(db))
+
+		// Restarting with a different internal call setting restarts the index.
+		chain, err = createBlockChain(db, addressIndexConfig(!internal, 0), gspec, lastAccepted)
+		require.NoError(t, err)
+		chain.Stop()
+		require.Empty(t, addressTxs(db, addressIndexAddr))
+		require.Equal(t, uint64(5), *rawdb.ReadAddressIndexTail(db))
+		require.Equal(t, uint64(4), *rawdb.ReadAddressIndexHead(db))
+		require.Equal(t, !internal, rawdb.ReadAddressIndexInternal(db))
+	}
+}
+
+func TestAddressIndexExtend(t *testing.T) {
+	gspec, blocks := newAddressIndexChain(t)
+	db := rawdb.NewMemoryDatabase()
+	chain, err := createBlockChain(db, addressIndexConfig(false, 0), gspec, common.Hash{})
+	require.NoError(t, err)
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	for _, block := range blocks[:2] {
+		require.NoError(t, chain.Accept(block))
+	}
+	chain.DrainAcceptorQueue()
+	chain.Stop()
+
+	// Accept the remaining blocks with address indexing disabled.
+	config := addressIndexConfig(false, 0)
+	config.AddressIndexing = false
+	chain, err = createBlockChain(db, config, gspec, blocks[1].Hash())
+	require.NoError(t, err)
+	_, err = chain.InsertChain(blocks[2:])
+	require.NoError(t, err)
+	for _, block := range blocks[2:] {
+		require.NoError(t, chain.Accept(block))
+	}
+	chain.DrainAcceptorQueue()
+	chain.Stop()
+	require.Equal(t, uint64(2), *rawdb.ReadAddressIndexHead(db))
+
+	// Re-enabling address indexing indexes the blocks accepted meanwhile.
+	chain, err = createBlockChain(db, addressIndexConfig(false, 0), gspec, blocks[len(blocks)-1].Hash())
+	require.NoError(t, err)
+	chain.Stop()
+	require.Equal(t, uint64(1), *rawdb.ReadAddressIndexTail(db))
+	require.Equal(t, uint64(4), *rawdb.ReadAddressIndexHead(db))
+	requireAddressIndex(t, db, false)
+
+	// An index ahead of the acceptor tip is rolled back to it.
+	rawdb.WriteAddressIndexHead(db, 6)
+	rawdb.WriteAddressTxEntries(db, 6, []rawdb.AddressTxEntry{{Address: addressIndexAddr, BlockNumber: 6}})
+	chain, err = createBlockChain(db, addressIndexConfig(false, 0), gspec, blocks[len(blocks)-1].Hash())
+	require.NoError(t, err)
+	chain.Stop()
+	require.Equal(t, uint64(4), *rawdb.ReadAddressIndexHead(db))
+	requireAddressIndex(t, db, false)
+}
+
+func TestAddressIndexUngracefulShutdown(t *testing.T) {
+	gspec, blocks := newAddressIndexChain(t)
+	db := rawdb.NewMemoryDatabase()
+	chain, err := createBlockChain(db, addressIndexConfig(true, 0), gspec, common.Hash{})
+	require.NoError(t, err)
+
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	for i, block := range blocks {
+		require.NoError(t, chain.Accept(block))
+		if i == 1 {
+			// Kill the async accepted block processor after block 2 so that
+			// the remaining blocks are only indexed when re-processed.
+			chain.DrainAcceptorQueue()
+			chain.stopAcceptor()
+			chain.acceptorQueue = nil
+		}
+	}
+	chain.Stop()
+	require.Equal(t, uint64(2), *rawdb.ReadAddressIndexHead(db))
+	require.Len(t, addressTxs(db, addressIndexAddr), 2)
+
+	// The state is not committed with pruning enabled, so restarting
+	// re-processes all blocks, indexing the ones after the acceptor tip with
+	// their internal calls.
+	chain, err = createBlockChain(db, addressIndexConfig(true, 0), gspec, blocks[len(blocks)-1].Hash())
+	require.NoError(t, err)
+	defer chain.Stop()
+	require.Equal(t, uint64(4), *rawdb.ReadAddressIndexHead(db))
+	requireAddressIndex(t, db, true)
+}
+
+func TestAddressIndexUnindexing(t *testing.T) {
+	gspec, blocks := newAddressIndexChain(t)
+	db := rawdb.NewMemoryDatabase()
+	chain, err := createBlockChain(db, addressIndexConfig(true, 0), gspec, common.Hash{})
+	require.NoError(t, err)
+
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		require.NoError(t, chain.Accept(block))
+	}
+	chain.DrainAcceptorQueue()
+	chain.Stop()
+
+	// Restarting with a transaction history of 2 blocks unindexes the
+	// entries of blocks 1 and 2.
+	chain, err = createBlockChain(db, addressIndexConfig(true, 2), gspec, blocks[len(blocks)-1].Hash())
+	require.NoError(t, err)
+	defer chain.Stop()
+
+	require.Eventually(t, func() bool {
+		tail := rawdb.ReadAddressIndexTail(db)
+		return tail != nil && *tail == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Len(t, addressTxs(db, addressIndexAddr), 2)
+	require.Empty(t, addressTxs(db, addressIndexCaller))
+	require.Empty(t, addressTxs(db, addressIndexOther))
+	require.Nil(t, rawdb.ReadAddressBlockEntries(db, 1))
+	require.Len(t, addressTxs(db, addressIndexCallee), 1)
+}
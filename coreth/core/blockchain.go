@@ -167,6 +167,10 @@ type CacheConfig struct {
 	AcceptedCacheSize               int     // Depth of accepted headers cache and accepted logs cache at the accepted tip
 	TransactionHistory              uint64  // Number of recent blocks for which to maintain transaction lookup indices
 	SkipTxIndexing                  bool    // Whether to skip transaction indexing
+	DaemonMintIndexing              bool    // Whether to persist the daemon invocations of accepted blocks
+	StateConnectorVoteIndexing      bool    // Whether to persist the state connector round votes of accepted blocks
+	AddressIndexing                 bool    // Whether to maintain an index of the transactions of each address
+	AddressIndexInternalCalls       bool    // Whether the address index includes the participants of internal calls
 	StateHistory                    uint64  // Number of blocks from head whose state histories are reserved.
 	StateScheme                     string  // Scheme used to store ethereum states and merkle tree nodes on top
 
@@ -274,6 +278,13 @@ type BlockChain struct {
 	txLookupCache *lru.Cache[common.Hash, txLookup]         // Cache for the most recent transaction lookup data.
 	badBlocks     *lru.Cache[common.Hash, *badBlock]        // Cache for bad blocks
 
+	// [daemonMintsCache] holds the daemon invocations of processed blocks until they are accepted or rejected.
+	daemonMintsCache *lru.Cache[common.Hash, []*types.DaemonMint]
+	// [roundVotesCache] holds the state connector rounds finalised by processed blocks until they are accepted or rejected.
+	roundVotesCache *lru.Cache[common.Hash, []*types.RoundVotes]
+	// [callParticipantsCache] holds the internal call participants of processed blocks until they are accepted or rejected.
+	callParticipantsCache *lru.Cache[common.Hash, map[int][]common.Address]
+
 	stopping atomic.Bool // false if chain is running, true when stopped
 
 	engine    consensus.Engine
@@ -361,21 +372,24 @@ func NewBlockChain(
 	log.Info("")
 
 	bc := &BlockChain{
-		chainConfig:       chainConfig,
-		cacheConfig:       cacheConfig,
-		db:                db,
-		triedb:            triedb,
-		bodyCache:         lru.NewCache[common.Hash, *types.Body](bodyCacheLimit),
-		receiptsCache:     lru.NewCache[common.Hash, []*types.Receipt](receiptsCacheLimit),
-		blockCache:        lru.NewCache[common.Hash, *types.Block](blockCacheLimit),
-		txLookupCache:     lru.NewCache[common.Hash, txLookup](txLookupCacheLimit),
-		badBlocks:         lru.NewCache[common.Hash, *badBlock](badBlockLimit),
-		engine:            engine,
-		vmConfig:          vmConfig,
-		senderCacher:      NewTxSenderCacher(runtime.NumCPU()),
-		acceptorQueue:     make(chan *types.Block, cacheConfig.AcceptorQueueLimit),
-		quit:              make(chan struct{}),
-		acceptedLogsCache: NewFIFOCache[common.Hash, [][]*types.Log](cacheConfig.AcceptedCacheSize),
+		chainConfig:           chainConfig,
+		cacheConfig:           cacheConfig,
+		db:                    db,
+		triedb:                triedb,
+		bodyCache:             lru.NewCache[common.Hash, *types.Body](bodyCacheLimit),
+		receiptsCache:         lru.NewCache[common.Hash, []*types.Receipt](receiptsCacheLimit),
+		daemonMintsCache:      lru.NewCache[common.Hash, []*types.DaemonMint](blockCacheLimit),
+		roundVotesCache:       lru.NewCache[common.Hash, []*types.RoundVotes](blockCacheLimit),
+		callParticipantsCache: lru.NewCache[common.Hash, map[int][]common.Address](blockCacheLimit),
+		blockCache:            lru.NewCache[common.Hash, *types.Block](blockCacheLimit),
+		txLookupCache:         lru.NewCache[common.Hash, txLookup](txLookupCacheLimit),
+		badBlocks:             lru.NewCache[common.Hash, *badBlock](badBlockLimit),
+		engine:                engine,
+		vmConfig:              vmConfig,
+		senderCacher:          NewTxSenderCacher(runtime.NumCPU()),
+		acceptorQueue:         make(chan *types.Block, cacheConfig.AcceptorQueueLimit),
+		quit:                  make(chan struct{}),
+		acceptedLogsCache:     NewFIFOCache[common.Hash, [][]*types.Log](cacheConfig.AcceptedCacheSize),
 	}
 	bc.stateCache = state.NewDatabaseWithNodeDB(bc.db, bc.triedb)
 	bc.validator = NewBlockValidator(chainConfig, bc, engine)
@@ -395,6 +409,14 @@ func NewBlockChain(
 	// Create the state manager
 	bc.stateManager = NewTrieWriter(bc.triedb, cacheConfig)
 
+	// Make sure the address index covers a contiguous range up to the acceptor
+	// tip before any accepted blocks are re-processed.
+	if bc.cacheConfig.AddressIndexing {
+		if err := bc.initAddressIndex(lastAcceptedHash); err != nil {
+			return nil, err
+		}
+	}
+
 	// Re-generate current block state if it is missing
 	if err := bc.loadLastState(lastAcceptedHash); err != nil {
 		return nil, err
@@ -453,6 +475,8 @@ func NewBlockChain(
 // writeBlockAcceptedIndices writes any indices that must be persisted for accepted block.
 // This includes the following:
 // - transaction lookup indices
+// - daemon invocations (if enabled)
+// - address index entries (if enabled)
 // - updating the acceptor tip index
 func (bc *BlockChain) writeBlockAcceptedIndices(b *types.Block) error {
 	batch := bc.db.NewBatch()
@@ -469,6 +493,28 @@ func (bc *BlockChain) batchBlockAcceptedIndices(batch ethdb.Batch, b *types.Bloc
 	if !bc.cacheConfig.SkipTxIndexing {
 		rawdb.WriteTxLookupEntriesByBlock(batch, b)
 	}
+	if mints, ok := bc.daemonMintsCache.Get(b.Hash()); ok {
+		markDaemonMintsAccepted(mints)
+		if bc.cacheConfig.DaemonMintIndexing && len(mints) > 0 {
+			rawdb.WriteDaemonMints(batch, b.NumberU64(), mints)
+		}
+		bc.daemonMintsCache.Remove(b.Hash())
+	}
+	if votes, ok := bc.roundVotesCache.Get(b.Hash()); ok {
+		if bc.cacheConfig.StateConnectorVoteIndexing {
+			for _, v := range votes {
+				v.BlockNumber = b.NumberU64()
+				rawdb.WriteRoundVotes(batch, v)
+			}
+		}
+		bc.roundVotesCache.Remove(b.Hash())
+	}
+	if bc.cacheConfig.AddressIndexing {
+		participants, _ := bc.callParticipantsCache.Get(b.Hash())
+		rawdb.WriteAddressTxEntries(batch, b.NumberU64(), bc.addressTxEntries(b, participants))
+		rawdb.WriteAddressIndexHead(batch, b.NumberU64())
+		bc.callParticipantsCache.Remove(b.Hash())
+	}
 	if err := rawdb.WriteAcceptorTip(batch, b.Hash()); err != nil {
 		return fmt.Errorf("%w: failed to write acceptor tip key", err)
 	}
@@ -1087,6 +1133,9 @@ func (bc *BlockChain) Reject(block *types.Block) error {
 
 	// Remove the block from the block cache (ignore return value of whether it was in the cache)
 	_ = bc.blockCache.Remove(block.Hash())
+	_ = bc.daemonMintsCache.Remove(block.Hash())
+	_ = bc.roundVotesCache.Remove(block.Hash())
+	_ = bc.callParticipantsCache.Remove(block.Hash())
 
 	return nil
 }
@@ -1311,7 +1360,9 @@ func (bc *BlockChain) insertBlock(block *types.Block, writes bool) error {
 
 	// Process block using the parent state as reference point
 	pstart := time.Now()
-	receipts, logs, usedGas, err := bc.processor.Process(block, parent, statedb, bc.vmConfig)
+	vmConfig := bc.vmConfig
+	vmConfig.RecordCallParticipants = bc.recordsCallParticipants()
+	receipts, logs, usedGas, err := bc.processor.Process(block, parent, statedb, vmConfig)
 	if serr := statedb.Error(); serr != nil {
 		log.Error("statedb error encountered", "err", serr, "number", block.Number(), "hash", block.Hash())
 	}
@@ -1361,6 +1412,11 @@ func (bc *BlockChain) insertBlock(block *types.Block, writes bool) error {
 	if err := bc.writeBlockAndSetHead(block, receipts, logs, statedb); err != nil {
 		return err
 	}
+	bc.daemonMintsCache.Add(block.Hash(), statedb.DaemonMints())
+	bc.roundVotesCache.Add(block.Hash(), statedb.RoundVotes())
+	if bc.recordsCallParticipants() {
+		bc.callParticipantsCache.Add(block.Hash(), statedb.CallParticipants())
+	}
 	// Update the metrics touched during block commit
 	accountCommitTimer.Inc(statedb.AccountCommits.Milliseconds())   // Account commits are complete, we can mark them
 	storageCommitTimer.Inc(statedb.StorageCommits.Milliseconds())   // Storage commits are complete, we can mark them
@@ -1669,7 +1725,7 @@ func (bc *BlockChain) reprocessBlock(parent *types.Block, current *types.Block)
 	defer statedb.StopPrefetcher()
 
 	// Process previously stored block
-	receipts, _, usedGas, err := bc.processor.Process(current, parent.Header(), statedb, vm.Config{})
+	receipts, _, usedGas, err := bc.processor.Process(current, parent.Header(), statedb, vm.Config{RecordCallParticipants: bc.recordsCallParticipants()})
 	if err != nil {
 		return common.Hash{}, fmt.Errorf("failed to re-process block (%s: %d): %v", current.Hash().Hex(), current.NumberU64(), err)
 	}
@@ -1678,6 +1734,11 @@ func (bc *BlockChain) reprocessBlock(parent *types.Block, current *types.Block)
 	if err := bc.validator.ValidateState(current, statedb, receipts, usedGas); err != nil {
 		return common.Hash{}, fmt.Errorf("failed to validate state while re-processing block (%s: %d): %v", current.Hash().Hex(), current.NumberU64(), err)
 	}
+	bc.daemonMintsCache.Add(current.Hash(), statedb.DaemonMints())
+	bc.roundVotesCache.Add(current.Hash(), statedb.RoundVotes())
+	if bc.recordsCallParticipants() {
+		bc.callParticipantsCache.Add(current.Hash(), statedb.CallParticipants())
+	}
 	log.Debug("Processed block", "block", current.Hash(), "number", current.NumberU64())
 
 	// Commit all cached state changes into underlying memory database.
@@ -2053,6 +2114,13 @@ func (bc *BlockChain) ResetToStateSyncedBlock(block *types.Block) error {
 	if bc.cacheConfig.TransactionHistory != 0 {
 		bc.repairTxIndexTail(block.NumberU64())
 	}
+	// Blocks before the state synced block are never executed, so the address
+	// index restarts after it.
+	if bc.cacheConfig.AddressIndexing {
+		if err := bc.resetAddressIndex(block.NumberU64()); err != nil {
+			return err
+		}
+	}
 
 	// Update all in-memory chain markers
 	bc.lastAccepted = block
@@ -6,121 +6,49 @@ package core
 import (
 	"bytes"
 	"fmt"
-	"math"
 	"math/big"
-	"os"
-	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/holiman/uint256"
 
+	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/params"
-	"github.com/ava-labs/coreth/utils"
+	"github.com/ava-labs/coreth/precompile/contracts/systeminfo"
 )
 
 var (
-	// Define activation times for submitter contract
-	submitterContractActivationTimeFlare  = uint64(time.Date(2024, time.March, 26, 12, 0, 0, 0, time.UTC).Unix())
-	submitterContractActivationTimeCostwo = uint64(time.Date(2024, time.March, 7, 12, 0, 0, 0, time.UTC).Unix())
-
-	submitterContractActivationTimeSongbird = uint64(time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC).Unix())
-	submitterContractActivationTimeCoston   = uint64(time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC).Unix())
-
-	// Define ftso and submitter contract addresses
-	prioritisedFTSOContractAddress = common.HexToAddress("0x1000000000000000000000000000000000000003")
-
-	prioritisedSubmitterContractAddress    = common.HexToAddress("0x2cA6571Daa15ce734Bbd0Bf27D5C9D16787fc33f") // for flare, costwo, songbird and coston
-	prioritisedSubmitterContractAddressEnv = common.HexToAddress(os.Getenv("SUBMITTER_CONTRACT_ADDRESS"))      // for local and staging chains
-
-	// Define data prefixes for submitter and prioritized ftso contracts
-	submitterDataPrefixes = [][4]byte{
-		{0x6c, 0x53, 0x2f, 0xae},
-		{0x9d, 0x00, 0xc9, 0xfd},
-		{0xe1, 0xb1, 0x57, 0xe7},
-		{0x57, 0xee, 0xd5, 0x80},
-		{0x83, 0x3b, 0xf6, 0xc0},
-	}
-
-	prioritisedFTSOContractDataPrefixesFlareNetworks = [][4]byte{
-		{0x8f, 0xc6, 0xf6, 0x67},
-		{0xe2, 0xdb, 0x5a, 0x52},
-	}
-
-	prioritisedFTSOContractDataPrefixesSongbirdNetworks = [][4]byte{
-		{0xc5, 0xad, 0xc5, 0x39},
-		{0x60, 0x84, 0x8b, 0x44},
-	}
+	daemonCallsCounter      = metrics.NewRegisteredCounter("flare/daemon/calls", nil)
+	daemonRevertsCounter    = metrics.NewRegisteredCounter("flare/daemon/reverts", nil)
+	daemonMintsCounter      = metrics.NewRegisteredCounter("flare/daemon/mints", nil)
+	daemonMintedGweiCounter = metrics.NewRegisteredCounter("flare/daemon/minted/gwei", nil)
+	daemonMintHistogram     = metrics.NewRegisteredHistogram("flare/daemon/mint/gwei", nil, metrics.NewExpDecaySample(1028, 0.015))
+	daemonGasUsedHistogram  = metrics.NewRegisteredHistogram("flare/daemon/gas/used", nil, metrics.NewExpDecaySample(1028, 0.015))
 )
 
-const (
-	prioritisedCallDataCap = 4500 // 4500 bytes
-)
-
-type prioritisedParams struct {
-	submitterActivationTime  uint64
-	submitterAddress         common.Address
-	maxGasLimit              uint64
-	dataPrefixActivationTime uint64
-	submitterDataPrefixes    [][4]byte
-	ftsoDataPrefixes         [][4]byte
+// daemonHook calls the daemon and mints the requested amount after every
+// successful message.
+var daemonHook = &SystemHook{
+	Name:  "daemon",
+	Stage: SystemHookAfterFees,
+	Match: func(*SystemHookContext) bool { return true },
+	Handle: func(st *StateTransition, ctx *SystemHookContext) error {
+		mint := atomicDaemonAndMint(st, log.Root())
+		if recorder, ok := st.state.(daemonMintRecorder); ok {
+			recorder.AddDaemonMint(mint)
+		}
+		// Expose the invocation to contracts through the system info
+		// precompile once it is enabled.
+		if st.evm.ChainConfig().IsPrecompileEnabled(systeminfo.ContractAddress, ctx.Timestamp) {
+			daemonContract := common.HexToAddress(GetDaemonContractAddr(ctx.Timestamp))
+			systeminfo.StoreDaemonMint(st.state, daemonContract, mint.MintRequest, mint.Minted, st.evm.Context.BlockNumber.Uint64())
+		}
+		return nil
+	},
 }
 
-var (
-	prioritisedContractVariants = utils.NewChainValue(&prioritisedParams{
-		0, common.Address{}, 0, 0, [][4]byte{}, [][4]byte{},
-	}).
-		AddValue(params.FlareChainID, &prioritisedParams{
-			submitterContractActivationTimeFlare,
-			prioritisedSubmitterContractAddress,
-			3000000,
-			uint64(time.Date(2024, time.October, 10, 15, 0, 0, 0, time.UTC).Unix()),
-			submitterDataPrefixes,
-			prioritisedFTSOContractDataPrefixesFlareNetworks,
-		}).
-		AddValue(params.CostwoChainID, &prioritisedParams{
-			submitterContractActivationTimeCostwo,
-			prioritisedSubmitterContractAddress,
-			3000000,
-			uint64(time.Date(2024, time.October, 10, 10, 0, 0, 0, time.UTC).Unix()),
-			submitterDataPrefixes,
-			prioritisedFTSOContractDataPrefixesFlareNetworks,
-		}).
-		AddValue(params.SongbirdChainID, &prioritisedParams{
-			submitterContractActivationTimeSongbird,
-			prioritisedSubmitterContractAddress,
-			math.MaxUint64,
-			uint64(time.Date(2024, time.October, 10, 13, 0, 0, 0, time.UTC).Unix()),
-			submitterDataPrefixes,
-			prioritisedFTSOContractDataPrefixesSongbirdNetworks,
-		}).
-		AddValue(params.CostonChainID, &prioritisedParams{
-			submitterContractActivationTimeCoston,
-			prioritisedSubmitterContractAddress,
-			math.MaxUint64,
-			uint64(time.Date(2024, time.October, 10, 8, 0, 0, 0, time.UTC).Unix()),
-			submitterDataPrefixes,
-			prioritisedFTSOContractDataPrefixesSongbirdNetworks,
-		}).
-		AddValue(params.LocalFlareChainID, &prioritisedParams{
-			0,
-			prioritisedSubmitterContractAddressEnv,
-			3000000,
-			0,
-			[][4]byte{},
-			[][4]byte{},
-		}).
-		AddValue(params.LocalChainID, &prioritisedParams{
-			0,
-			prioritisedSubmitterContractAddressEnv,
-			math.MaxUint64,
-			0,
-			[][4]byte{},
-			[][4]byte{},
-		})
-)
-
 // Define errors
 type ErrInvalidDaemonData struct{}
 
@@ -149,6 +77,12 @@ type EVMCaller interface {
 	AddBalance(addr common.Address, amount *uint256.Int)
 }
 
+// daemonMintRecorder is implemented by state databases that keep a record of
+// the daemon invocations made while processing a block.
+type daemonMintRecorder interface {
+	AddDaemonMint(mint *types.DaemonMint)
+}
+
 func GetDaemonGasMultiplier(blockTime uint64) uint64 {
 	switch {
 	default:
@@ -170,28 +104,73 @@ func GetDaemonSelector(blockTime uint64) []byte {
 	}
 }
 
-func IsPrioritisedContractCall(chainID *big.Int, blockTime uint64, to *common.Address, data []byte, ret []byte, initialGas uint64) bool {
-	if to == nil || chainID == nil {
-		return false
+// PrioritisationReason identifies the rule that decided whether a call is
+// charged the nominal prioritised fee.
+type PrioritisationReason string
+
+const (
+	PrioritisationNotConfigured           PrioritisationReason = "notConfigured"
+	PrioritisationContractCreation        PrioritisationReason = "contractCreation"
+	PrioritisationExecutionFailed         PrioritisationReason = "executionFailed"
+	PrioritisationGasLimitExceeded        PrioritisationReason = "gasLimitExceeded"
+	PrioritisationFTSOCall                PrioritisationReason = "ftsoCall"
+	PrioritisationFTSOPrefixMismatch      PrioritisationReason = "ftsoPrefixMismatch"
+	PrioritisationSubmitterNotActivated   PrioritisationReason = "submitterNotActivated"
+	PrioritisationZeroReturnValue         PrioritisationReason = "zeroReturnValue"
+	PrioritisationSubmitterCall           PrioritisationReason = "submitterCall"
+	PrioritisationCallDataCapExceeded     PrioritisationReason = "callDataCapExceeded"
+	PrioritisationSubmitterPrefixMismatch PrioritisationReason = "submitterPrefixMismatch"
+	PrioritisationNotPrioritisedContract  PrioritisationReason = "notPrioritisedContract"
+)
+
+// IsPrioritisedContractCall returns true if a call to [to] is charged the
+// nominal prioritised fee under the prioritised contract config of [config]
+// in effect at [blockTime].
+func IsPrioritisedContractCall(config *params.ChainConfig, blockTime uint64, to *common.Address, data []byte, ret []byte, initialGas uint64) bool {
+	prioritised, _ := ExplainPrioritisedContractCall(config, blockTime, to, data, ret, initialGas)
+	return prioritised
+}
+
+// ExplainPrioritisedContractCall is the same as IsPrioritisedContractCall but
+// also returns the reason for the outcome.
+func ExplainPrioritisedContractCall(config *params.ChainConfig, blockTime uint64, to *common.Address, data []byte, ret []byte, initialGas uint64) (bool, PrioritisationReason) {
+	if to == nil {
+		return false, PrioritisationContractCreation
+	}
+	if config == nil {
+		return false, PrioritisationNotConfigured
 	}
 
-	chainValue := prioritisedContractVariants.GetValue(chainID)
+	rules := config.GetPrioritisedContractConfig(blockTime)
+	if rules == nil {
+		return false, PrioritisationNotConfigured
+	}
 
 	switch {
-	case initialGas > chainValue.maxGasLimit:
-		return false
-	case *to == prioritisedFTSOContractAddress:
-		if blockTime > chainValue.dataPrefixActivationTime {
-			return checkDataPrefix(data, chainValue.ftsoDataPrefixes)
+	case initialGas > rules.MaxGasLimit:
+		return false, PrioritisationGasLimitExceeded
+	case *to == rules.FTSOAddress:
+		if blockTime > rules.DataPrefixActivationTime && !checkDataPrefix(data, rules.FTSODataPrefixes) {
+			return false, PrioritisationFTSOPrefixMismatch
 		}
-		return true
-	case *to == chainValue.submitterAddress && blockTime > chainValue.submitterActivationTime && !isZeroSlice(ret):
-		if blockTime > chainValue.dataPrefixActivationTime {
-			return len(data) <= prioritisedCallDataCap && checkDataPrefix(data, chainValue.submitterDataPrefixes)
+		return true, PrioritisationFTSOCall
+	case *to == rules.SubmitterAddress:
+		switch {
+		case blockTime <= rules.SubmitterActivationTime:
+			return false, PrioritisationSubmitterNotActivated
+		case isZeroSlice(ret):
+			return false, PrioritisationZeroReturnValue
+		case blockTime <= rules.DataPrefixActivationTime:
+			return true, PrioritisationSubmitterCall
+		case uint64(len(data)) > rules.CallDataCap:
+			return false, PrioritisationCallDataCapExceeded
+		case !checkDataPrefix(data, rules.SubmitterDataPrefixes):
+			return false, PrioritisationSubmitterPrefixMismatch
+		default:
+			return true, PrioritisationSubmitterCall
 		}
-		return true
 	default:
-		return false
+		return false, PrioritisationNotPrioritisedContract
 	}
 }
 
@@ -206,17 +185,22 @@ func GetMaximumMintRequest(chainID *big.Int, blockTime uint64) *uint256.Int {
 	return maxRequest
 }
 
-func daemon(evm EVMCaller) (int, *uint256.Int, error) {
+func daemon(evm EVMCaller) (int, *uint256.Int, uint64, error) {
 	bigZero := uint256.NewInt(0)
 	// Get the contract to call
 	daemonContract := common.HexToAddress(GetDaemonContractAddr(evm.GetBlockTime()))
 
 	// Call the method
-	daemonSnapshot, daemonRet, _, daemonErr := evm.DaemonCall(
+	gas := GetDaemonGasMultiplier(evm.GetBlockTime()) * evm.GetGasLimit()
+	daemonSnapshot, daemonRet, leftOverGas, daemonErr := evm.DaemonCall(
 		vm.AccountRef(daemonContract),
 		daemonContract,
 		GetDaemonSelector(evm.GetBlockTime()),
-		GetDaemonGasMultiplier(evm.GetBlockTime())*evm.GetGasLimit())
+		gas)
+	var gasUsed uint64
+	if leftOverGas <= gas {
+		gasUsed = gas - leftOverGas
+	}
 	// If no error and a value came back...
 	if daemonErr == nil && daemonRet != nil {
 		// Did we get one big int?
@@ -225,16 +209,16 @@ func daemon(evm EVMCaller) (int, *uint256.Int, error) {
 			// Mint request cannot be less than 0 as SetBytes treats value as unsigned
 			mintRequest := new(uint256.Int).SetBytes32(daemonRet)
 			// return the mint request
-			return daemonSnapshot, mintRequest, nil
+			return daemonSnapshot, mintRequest, gasUsed, nil
 		} else {
 			// Returned length was not 32 bytes
-			return 0, bigZero, &ErrInvalidDaemonData{}
+			return 0, bigZero, gasUsed, &ErrInvalidDaemonData{}
 		}
 	} else {
 		if daemonErr != nil {
-			return 0, bigZero, daemonErr
+			return 0, bigZero, gasUsed, daemonErr
 		} else {
-			return 0, bigZero, &ErrDaemonDataEmpty{}
+			return 0, bigZero, gasUsed, &ErrDaemonDataEmpty{}
 		}
 	}
 }
@@ -256,9 +240,17 @@ func mint(evm EVMCaller, mintRequest *uint256.Int) error {
 	return nil
 }
 
-func atomicDaemonAndMint(evm EVMCaller, log log.Logger) {
+// atomicDaemonAndMint calls the daemon and mints the requested amount. The
+// daemon state transition is reverted if the mint request is rejected.
+// Returns a record of the invocation.
+func atomicDaemonAndMint(evm EVMCaller, log log.Logger) *types.DaemonMint {
 	// Call the daemon
-	daemonSnapshot, mintRequest, daemonErr := daemon(evm)
+	daemonSnapshot, mintRequest, gasUsed, daemonErr := daemon(evm)
+	record := &types.DaemonMint{
+		MintRequest: mintRequest.ToBig(),
+		Minted:      new(big.Int),
+		GasUsed:     gasUsed,
+	}
 	// If no error...
 	if daemonErr == nil {
 		// time to mint
@@ -266,9 +258,54 @@ func atomicDaemonAndMint(evm EVMCaller, log log.Logger) {
 			log.Warn("Error minting inflation request", "error", mintError)
 			// Revert to snapshot to unwind daemon state transition
 			evm.DaemonRevertToSnapshot(daemonSnapshot)
+			record.Reverted = true
+			record.Error = classifyDaemonError(mintError)
+		} else {
+			record.Minted.Set(record.MintRequest)
 		}
 	} else {
 		log.Warn("Daemon error", "error", daemonErr)
+		record.Error = classifyDaemonError(daemonErr)
+	}
+	return record
+}
+
+// classifyDaemonError returns the class of an error returned by the daemon or mint.
+func classifyDaemonError(err error) types.DaemonMintError {
+	switch err.(type) {
+	case nil:
+		return types.DaemonMintErrorNone
+	case *ErrInvalidDaemonData:
+		return types.DaemonMintErrorInvalidData
+	case *ErrDaemonDataEmpty:
+		return types.DaemonMintErrorEmptyData
+	case *ErrMaxMintExceeded:
+		return types.DaemonMintErrorMaxExceeded
+	default:
+		return types.DaemonMintErrorCallFailed
+	}
+}
+
+// markDaemonMintsAccepted updates the daemon metrics with the invocations of
+// an accepted block.
+func markDaemonMintsAccepted(mints []*types.DaemonMint) {
+	for _, mint := range mints {
+		daemonCallsCounter.Inc(1)
+		daemonGasUsedHistogram.Update(int64(mint.GasUsed))
+		if mint.Reverted {
+			daemonRevertsCounter.Inc(1)
+		}
+		if mint.Error != types.DaemonMintErrorNone {
+			metrics.GetOrRegisterCounter("flare/daemon/errors/"+string(mint.Error), nil).Inc(1)
+			continue
+		}
+		if mint.Minted.Sign() > 0 {
+			daemonMintsCounter.Inc(1)
+			// Tracked in gwei so the amounts fit into the int64 histogram.
+			mintedGwei := new(big.Int).Div(mint.Minted, big.NewInt(params.GWei))
+			daemonMintedGweiCounter.Inc(mintedGwei.Int64())
+			daemonMintHistogram.Update(mintedGwei.Int64())
+		}
 	}
 }
 
@@ -281,7 +318,7 @@ func isZeroSlice(s []byte) bool {
 	return true
 }
 
-func checkDataPrefix(data []byte, prefixes [][4]byte) bool {
+func checkDataPrefix(data []byte, prefixes []params.DataPrefix) bool {
 	if len(data) < 4 {
 		return false
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/holiman/uint256"
 
+	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/params"
 
@@ -106,7 +107,7 @@ func TestDaemonShouldReturnMintRequest(t *testing.T) {
 		mockEVMCallerData: *mockEVMCallerData,
 	}
 
-	_, mintRequest, _ := daemon(defaultEVMMock)
+	_, mintRequest, _, _ := daemon(defaultEVMMock)
 
 	if mintRequest.Cmp(mintRequestReturn) != 0 {
 		t.Errorf("got %s want %q", mintRequest.String(), "60000000000000000000000000")
@@ -128,7 +129,7 @@ func TestDaemonShouldNotLetMintRequestOverflow(t *testing.T) {
 		mockEVMCallerData: *mockEVMCallerData,
 	}
 
-	snapshot, mintRequest, mintRequestError := daemon(defaultEVMMock)
+	snapshot, mintRequest, _, mintRequestError := daemon(defaultEVMMock)
 
 	if mintRequestError != nil {
 		t.Errorf("received unexpected error %s", mintRequestError)
@@ -189,7 +190,7 @@ func TestDaemonValidatesMintRequestReturnValueSize(t *testing.T) {
 		mockEVMCallerData: *mockEVMCallerData,
 	}
 	// Call to return less than 32 bytes
-	_, _, err := daemon(badMintReturnSizeEVMMock)
+	_, _, _, err := daemon(badMintReturnSizeEVMMock)
 
 	if err != nil {
 		if err, ok := err.(*ErrInvalidDaemonData); !ok {
@@ -239,7 +240,7 @@ func TestDaemonReturnsCallError(t *testing.T) {
 		mockEVMCallerData: *mockEVMCallerData,
 	}
 	// Call to return less than 32 bytes
-	_, _, err := daemon(badDaemonCallEVMMock)
+	_, _, _, err := daemon(badDaemonCallEVMMock)
 
 	if err == nil {
 		t.Errorf("no error received")
@@ -341,7 +342,7 @@ func TestDaemonHandlesNilMintRequest(t *testing.T) {
 		mockEVMCallerData: *mockEVMCallerData,
 	}
 	// Call to return less than 32 bytes
-	_, _, err := daemon(returnNilMintRequestEVMMock)
+	_, _, _, err := daemon(returnNilMintRequestEVMMock)
 
 	if err != nil {
 		if err, ok := err.(*ErrDaemonDataEmpty); !ok {
@@ -450,7 +451,7 @@ func TestDaemonFiredAndMinted(t *testing.T) {
 	}
 
 	log := log.New()
-	atomicDaemonAndMint(defaultEVMMock, log)
+	record := atomicDaemonAndMint(defaultEVMMock, log)
 
 	// EVM Call function calling the daemon should have been cqlled
 	if defaultEVMMock.mockEVMCallerData.callCalls != 1 {
@@ -460,6 +461,10 @@ func TestDaemonFiredAndMinted(t *testing.T) {
 	if defaultEVMMock.mockEVMCallerData.addBalanceCalls != 1 {
 		t.Errorf("Add balance call count not as expected. got %d want 1", defaultEVMMock.mockEVMCallerData.addBalanceCalls)
 	}
+	// The invocation should be recorded as a successful mint
+	if record.Minted.Cmp(mintRequestReturn.ToBig()) != 0 || record.Reverted || record.Error != types.DaemonMintErrorNone {
+		t.Errorf("Daemon mint record not as expected. got %+v", record)
+	}
 }
 
 func TestDaemonShouldNotMintMoreThanLimit(t *testing.T) {
@@ -475,7 +480,7 @@ func TestDaemonShouldNotMintMoreThanLimit(t *testing.T) {
 	}
 
 	log := log.New()
-	atomicDaemonAndMint(defaultEVMMock, log)
+	record := atomicDaemonAndMint(defaultEVMMock, log)
 
 	// EVM Call function calling the daemon should have been called
 	if defaultEVMMock.mockEVMCallerData.callCalls != 1 {
@@ -485,6 +490,10 @@ func TestDaemonShouldNotMintMoreThanLimit(t *testing.T) {
 	if defaultEVMMock.mockEVMCallerData.addBalanceCalls != 0 {
 		t.Errorf("Add balance call count not as expected. got %d want 1", defaultEVMMock.mockEVMCallerData.addBalanceCalls)
 	}
+	// The invocation should be recorded as reverted
+	if record.Minted.Sign() != 0 || !record.Reverted || record.Error != types.DaemonMintErrorMaxExceeded {
+		t.Errorf("Daemon mint record not as expected. got %+v", record)
+	}
 }
 
 func TestPrioritisedContract(t *testing.T) {
@@ -498,37 +507,90 @@ func TestPrioritisedContract(t *testing.T) {
 	ret1[31] = 1
 	data := []byte{0x01, 0x02, 0x03, 0x04, 0x05}
 
-	if IsPrioritisedContractCall(params.FlareChainID, preForkTime, &address, data, nil, initialGas) {
+	config := &params.ChainConfig{ChainID: params.FlareChainID}
+	prioritisedFTSOContractAddress := common.HexToAddress("0x1000000000000000000000000000000000000003")
+	prioritisedSubmitterContractAddress := common.HexToAddress("0x2cA6571Daa15ce734Bbd0Bf27D5C9D16787fc33f")
+	prioritisedCallDataCap := 4500
+
+	if IsPrioritisedContractCall(config, preForkTime, &address, data, nil, initialGas) {
 		t.Errorf("Expected false for wrong address")
 	}
-	if !IsPrioritisedContractCall(params.FlareChainID, preForkTime, &prioritisedFTSOContractAddress, nil, nil, initialGas) {
+	if !IsPrioritisedContractCall(config, preForkTime, &prioritisedFTSOContractAddress, nil, nil, initialGas) {
 		t.Errorf("Expected true for FTSO contract")
 	}
-	if IsPrioritisedContractCall(params.FlareChainID, preForkTime, &prioritisedSubmitterContractAddress, data, ret1[:], initialGas) {
+	if IsPrioritisedContractCall(config, preForkTime, &prioritisedSubmitterContractAddress, data, ret1[:], initialGas) {
 		t.Errorf("Expected false for submitter contract before activation")
 	}
-	if !IsPrioritisedContractCall(params.FlareChainID, postForkTime, &prioritisedSubmitterContractAddress, data, ret1[:], initialGas) {
+	if !IsPrioritisedContractCall(config, postForkTime, &prioritisedSubmitterContractAddress, data, ret1[:], initialGas) {
 		t.Errorf("Expected true for submitter contract after activation")
 	}
-	if IsPrioritisedContractCall(params.FlareChainID, postForkTime, &prioritisedSubmitterContractAddress, data, ret0[:], initialGas) {
+	if IsPrioritisedContractCall(config, postForkTime, &prioritisedSubmitterContractAddress, data, ret0[:], initialGas) {
 		t.Errorf("Expected false for submitter contract with wrong return value")
 	}
-	if IsPrioritisedContractCall(params.FlareChainID, postForkTime, &prioritisedSubmitterContractAddress, data, nil, initialGas) {
+	if IsPrioritisedContractCall(config, postForkTime, &prioritisedSubmitterContractAddress, data, nil, initialGas) {
 		t.Errorf("Expected false for submitter contract with no return value")
 	}
-	if IsPrioritisedContractCall(params.FlareChainID, postPrefixForkTime, &prioritisedSubmitterContractAddress, data, ret1[:], initialGas) {
+	if IsPrioritisedContractCall(config, postPrefixForkTime, &prioritisedSubmitterContractAddress, data, ret1[:], initialGas) {
 		t.Errorf("Expected false for submitter contract after prefix activation with wrong data")
 	}
-	if !IsPrioritisedContractCall(params.FlareChainID, postPrefixForkTime, &prioritisedSubmitterContractAddress, []byte{0xe1, 0xb1, 0x57, 0xe7, 0x00, 0x00}, ret1[:], initialGas) {
+	if !IsPrioritisedContractCall(config, postPrefixForkTime, &prioritisedSubmitterContractAddress, []byte{0xe1, 0xb1, 0x57, 0xe7, 0x00, 0x00}, ret1[:], initialGas) {
 		t.Errorf("Expected true for submitter contract after prefix activation with correct data")
 	}
-	if IsPrioritisedContractCall(params.FlareChainID, postPrefixForkTime, &prioritisedSubmitterContractAddress, make([]byte, prioritisedCallDataCap+1), ret1[:], initialGas) {
+	if IsPrioritisedContractCall(config, postPrefixForkTime, &prioritisedSubmitterContractAddress, make([]byte, prioritisedCallDataCap+1), ret1[:], initialGas) {
 		t.Errorf("Expected false for submitter contract after prefix activation with too long data")
 	}
-	if IsPrioritisedContractCall(params.FlareChainID, postPrefixForkTime, &prioritisedFTSOContractAddress, data, nil, initialGas) {
+	if IsPrioritisedContractCall(config, postPrefixForkTime, &prioritisedFTSOContractAddress, data, nil, initialGas) {
 		t.Errorf("Expected false for FTSO contract after prefix activation with wrong data")
 	}
-	if !IsPrioritisedContractCall(params.FlareChainID, postPrefixForkTime, &prioritisedFTSOContractAddress, []byte{0x8f, 0xc6, 0xf6, 0x67, 0x05}, nil, initialGas) {
+	if !IsPrioritisedContractCall(config, postPrefixForkTime, &prioritisedFTSOContractAddress, []byte{0x8f, 0xc6, 0xf6, 0x67, 0x05}, nil, initialGas) {
 		t.Errorf("Expected true for FTSO contract after prefix activation with correct data")
 	}
 }
+
+func TestExplainPrioritisedContract(t *testing.T) {
+	config := &params.ChainConfig{ChainID: params.FlareChainID}
+	ftso := common.HexToAddress("0x1000000000000000000000000000000000000003")
+	submitter := common.HexToAddress("0x2cA6571Daa15ce734Bbd0Bf27D5C9D16787fc33f")
+	other := common.HexToAddress("0x123456789aBCdEF123456789aBCdef123456789A")
+	preForkTime := uint64(time.Date(2024, time.March, 20, 12, 0, 0, 0, time.UTC).Unix())
+	postForkTime := uint64(time.Date(2024, time.March, 27, 12, 0, 0, 0, time.UTC).Unix())
+	postPrefixForkTime := uint64(time.Date(2024, time.October, 11, 0, 0, 0, 0, time.UTC).Unix())
+	ret1 := make([]byte, 32)
+	ret1[31] = 1
+	submitterData := []byte{0xe1, 0xb1, 0x57, 0xe7}
+
+	tests := []struct {
+		name        string
+		config      *params.ChainConfig
+		blockTime   uint64
+		to          *common.Address
+		data        []byte
+		ret         []byte
+		initialGas  uint64
+		prioritised bool
+		reason      PrioritisationReason
+	}{
+		{"contract creation", config, postForkTime, nil, nil, nil, 0, false, PrioritisationContractCreation},
+		{"unknown chain", &params.ChainConfig{ChainID: params.AvalancheMainnetChainID}, postForkTime, &ftso, nil, nil, 0, false, PrioritisationNotConfigured},
+		{"gas limit exceeded", config, postForkTime, &ftso, nil, nil, 3000001, false, PrioritisationGasLimitExceeded},
+		{"ftso call", config, postForkTime, &ftso, nil, nil, 0, true, PrioritisationFTSOCall},
+		{"ftso prefix mismatch", config, postPrefixForkTime, &ftso, submitterData, nil, 0, false, PrioritisationFTSOPrefixMismatch},
+		{"submitter not activated", config, preForkTime, &submitter, submitterData, ret1, 0, false, PrioritisationSubmitterNotActivated},
+		{"zero return value", config, postForkTime, &submitter, submitterData, nil, 0, false, PrioritisationZeroReturnValue},
+		{"submitter call", config, postPrefixForkTime, &submitter, submitterData, ret1, 0, true, PrioritisationSubmitterCall},
+		{"call data cap exceeded", config, postPrefixForkTime, &submitter, append(submitterData, make([]byte, 4500)...), ret1, 0, false, PrioritisationCallDataCapExceeded},
+		{"submitter prefix mismatch", config, postPrefixForkTime, &submitter, []byte{0x01, 0x02, 0x03, 0x04}, ret1, 0, false, PrioritisationSubmitterPrefixMismatch},
+		{"other contract", config, postForkTime, &other, nil, nil, 0, false, PrioritisationNotPrioritisedContract},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prioritised, reason := ExplainPrioritisedContractCall(test.config, test.blockTime, test.to, test.data, test.ret, test.initialGas)
+			if prioritised != test.prioritised || reason != test.reason {
+				t.Errorf("got (%v, %s), want (%v, %s)", prioritised, reason, test.prioritised, test.reason)
+			}
+			if prioritised != IsPrioritisedContractCall(test.config, test.blockTime, test.to, test.data, test.ret, test.initialGas) {
+				t.Errorf("ExplainPrioritisedContractCall disagrees with IsPrioritisedContractCall")
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@
 package core
 
 import (
+	"bytes"
 	"encoding/binary"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -27,6 +29,63 @@ var (
 	localFlareDistributionChangeActivationTime = uint64(time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC).Unix())
 )
 
+var (
+	// governanceSettingsHook lets the governance settings contract update the
+	// governance address and timelock.
+	governanceSettingsHook = &SystemHook{
+		Name:  "governanceSettings",
+		Stage: SystemHookAfterCall,
+		Match: func(ctx *SystemHookContext) bool {
+			return GetGovernanceSettingIsActivatedAndCalled(ctx.ChainID, ctx.Timestamp, *ctx.Msg.To) && len(ctx.Msg.Data) == 36
+		},
+		Handle: func(st *StateTransition, ctx *SystemHookContext) error {
+			switch selector := ctx.Msg.Data[0:4]; {
+			case bytes.Equal(selector, SetGovernanceAddressSelector(ctx.ChainID, ctx.Timestamp)):
+				if err := st.SetGovernanceAddress(ctx.ChainID, ctx.Timestamp, ctx.Msg.Data[4:36]); err != nil {
+					return fmt.Errorf("setting governance address: %w", err)
+				}
+			case bytes.Equal(selector, SetTimelockSelector(ctx.ChainID, ctx.Timestamp)):
+				if err := st.SetTimelock(ctx.ChainID, ctx.Timestamp, ctx.Msg.Data[4:36]); err != nil {
+					return fmt.Errorf("setting governance timelock: %w", err)
+				}
+			}
+			return nil
+		},
+	}
+
+	// initialAirdropChangeHook moves the initial airdrop funds to their
+	// target contract.
+	initialAirdropChangeHook = &SystemHook{
+		Name:  "initialAirdropChange",
+		Stage: SystemHookAfterCall,
+		Match: func(ctx *SystemHookContext) bool {
+			return GetInitialAirdropChangeIsActivatedAndCalled(ctx.ChainID, ctx.Timestamp, *ctx.Msg.To) && len(ctx.Msg.Data) == 4
+		},
+		Handle: func(st *StateTransition, ctx *SystemHookContext) error {
+			if !bytes.Equal(ctx.Msg.Data[0:4], UpdateInitialAirdropAddressSelector(ctx.ChainID, ctx.Timestamp)) {
+				return nil
+			}
+			return st.UpdateInitialAirdropAddress(ctx.ChainID, ctx.Timestamp)
+		},
+	}
+
+	// distributionChangeHook moves the distribution funds to their target
+	// contract.
+	distributionChangeHook = &SystemHook{
+		Name:  "distributionChange",
+		Stage: SystemHookAfterCall,
+		Match: func(ctx *SystemHookContext) bool {
+			return GetDistributionChangeIsActivatedAndCalled(ctx.ChainID, ctx.Timestamp, *ctx.Msg.To) && len(ctx.Msg.Data) == 4
+		},
+		Handle: func(st *StateTransition, ctx *SystemHookContext) error {
+			if !bytes.Equal(ctx.Msg.Data[0:4], UpdateDistributionAddressSelector(ctx.ChainID, ctx.Timestamp)) {
+				return nil
+			}
+			return st.UpdateDistributionAddress(ctx.ChainID, ctx.Timestamp)
+		},
+	}
+)
+
 func GetGovernanceSettingIsActivatedAndCalled(chainID *big.Int, blockTime uint64, to common.Address) bool {
 	switch {
 	case chainID.Cmp(params.FlareChainID) == 0 && blockTime >= flareGovActivationTime:
@@ -266,7 +325,7 @@ func (st *StateTransition) SetGovernanceAddress(chainID *big.Int, timestamp uint
 			st.evm.Context.Coinbase = originalCoinbase
 		}()
 		st.evm.Context.Coinbase = coinbaseSignal
-		_, _, _, err := st.evm.DaemonCall(vm.AccountRef(coinbaseSignal), st.to(), st.msg.Data, st.evm.Context.GasLimit)
+		_, _, _, err := st.evm.SystemCall(vm.SystemCallGovernance, vm.AccountRef(coinbaseSignal), st.to(), st.msg.Data, st.evm.Context.GasLimit)
 		if err != nil {
 			return err
 		}
@@ -282,7 +341,7 @@ func (st *StateTransition) SetTimelock(chainID *big.Int, timestamp uint64, newTi
 			st.evm.Context.Coinbase = originalCoinbase
 		}()
 		st.evm.Context.Coinbase = coinbaseSignal
-		_, _, _, err := st.evm.DaemonCall(vm.AccountRef(coinbaseSignal), st.to(), st.msg.Data, st.evm.Context.GasLimit)
+		_, _, _, err := st.evm.SystemCall(vm.SystemCallGovernance, vm.AccountRef(coinbaseSignal), st.to(), st.msg.Data, st.evm.Context.GasLimit)
 		if err != nil {
 			return err
 		}
@@ -297,7 +356,7 @@ func (st *StateTransition) UpdateInitialAirdropAddress(chainID *big.Int, timesta
 		st.evm.Context.Coinbase = originalCoinbase
 	}()
 	st.evm.Context.Coinbase = coinbaseSignal
-	_, _, _, err := st.evm.DaemonCall(vm.AccountRef(coinbaseSignal), st.to(), st.msg.Data, st.evm.Context.GasLimit)
+	_, _, _, err := st.evm.SystemCall(vm.SystemCallGovernance, vm.AccountRef(coinbaseSignal), st.to(), st.msg.Data, st.evm.Context.GasLimit)
 	if err != nil {
 		return err
 	}
@@ -319,7 +378,7 @@ func (st *StateTransition) UpdateDistributionAddress(chainID *big.Int, timestamp
 		st.evm.Context.Coinbase = originalCoinbase
 	}()
 	st.evm.Context.Coinbase = coinbaseSignal
-	_, _, _, err := st.evm.DaemonCall(vm.AccountRef(coinbaseSignal), st.to(), st.msg.Data, st.evm.Context.GasLimit)
+	_, _, _, err := st.evm.SystemCall(vm.SystemCallGovernance, vm.AccountRef(coinbaseSignal), st.to(), st.msg.Data, st.evm.Context.GasLimit)
 	if err != nil {
 		return err
 	}
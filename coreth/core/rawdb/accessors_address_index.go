@@ -0,0 +1,193 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// AddressRole is a bit set of the ways an address took part in a transaction.
+type AddressRole uint8
+
+const (
+	AddressRoleSender    AddressRole = 1 << iota // the address signed the transaction
+	AddressRoleRecipient                         // the address is the recipient or the created contract
+	AddressRoleInternal                          // the address took part in an internal call
+)
+
+// AddressTxEntry is an entry of the address transaction index.
+type AddressTxEntry struct {
+	Address     common.Address
+	BlockNumber uint64
+	TxIndex     uint32
+	Roles       AddressRole
+}
+
+// ReadAddressTxEntries retrieves at most [limit] index entries of [address],
+// starting at transaction [index] of block [number] and ending with block
+// [last], in ascending order.
+func ReadAddressTxEntries(db ethdb.Iteratee, address common.Address, number uint64, index uint32, last uint64, limit int) []AddressTxEntry {
+	prefix := append(append([]byte{}, addressTxPrefix...), address.Bytes()...)
+	start := addressTxKey(address, number, index)[len(prefix):]
+	it := db.NewIterator(prefix, start)
+	defer it.Release()
+
+	var entries []AddressTxEntry
+	for len(entries) < limit && it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+12 || len(it.Value()) != 1 {
+			continue
+		}
+		entry := AddressTxEntry{
+			Address:     address,
+			BlockNumber: binary.BigEndian.Uint64(key[len(prefix):]),
+			TxIndex:     binary.BigEndian.Uint32(key[len(prefix)+8:]),
+			Roles:       AddressRole(it.Value()[0]),
+		}
+		if entry.BlockNumber > last {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// ReadAddressBlockEntries retrieves the address index entries of the block at
+// [number]. Returns nil if the block was not indexed.
+func ReadAddressBlockEntries(db ethdb.KeyValueReader, number uint64) []AddressTxEntry {
+	data, _ := db.Get(addressBlockKey(number))
+	if len(data) == 0 {
+		return nil
+	}
+	var entries []AddressTxEntry
+	if err := rlp.DecodeBytes(data, &entries); err != nil {
+		log.Error("Invalid address index entries RLP", "number", number, "err", err)
+		return nil
+	}
+	return entries
+}
+
+// WriteAddressTxEntries stores the address index entries of the accepted block
+// at [number], along with the list of entries needed to remove them again.
+func WriteAddressTxEntries(db ethdb.KeyValueWriter, number uint64, entries []AddressTxEntry) {
+	data, err := rlp.EncodeToBytes(entries)
+	if err != nil {
+		log.Crit("Failed to encode address index entries", "err", err)
+	}
+	if err := db.Put(addressBlockKey(number), data); err != nil {
+		log.Crit("Failed to store address index entries", "err", err)
+	}
+	for _, entry := range entries {
+		if err := db.Put(addressTxKey(entry.Address, number, entry.TxIndex), []byte{byte(entry.Roles)}); err != nil {
+			log.Crit("Failed to store address index entry", "err", err)
+		}
+	}
+}
+
+// DeleteAddressTxEntries removes the address index [entries] of the block at
+// [number].
+func DeleteAddressTxEntries(db ethdb.KeyValueWriter, number uint64, entries []AddressTxEntry) {
+	for _, entry := range entries {
+		if err := db.Delete(addressTxKey(entry.Address, number, entry.TxIndex)); err != nil {
+			log.Crit("Failed to delete address index entry", "err", err)
+		}
+	}
+	if err := db.Delete(addressBlockKey(number)); err != nil {
+		log.Crit("Failed to delete address index entries", "err", err)
+	}
+}
+
+// ReadAddressIndexTail retrieves the number of the oldest block covered by
+// the address index.
+func ReadAddressIndexTail(db ethdb.KeyValueReader) *uint64 {
+	return readAddressIndexNumber(db, addressIndexTailKey)
+}
+
+// WriteAddressIndexTail stores the number of the oldest block covered by the
+// address index.
+func WriteAddressIndexTail(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(addressIndexTailKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the address index tail", "err", err)
+	}
+}
+
+// ReadAddressIndexHead retrieves the number of the newest block covered by
+// the address index.
+func ReadAddressIndexHead(db ethdb.KeyValueReader) *uint64 {
+	return readAddressIndexNumber(db, addressIndexHeadKey)
+}
+
+// WriteAddressIndexHead stores the number of the newest block covered by the
+// address index.
+func WriteAddressIndexHead(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(addressIndexHeadKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store the address index head", "err", err)
+	}
+}
+
+// ReadAddressIndexInternal retrieves whether the address index covers the
+// participants of internal calls.
+func ReadAddressIndexInternal(db ethdb.KeyValueReader) bool {
+	data, _ := db.Get(addressIndexInternalKey)
+	return len(data) == 1 && data[0] == 1
+}
+
+// WriteAddressIndexInternal stores whether the address index covers the
+// participants of internal calls.
+func WriteAddressIndexInternal(db ethdb.KeyValueWriter, internal bool) {
+	var value byte
+	if internal {
+		value = 1
+	}
+	if err := db.Put(addressIndexInternalKey, []byte{value}); err != nil {
+		log.Crit("Failed to store the address index internal flag", "err", err)
+	}
+}
+
+func readAddressIndexNumber(db ethdb.KeyValueReader, key []byte) *uint64 {
+	data, _ := db.Get(key)
+	if len(data) != 8 {
+		return nil
+	}
+	number := binary.BigEndian.Uint64(data)
+	return &number
+}
+
+// DeleteAddressIndex removes all entries of the address index, leaving its
+// tail, head and internal flag untouched.
+func DeleteAddressIndex(db ethdb.KeyValueStore) error {
+	for _, prefix := range [][]byte{addressTxPrefix, addressBlockPrefix} {
+		if err := deleteAddressIndexPrefix(db, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteAddressIndexPrefix(db ethdb.KeyValueStore, prefix []byte) error {
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
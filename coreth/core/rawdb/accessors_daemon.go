@@ -0,0 +1,44 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ReadDaemonMints retrieves the daemon invocations of the accepted block at
+// [number]. Returns nil if the block was not indexed.
+func ReadDaemonMints(db ethdb.KeyValueReader, number uint64) []*types.DaemonMint {
+	data, _ := db.Get(daemonMintKey(number))
+	if len(data) == 0 {
+		return nil
+	}
+	var mints []*types.DaemonMint
+	if err := rlp.DecodeBytes(data, &mints); err != nil {
+		log.Error("Invalid daemon mint RLP", "number", number, "err", err)
+		return nil
+	}
+	return mints
+}
+
+// WriteDaemonMints stores the daemon invocations of the accepted block at [number].
+func WriteDaemonMints(db ethdb.KeyValueWriter, number uint64, mints []*types.DaemonMint) {
+	data, err := rlp.EncodeToBytes(mints)
+	if err != nil {
+		log.Crit("Failed to encode daemon mints", "err", err)
+	}
+	if err := db.Put(daemonMintKey(number), data); err != nil {
+		log.Crit("Failed to store daemon mints", "err", err)
+	}
+}
+
+// DeleteDaemonMints removes the daemon invocations of the block at [number].
+func DeleteDaemonMints(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Delete(daemonMintKey(number)); err != nil {
+		log.Crit("Failed to delete daemon mints", "err", err)
+	}
+}
@@ -0,0 +1,43 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaemonMintStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+	require.Nil(t, ReadDaemonMints(db, 1))
+
+	mints := []*types.DaemonMint{
+		{
+			TxHash:      common.Hash{1},
+			TxIndex:     0,
+			MintRequest: big.NewInt(100),
+			Minted:      big.NewInt(100),
+			GasUsed:     21000,
+		},
+		{
+			TxHash:      common.Hash{2},
+			TxIndex:     1,
+			MintRequest: big.NewInt(200),
+			Minted:      big.NewInt(0),
+			GasUsed:     30000,
+			Reverted:    true,
+			Error:       types.DaemonMintErrorMaxExceeded,
+		},
+	}
+	WriteDaemonMints(db, 1, mints)
+	require.Equal(t, mints, ReadDaemonMints(db, 1))
+	require.Nil(t, ReadDaemonMints(db, 2))
+
+	DeleteDaemonMints(db, 1)
+	require.Nil(t, ReadDaemonMints(db, 1))
+}
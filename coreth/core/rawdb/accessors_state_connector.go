@@ -0,0 +1,46 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// ReadRoundVotes retrieves the votes of the finalised state connector [round].
+// Returns nil if the round was not indexed.
+func ReadRoundVotes(db ethdb.KeyValueReader, round *big.Int) *types.RoundVotes {
+	data, _ := db.Get(roundVotesKey(round))
+	if len(data) == 0 {
+		return nil
+	}
+	votes := new(types.RoundVotes)
+	if err := rlp.DecodeBytes(data, votes); err != nil {
+		log.Error("Invalid round votes RLP", "round", round, "err", err)
+		return nil
+	}
+	return votes
+}
+
+// WriteRoundVotes stores the votes of a finalised state connector round.
+func WriteRoundVotes(db ethdb.KeyValueWriter, votes *types.RoundVotes) {
+	data, err := rlp.EncodeToBytes(votes)
+	if err != nil {
+		log.Crit("Failed to encode round votes", "err", err)
+	}
+	if err := db.Put(roundVotesKey(votes.Round), data); err != nil {
+		log.Crit("Failed to store round votes", "err", err)
+	}
+}
+
+// DeleteRoundVotes removes the votes of the state connector [round].
+func DeleteRoundVotes(db ethdb.KeyValueWriter, round *big.Int) {
+	if err := db.Delete(roundVotesKey(round)); err != nil {
+		log.Crit("Failed to delete round votes", "err", err)
+	}
+}
@@ -0,0 +1,38 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundVotesStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+	require.Nil(t, ReadRoundVotes(db, big.NewInt(7)))
+
+	votes := &types.RoundVotes{
+		Round:              big.NewInt(7),
+		BlockNumber:        100,
+		TxHash:             common.HexToHash("0x01"),
+		TxIndex:            2,
+		Attestors:          []common.Address{common.HexToAddress("0xa1"), common.HexToAddress("0xa2"), common.HexToAddress("0xa3")},
+		ReachedMajority:    true,
+		MajorityDecision:   common.HexToHash("0xbeef").Bytes(),
+		MajorityAttestors:  []common.Address{common.HexToAddress("0xa1"), common.HexToAddress("0xa2")},
+		DivergentAttestors: []common.Address{},
+		AbstainedAttestors: []common.Address{common.HexToAddress("0xa3")},
+		Finalised:          true,
+	}
+	WriteRoundVotes(db, votes)
+	require.Equal(t, votes, ReadRoundVotes(db, big.NewInt(7)))
+	require.Nil(t, ReadRoundVotes(db, big.NewInt(8)))
+
+	DeleteRoundVotes(db, big.NewInt(7))
+	require.Nil(t, ReadRoundVotes(db, big.NewInt(7)))
+}
@@ -0,0 +1,442 @@
+// (c) 2025, Ava Labs, Inc.
+//
+// This file is a derived work, based on the go-ethereum library whose original
+// notices appear below.
+//
+// It is distributed under a license compatible with the licensing terms of the
+// original code from which it is derived.
+//
+// Much love to the original authors for their work.
+// **********
+// Copyright 2022 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethrawdb "github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// freezerRecheckInterval is the frequency to check the key-value database for
+	// chain progression that might permit new blocks to be frozen into immutable
+	// storage.
+	freezerRecheckInterval = time.Minute
+
+	// freezerBatchLimit is the maximum number of blocks to freeze in one batch
+	// before doing an fsync and deleting it from the key-value store.
+	freezerBatchLimit = 30000
+
+	// freezerTableSize defines the maximum size of freezer data files.
+	freezerTableSize = 2 * 1000 * 1000 * 1000
+)
+
+var (
+	errMissingCanonicalHash = errors.New("canonical hash missing")
+	errBelowFreezerTail     = errors.New("below the freezer tail")
+)
+
+// chainFreezer is a wrapper of freezer with additional chain freezing feature.
+// The background thread will keep moving ancient chain segments from key-value
+// database to flat files for saving space on live database.
+//
+// Unlike upstream, blocks are frozen once they are [threshold] blocks below the
+// acceptor tip, as accepted blocks are final.
+//
+// The frozen blocks start at the tail of the freezer, which is moved to the
+// first block on disk once the node state syncs past the frozen blocks. Block
+// numbers are relative to the tail in the underlying freezer.
+type chainFreezer struct {
+	threshold atomic.Uint64 // Number of recent accepted blocks not to freeze
+	tail      atomic.Uint64 // Number of the first frozen block
+
+	*ethrawdb.Freezer
+	readonly bool
+	quit     chan struct{}
+	wg       sync.WaitGroup
+	trigger  chan chan struct{} // Manual blocking freeze trigger, test determinism
+}
+
+// newChainFreezer initializes the freezer for ancient chain data, starting at
+// block [tail].
+func newChainFreezer(datadir string, namespace string, readonly bool, threshold uint64, tail uint64) (*chainFreezer, error) {
+	freezer, err := ethrawdb.NewFreezer(datadir, namespace, readonly, freezerTableSize, chainFreezerNoSnappy)
+	if err != nil {
+		return nil, err
+	}
+	cf := chainFreezer{
+		Freezer:  freezer,
+		readonly: readonly,
+		quit:     make(chan struct{}),
+		trigger:  make(chan chan struct{}),
+	}
+	cf.threshold.Store(threshold)
+	cf.tail.Store(tail)
+	return &cf, nil
+}
+
+// Close closes the chain freezer instance and terminates the background thread.
+func (f *chainFreezer) Close() error {
+	select {
+	case <-f.quit:
+	default:
+		close(f.quit)
+	}
+	f.wg.Wait()
+	return f.Freezer.Close()
+}
+
+// freeze is a background thread that periodically checks the blockchain for any
+// accepted blocks and moves ancient data from the fast database into the freezer.
+//
+// This functionality is deliberately broken off from block accepting to avoid
+// incurring additional data shuffling delays on block acceptance.
+//
+// The ancients must be contiguous, so if the node state syncs past the frozen
+// blocks, the frozen blocks are dropped and freezing restarts at the first
+// block on disk after the state synced block.
+func (f *chainFreezer) freeze(db ethdb.KeyValueStore) {
+	var (
+		backoff   bool
+		triggered chan struct{} // Used in tests
+		nfdb      = &nofreezedb{KeyValueStore: db}
+	)
+	timer := time.NewTimer(freezerRecheckInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-f.quit:
+			log.Info("Freezer shutting down")
+			return
+		default:
+		}
+		if backoff {
+			// If we were doing a manual trigger, notify it
+			if triggered != nil {
+				triggered <- struct{}{}
+				triggered = nil
+			}
+			select {
+			case <-timer.C:
+				backoff = false
+				timer.Reset(freezerRecheckInterval)
+			case triggered = <-f.trigger:
+				backoff = false
+			case <-f.quit:
+				return
+			}
+		}
+		// Retrieve the freezing threshold.
+		hash, err := ReadAcceptorTip(nfdb)
+		if err != nil || hash == (common.Hash{}) {
+			log.Debug("Acceptor tip unavailable", "err", err) // new chain, empty database
+			backoff = true
+			continue
+		}
+		number := ReadHeaderNumber(nfdb, hash)
+		threshold := f.threshold.Load()
+		frozen, _ := f.Ancients()
+		switch {
+		case number == nil:
+			log.Error("Acceptor tip number unavailable", "hash", hash)
+			backoff = true
+			continue
+
+		case *number < threshold:
+			log.Debug("Acceptor tip not old enough to freeze", "number", *number, "hash", hash, "delay", threshold)
+			backoff = true
+			continue
+
+		case *number-threshold < frozen:
+			log.Debug("Ancient blocks frozen already", "number", *number, "hash", hash, "frozen", frozen)
+			backoff = true
+			continue
+		}
+
+		// Seems we have data ready to be frozen, process in usable batches
+		var (
+			start = time.Now()
+			first = frozen
+			limit = *number - threshold
+		)
+		if limit-first > freezerBatchLimit {
+			limit = first + freezerBatchLimit
+		}
+		ancients, err := f.freezeRange(nfdb, first, limit)
+		if errors.Is(err, errMissingCanonicalHash) && GetLatestSyncPerformed(nfdb) > first {
+			moved, err := f.resetTail(db, nfdb, limit)
+			if err != nil {
+				log.Error("Error moving the freezer tail after state sync", "err", err)
+			}
+			backoff = !moved
+			continue
+		}
+		if err != nil {
+			log.Error("Error in block freeze operation", "err", err)
+			backoff = true
+			continue
+		}
+
+		// Batch of blocks have been frozen, flush them before wiping from the
+		// key-value store
+		if err := f.Sync(); err != nil {
+			log.Crit("Failed to flush frozen tables", "err", err)
+		}
+
+		// Wipe out all data from the active database
+		batch := db.NewBatch()
+		for i := 0; i < len(ancients); i++ {
+			// Always keep the genesis block in active database
+			if first+uint64(i) != 0 {
+				DeleteBlockWithoutNumber(batch, ancients[i], first+uint64(i))
+				DeleteCanonicalHash(batch, first+uint64(i))
+			}
+		}
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed to delete frozen canonical blocks", "err", err)
+		}
+		batch.Reset()
+
+		// Wipe out any rejected blocks left at the frozen heights. Their
+		// descendants are rejected as well, so there are no dangling side
+		// chains to follow past the frozen heights.
+		frozen, _ = f.Ancients() // Needs reload after during freezeRange
+		for number := first; number < frozen; number++ {
+			// Always keep the genesis block in active database
+			if number != 0 {
+				for _, hash := range ReadAllHashes(db, number) {
+					log.Trace("Deleting side chain", "number", number, "hash", hash)
+					DeleteBlock(batch, hash, number)
+				}
+			}
+		}
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed to delete frozen side blocks", "err", err)
+		}
+
+		// Log something friendly for the user
+		context := []interface{}{
+			"blocks", frozen - first, "elapsed", common.PrettyDuration(time.Since(start)), "number", frozen - 1,
+		}
+		if n := len(ancients); n > 0 {
+			context = append(context, []interface{}{"hash", ancients[n-1]}...)
+		}
+		log.Debug("Deep froze chain segment", context...)
+
+		// Avoid database thrashing with tiny writes
+		if frozen-first < freezerBatchLimit {
+			backoff = true
+		}
+	}
+}
+
+func (f *chainFreezer) freezeRange(nfdb *nofreezedb, number, limit uint64) (hashes []common.Hash, err error) {
+	hashes = make([]common.Hash, 0, limit-number+1)
+
+	_, err = f.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		for ; number <= limit; number++ {
+			// Retrieve all the components of the canonical block.
+			hash := ReadCanonicalHash(nfdb, number)
+			if hash == (common.Hash{}) {
+				return fmt.Errorf("%w, can't freeze block %d", errMissingCanonicalHash, number)
+			}
+			header := ReadHeaderRLP(nfdb, hash, number)
+			if len(header) == 0 {
+				return fmt.Errorf("block header missing, can't freeze block %d", number)
+			}
+			body := ReadBodyRLP(nfdb, hash, number)
+			if len(body) == 0 {
+				return fmt.Errorf("block body missing, can't freeze block %d", number)
+			}
+			receipts := ReadReceiptsRLP(nfdb, hash, number)
+			if len(receipts) == 0 {
+				return fmt.Errorf("block receipts missing, can't freeze block %d", number)
+			}
+
+			// Write to the batch.
+			if err := op.AppendRaw(ChainFreezerHashTable, number, hash[:]); err != nil {
+				return fmt.Errorf("can't write hash to Freezer: %v", err)
+			}
+			if err := op.AppendRaw(ChainFreezerHeaderTable, number, header); err != nil {
+				return fmt.Errorf("can't write header to Freezer: %v", err)
+			}
+			if err := op.AppendRaw(ChainFreezerBodiesTable, number, body); err != nil {
+				return fmt.Errorf("can't write body to Freezer: %v", err)
+			}
+			if err := op.AppendRaw(ChainFreezerReceiptTable, number, receipts); err != nil {
+				return fmt.Errorf("can't write receipts to Freezer: %v", err)
+			}
+
+			hashes = append(hashes, hash)
+		}
+		return nil
+	})
+
+	return hashes, err
+}
+
+// resetTail moves the tail of the freezer to the first block after the state
+// synced block up to [limit] whose receipts are on disk, dropping the frozen
+// blocks. The state synced block and its parents fetched by the state sync have
+// no receipts, so they remain in the key-value store. It returns whether the
+// tail was moved.
+func (f *chainFreezer) resetTail(db ethdb.KeyValueStore, nfdb *nofreezedb, limit uint64) (bool, error) {
+	synced := GetLatestSyncPerformed(nfdb)
+	tail := synced
+	for ; tail <= limit; tail++ {
+		hash := ReadCanonicalHash(nfdb, tail)
+		if hash != (common.Hash{}) && len(ReadReceiptsRLP(nfdb, hash, tail)) > 0 {
+			break
+		}
+	}
+	if tail > limit {
+		log.Debug("No blocks to freeze after the state synced block", "synced", synced, "limit", limit)
+		return false, nil
+	}
+	frozen, _ := f.Ancients()
+	log.Warn("Restarting ancient blocks after the state synced block", "frozen", frozen, "synced", synced, "tail", tail)
+
+	if _, err := f.Freezer.TruncateHead(0); err != nil {
+		return false, err
+	}
+	if err := writeFreezerTail(db, tail); err != nil {
+		return false, err
+	}
+	f.tail.Store(tail)
+	return true, nil
+}
+
+// readFreezerTail returns the number of the first frozen block.
+func readFreezerTail(db ethdb.KeyValueReader) uint64 {
+	data, _ := db.Get(freezerTailKey)
+	if len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+// writeFreezerTail stores the number of the first frozen block.
+func writeFreezerTail(db ethdb.KeyValueWriter, number uint64) error {
+	return db.Put(freezerTailKey, encodeBlockNumber(number))
+}
+
+// HasAncient returns an indicator whether the specified ancient data exists
+// in the freezer.
+func (f *chainFreezer) HasAncient(kind string, number uint64) (bool, error) {
+	tail := f.tail.Load()
+	if number < tail {
+		return false, nil
+	}
+	return f.Freezer.HasAncient(kind, number-tail)
+}
+
+// Ancient retrieves an ancient binary blob from the freezer.
+func (f *chainFreezer) Ancient(kind string, number uint64) ([]byte, error) {
+	tail := f.tail.Load()
+	if number < tail {
+		return nil, errBelowFreezerTail
+	}
+	return f.Freezer.Ancient(kind, number-tail)
+}
+
+// AncientRange retrieves multiple items in sequence, starting from the index
+// [start].
+func (f *chainFreezer) AncientRange(kind string, start, count, maxBytes uint64) ([][]byte, error) {
+	tail := f.tail.Load()
+	if start < tail {
+		return nil, errBelowFreezerTail
+	}
+	return f.Freezer.AncientRange(kind, start-tail, count, maxBytes)
+}
+
+// Ancients returns the number of the block following the frozen blocks.
+func (f *chainFreezer) Ancients() (uint64, error) {
+	frozen, err := f.Freezer.Ancients()
+	return f.tail.Load() + frozen, err
+}
+
+// Tail returns the number of the first frozen block.
+func (f *chainFreezer) Tail() (uint64, error) {
+	tail, err := f.Freezer.Tail()
+	return f.tail.Load() + tail, err
+}
+
+// ReadAncients runs the given read operation while ensuring that no writes
+// take place on the underlying freezer.
+func (f *chainFreezer) ReadAncients(fn func(ethdb.AncientReaderOp) error) error {
+	return f.Freezer.ReadAncients(func(ethdb.AncientReaderOp) error {
+		return fn(f)
+	})
+}
+
+// ModifyAncients runs the given write operation.
+func (f *chainFreezer) ModifyAncients(fn func(ethdb.AncientWriteOp) error) (int64, error) {
+	tail := f.tail.Load()
+	return f.Freezer.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		return fn(&tailWriteOp{AncientWriteOp: op, tail: tail})
+	})
+}
+
+// TruncateHead discards any recent data above the provided threshold number.
+// It returns the previous head number.
+func (f *chainFreezer) TruncateHead(items uint64) (uint64, error) {
+	tail := f.tail.Load()
+	if items < tail {
+		return 0, errBelowFreezerTail
+	}
+	old, err := f.Freezer.TruncateHead(items - tail)
+	return tail + old, err
+}
+
+// TruncateTail discards any recent data below the provided threshold number.
+// It returns the previous tail number.
+func (f *chainFreezer) TruncateTail(items uint64) (uint64, error) {
+	tail := f.tail.Load()
+	old, err := f.Freezer.TruncateTail(max(items, tail) - tail)
+	return tail + old, err
+}
+
+// tailWriteOp writes the blocks of a chain freezer to its underlying freezer,
+// numbered relative to the tail.
+type tailWriteOp struct {
+	ethdb.AncientWriteOp
+	tail uint64
+}
+
+func (op *tailWriteOp) Append(kind string, number uint64, item interface{}) error {
+	if number < op.tail {
+		return errBelowFreezerTail
+	}
+	return op.AncientWriteOp.Append(kind, number-op.tail, item)
+}
+
+func (op *tailWriteOp) AppendRaw(kind string, number uint64, item []byte) error {
+	if number < op.tail {
+		return errBelowFreezerTail
+	}
+	return op.AncientWriteOp.AppendRaw(kind, number-op.tail, item)
+}
@@ -0,0 +1,226 @@
+// (c) 2025, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rawdb
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFreezerTestChain writes a canonical chain of [n] blocks with receipts
+// and a rejected block at height 2, and marks the last block as the acceptor
+// tip.
+func writeFreezerTestChain(t *testing.T, db *memorydb.Database, n int) ([]*types.Block, *types.Block) {
+	blocks := make([]*types.Block, n)
+	parent := common.Hash{}
+	for i := range blocks {
+		blocks[i] = types.NewBlockWithHeader(&types.Header{
+			Number:     big.NewInt(int64(i)),
+			ParentHash: parent,
+			Extra:      []byte("canonical"),
+		})
+		parent = blocks[i].Hash()
+
+		WriteBlock(db, blocks[i])
+		WriteCanonicalHash(db, blocks[i].Hash(), uint64(i))
+		receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: uint64(i), Logs: []*types.Log{}}
+		WriteReceipts(db, blocks[i].Hash(), uint64(i), types.Receipts{receipt})
+	}
+	WriteHeadHeaderHash(db, parent)
+	require.NoError(t, WriteAcceptorTip(db, parent))
+
+	rejected := types.NewBlockWithHeader(&types.Header{
+		Number:     big.NewInt(2),
+		ParentHash: blocks[1].Hash(),
+		Extra:      []byte("rejected"),
+	})
+	WriteBlock(db, rejected)
+	return blocks, rejected
+}
+
+func TestChainFreezer(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		kvdb    = memorydb.New()
+		ancient = t.TempDir()
+	)
+	blocks, rejected := writeFreezerTestChain(t, kvdb, 10)
+
+	db, err := NewDatabaseWithFreezer(kvdb, ancient, "", false, 100)
+	require.NoError(err)
+
+	// Nothing is frozen until the acceptor tip is deep enough.
+	require.NoError(db.(*freezerdb).Freeze(100))
+	frozen, err := db.Ancients()
+	require.NoError(err)
+	require.Zero(frozen)
+
+	// Blocks that are at least 4 blocks below the acceptor tip are frozen.
+	require.NoError(db.(*freezerdb).Freeze(4))
+	frozen, err = db.Ancients()
+	require.NoError(err)
+	require.Equal(uint64(6), frozen)
+
+	checkBlocks := func(db *freezerdb) {
+		for i, block := range blocks {
+			number := uint64(i)
+			require.Equal(block.Hash(), ReadCanonicalHash(db, number))
+			require.True(HasHeader(db, block.Hash(), number))
+			require.True(HasBody(db, block.Hash(), number))
+			require.True(HasReceipts(db, block.Hash(), number))
+			stored := ReadBlock(db, block.Hash(), number)
+			require.NotNil(stored)
+			require.Equal(block.Hash(), stored.Hash())
+			require.NotEmpty(ReadCanonicalBodyRLP(db, number))
+			receipts := ReadRawReceipts(db, block.Hash(), number)
+			require.Len(receipts, 1)
+			require.Equal(number, receipts[0].CumulativeGasUsed)
+
+			// Frozen blocks except the genesis are moved out of the
+			// key-value store.
+			moved := number != 0 && number < frozen
+			for _, key := range [][]byte{
+				headerHashKey(number),
+				headerKey(number, block.Hash()),
+				blockBodyKey(number, block.Hash()),
+				blockReceiptsKey(number, block.Hash()),
+			} {
+				has, err := kvdb.Has(key)
+				require.NoError(err)
+				require.Equal(!moved, has, "block %d", number)
+			}
+			require.NotNil(ReadHeaderNumber(db, block.Hash()))
+		}
+		// Rejected blocks at frozen heights are deleted.
+		require.Nil(ReadHeader(db, rejected.Hash(), 2))
+		require.False(HasBody(db, rejected.Hash(), 2))
+	}
+	checkBlocks(db.(*freezerdb))
+
+	// Reopening the freezer serves the frozen blocks again.
+	require.NoError(db.(*freezerdb).AncientStore.Close())
+	db, err = NewDatabaseWithFreezer(kvdb, ancient, "", false, 100)
+	require.NoError(err)
+	checkBlocks(db.(*freezerdb))
+	require.NoError(db.(*freezerdb).AncientStore.Close())
+
+	// An empty freezer cannot be combined with a key-value store that blocks
+	// were already moved out of.
+	_, err = NewDatabaseWithFreezer(kvdb, t.TempDir(), "", false, 100)
+	require.ErrorContains(err, "ancient chain segments already extracted")
+}
+
+func TestChainFreezerGenesisMismatch(t *testing.T) {
+	require := require.New(t)
+
+	ancient := t.TempDir()
+	kvdb := memorydb.New()
+	writeFreezerTestChain(t, kvdb, 10)
+	db, err := NewDatabaseWithFreezer(kvdb, ancient, "", false, 100)
+	require.NoError(err)
+	require.NoError(db.(*freezerdb).Freeze(4))
+	require.NoError(db.(*freezerdb).AncientStore.Close())
+
+	// A key-value store of another chain cannot use the freezer.
+	other := memorydb.New()
+	WriteCanonicalHash(other, common.HexToHash("0x01"), 0)
+	_, err = NewDatabaseWithFreezer(other, ancient, "", false, 100)
+	require.ErrorContains(err, "genesis mismatch")
+}
+
+func TestChainFreezerStateSynced(t *testing.T) {
+	require := require.New(t)
+
+	// A state synced node only has the genesis, the state synced block without
+	// receipts and the blocks after it.
+	var (
+		kvdb    = memorydb.New()
+		ancient = t.TempDir()
+	)
+	blocks, _ := writeFreezerTestChain(t, kvdb, 10)
+	for _, block := range blocks[1:5] {
+		DeleteBlock(kvdb, block.Hash(), block.NumberU64())
+		DeleteCanonicalHash(kvdb, block.NumberU64())
+	}
+	DeleteReceipts(kvdb, blocks[5].Hash(), 5)
+	require.NoError(WriteSyncPerformed(kvdb, 5))
+
+	db, err := NewDatabaseWithFreezer(kvdb, ancient, "", false, 100)
+	require.NoError(err)
+
+	// Freezing starts at the first block with receipts after the state synced
+	// block.
+	require.NoError(db.(*freezerdb).Freeze(2))
+	checkBlocks := func(db ethdb.Database) {
+		tail, err := db.Tail()
+		require.NoError(err)
+		require.Equal(uint64(6), tail)
+		frozen, err := db.Ancients()
+		require.NoError(err)
+		require.Equal(uint64(8), frozen)
+
+		nfdb := NewDatabase(kvdb)
+		for _, block := range blocks[5:] {
+			number := block.NumberU64()
+			require.Equal(block.Hash(), ReadCanonicalHash(db, number))
+			require.True(HasBody(db, block.Hash(), number))
+			moved := number >= tail && number < frozen
+			require.Equal(!moved, ReadCanonicalHash(nfdb, number) == block.Hash(), "block %d", number)
+		}
+		require.Equal(blocks[0].Hash(), ReadCanonicalHash(db, 0))
+		require.Equal(common.Hash{}, ReadCanonicalHash(db, 1))
+	}
+	checkBlocks(db)
+
+	// Reopening the freezer keeps its tail.
+	require.NoError(db.(*freezerdb).AncientStore.Close())
+	db, err = NewDatabaseWithFreezer(kvdb, ancient, "", false, 100)
+	require.NoError(err)
+	defer db.(*freezerdb).AncientStore.Close()
+	checkBlocks(db)
+}
+
+func TestChainFreezerStateSyncedPastAncients(t *testing.T) {
+	require := require.New(t)
+
+	kvdb := memorydb.New()
+	blocks, _ := writeFreezerTestChain(t, kvdb, 10)
+	db, err := NewDatabaseWithFreezer(kvdb, t.TempDir(), "", false, 100)
+	require.NoError(err)
+	defer db.(*freezerdb).AncientStore.Close()
+	require.NoError(db.(*freezerdb).Freeze(8))
+	frozen, err := db.Ancients()
+	require.NoError(err)
+	require.Equal(uint64(2), frozen)
+
+	// The node state syncs to block 6, so the blocks between the ancients and
+	// the state synced block are missing.
+	for _, block := range blocks[2:6] {
+		DeleteBlock(kvdb, block.Hash(), block.NumberU64())
+		DeleteCanonicalHash(kvdb, block.NumberU64())
+	}
+	DeleteReceipts(kvdb, blocks[6].Hash(), 6)
+	require.NoError(WriteSyncPerformed(kvdb, 6))
+
+	// The ancients restart after the state synced block.
+	require.NoError(db.(*freezerdb).Freeze(1))
+	tail, err := db.Tail()
+	require.NoError(err)
+	require.Equal(uint64(7), tail)
+	frozen, err = db.Ancients()
+	require.NoError(err)
+	require.Equal(uint64(9), frozen)
+	require.Equal(common.Hash{}, ReadCanonicalHash(db, 1))
+	for _, block := range blocks[6:] {
+		require.Equal(block.Hash(), ReadCanonicalHash(db, block.NumberU64()))
+		require.True(HasBody(db, block.Hash(), block.NumberU64()))
+	}
+}
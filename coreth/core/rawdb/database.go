@@ -28,6 +28,7 @@ package rawdb
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -42,6 +43,54 @@ import (
 	"github.com/olekukonko/tablewriter"
 )
 
+// freezerdb is a database wrapper that enables freezer data retrievals.
+type freezerdb struct {
+	ancientRoot string
+	ethdb.KeyValueStore
+	ethdb.AncientStore
+}
+
+// AncientDatadir returns the path of root ancient directory.
+func (frdb *freezerdb) AncientDatadir() (string, error) {
+	return frdb.ancientRoot, nil
+}
+
+// Close implements io.Closer, closing both the fast key-value store as well as
+// the slow ancient tables.
+func (frdb *freezerdb) Close() error {
+	var errs []error
+	if err := frdb.AncientStore.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := frdb.KeyValueStore.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// Freeze is a helper method used for external testing to trigger and block until
+// a freeze cycle completes, without having to sleep for a minute to trigger the
+// automatic background run.
+func (frdb *freezerdb) Freeze(threshold uint64) error {
+	if frdb.AncientStore.(*chainFreezer).readonly {
+		return errReadOnly
+	}
+	// Set the freezer threshold to a temporary value
+	defer func(old uint64) {
+		frdb.AncientStore.(*chainFreezer).threshold.Store(old)
+	}(frdb.AncientStore.(*chainFreezer).threshold.Load())
+	frdb.AncientStore.(*chainFreezer).threshold.Store(threshold)
+
+	// Trigger a freeze cycle and block until it's done
+	trigger := make(chan struct{}, 1)
+	frdb.AncientStore.(*chainFreezer).trigger <- trigger
+	<-trigger
+	return nil
+}
+
 // nofreezedb is a database wrapper that disables freezer data retrievals.
 type nofreezedb struct {
 	ethdb.KeyValueStore
@@ -130,6 +179,70 @@ func NewDatabase(db ethdb.KeyValueStore) ethdb.Database {
 	return &nofreezedb{KeyValueStore: db}
 }
 
+// NewDatabaseWithFreezer creates a high level database on top of a given key-
+// value data store with a freezer moving accepted blocks that are [threshold]
+// blocks below the acceptor tip into cold storage. The passed ancient indicates
+// the path of root ancient directory where the chain freezer can be opened.
+func NewDatabaseWithFreezer(db ethdb.KeyValueStore, ancient string, namespace string, readonly bool, threshold uint64) (ethdb.Database, error) {
+	// Create the idle freezer instance
+	frdb, err := newChainFreezer(filepath.Join(ancient, ChainFreezerName), namespace, readonly, threshold, readFreezerTail(db))
+	if err != nil {
+		return nil, err
+	}
+	// Since the freezer is stored separately from the key-value database, ensure
+	// they belong together before serving up data from both of them:
+	//
+	//   - If the freezer is not empty, its genesis must match the key-value
+	//     store's, unless the freezer starts after a state sync, and the
+	//     key-value store must continue where it left off, unless the node
+	//     state synced past the frozen blocks.
+	//   - If the freezer is empty, nothing may have been moved out of the
+	//     key-value store yet, unless the blocks are missing because the node
+	//     state synced, in which case freezing starts after the state synced
+	//     block.
+	if kvgenesis, _ := db.Get(headerHashKey(0)); len(kvgenesis) > 0 {
+		frozen, _ := frdb.Ancients()
+		tail, _ := frdb.Tail()
+		if frozen > tail {
+			if tail == 0 {
+				frgenesis, err := frdb.Ancient(ChainFreezerHashTable, 0)
+				if err != nil {
+					frdb.Close()
+					return nil, fmt.Errorf("failed to retrieve genesis from ancient %v", err)
+				} else if !bytes.Equal(kvgenesis, frgenesis) {
+					frdb.Close()
+					return nil, fmt.Errorf("genesis mismatch: %#x (key-value store) != %#x (ancients)", kvgenesis, frgenesis)
+				}
+			}
+			if kvhash, _ := db.Get(headerHashKey(frozen)); len(kvhash) == 0 {
+				if head := ReadHeaderNumber(db, ReadHeadHeaderHash(db)); head != nil && *head > frozen-1 && GetLatestSyncPerformed(db) <= frozen {
+					frdb.Close()
+					return nil, fmt.Errorf("gap in the chain between ancients [#%d - #%d] and key-value store [#%d]", tail, frozen-1, *head)
+				}
+			}
+		} else if ReadHeadHeaderHash(db) != common.BytesToHash(kvgenesis) {
+			next := max(frozen, 1)
+			if kvblob, _ := db.Get(headerHashKey(next)); len(kvblob) == 0 && GetLatestSyncPerformed(db) <= next {
+				frdb.Close()
+				return nil, errors.New("ancient chain segments already extracted, please set the ancient directory to the correct path")
+			}
+		}
+	}
+	// Freezer is consistent with the key-value database, permit combining the two
+	if !readonly {
+		frdb.wg.Add(1)
+		go func() {
+			frdb.freeze(db)
+			frdb.wg.Done()
+		}()
+	}
+	return &freezerdb{
+		ancientRoot:   ancient,
+		KeyValueStore: db,
+		AncientStore:  frdb,
+	}, nil
+}
+
 // NewMemoryDatabase creates an ephemeral in-memory key-value database without a
 // freezer moving immutable chain segments into cold storage.
 func NewMemoryDatabase() ethdb.Database {
@@ -30,6 +30,7 @@ package rawdb
 import (
 	"bytes"
 	"encoding/binary"
+	"math/big"
 
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ethereum/go-ethereum/common"
@@ -82,6 +83,10 @@ var (
 	// acceptorTipKey tracks the tip of the last accepted block that has been fully processed.
 	acceptorTipKey = []byte("AcceptorTipKey")
 
+	// freezerTailKey tracks the first block of the chain freezer, which is moved
+	// past the genesis if the node state syncs past the frozen blocks.
+	freezerTailKey = []byte("FreezerTail")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerHashSuffix   = []byte("n") // headerPrefix + num (uint64 big endian) + headerHashSuffix -> hash
@@ -124,6 +129,20 @@ var (
 	// State sync metadata
 	syncPerformedPrefix    = []byte("sync_performed")
 	syncPerformedKeyLength = len(syncPerformedPrefix) + wrappers.LongLen // prefix + block number as uint64
+
+	// Flare indices
+	daemonMintPrefix = []byte("flare_daemon_mints") // daemonMintPrefix + num (uint64 big endian) -> daemon invocations of accepted block
+	roundVotesPrefix = []byte("flare_round_votes")  // roundVotesPrefix + round (32 bytes big endian) -> state connector round votes
+
+	addressTxPrefix    = []byte("flare_address_txs")    // addressTxPrefix + address + num (uint64 big endian) + tx index (uint32 big endian) -> address roles
+	addressBlockPrefix = []byte("flare_address_blocks") // addressBlockPrefix + num (uint64 big endian) -> address index entries of accepted block
+
+	// addressIndexTailKey tracks the oldest block covered by the address index.
+	addressIndexTailKey = []byte("FlareAddressIndexTail")
+	// addressIndexHeadKey tracks the newest block covered by the address index.
+	addressIndexHeadKey = []byte("FlareAddressIndexHead")
+	// addressIndexInternalKey tracks whether the address index covers internal call participants.
+	addressIndexInternalKey = []byte("FlareAddressIndexInternal")
 )
 
 // LegacyTxLookupEntry is the legacy TxLookupEntry definition with some unnecessary
@@ -171,6 +190,29 @@ func blockReceiptsKey(number uint64, hash common.Hash) []byte {
 	return append(append(blockReceiptsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
 }
 
+// daemonMintKey = daemonMintPrefix + num (uint64 big endian)
+func daemonMintKey(number uint64) []byte {
+	return append(append([]byte{}, daemonMintPrefix...), encodeBlockNumber(number)...)
+}
+
+// roundVotesKey = roundVotesPrefix + round (32 bytes big endian)
+func roundVotesKey(round *big.Int) []byte {
+	return append(append([]byte{}, roundVotesPrefix...), common.BigToHash(round).Bytes()...)
+}
+
+// addressTxKey = addressTxPrefix + address + num (uint64 big endian) + tx index (uint32 big endian)
+func addressTxKey(address common.Address, number uint64, index uint32) []byte {
+	key := make([]byte, 0, len(addressTxPrefix)+common.AddressLength+8+4)
+	key = append(append(key, addressTxPrefix...), address.Bytes()...)
+	key = append(key, encodeBlockNumber(number)...)
+	return binary.BigEndian.AppendUint32(key, index)
+}
+
+// addressBlockKey = addressBlockPrefix + num (uint64 big endian)
+func addressBlockKey(number uint64) []byte {
+	return append(append([]byte{}, addressBlockPrefix...), encodeBlockNumber(number)...)
+}
+
 // txLookupKey = txLookupPrefix + hash
 func txLookupKey(hash common.Hash) []byte {
 	return append(txLookupPrefix, hash.Bytes()...)
@@ -31,6 +31,7 @@ import (
 	"fmt"
 	"maps"
 	"math/big"
+	"slices"
 	"sort"
 	"time"
 
@@ -115,6 +116,13 @@ type StateDB struct {
 	logs    map[common.Hash][]*types.Log
 	logSize uint
 
+	// Daemon invocations and state connector rounds finalised in the scope of block.
+	daemonMints []*types.DaemonMint
+	roundVotes  []*types.RoundVotes
+
+	// Participants of internal calls by transaction index in the scope of block.
+	callParticipants map[int]map[common.Address]struct{}
+
 	// Preimages occurred seen by VM in the scope of block.
 	preimages map[common.Hash][]byte
 
@@ -284,6 +292,58 @@ func (s *StateDB) GetLogs(hash common.Hash, blockNumber uint64, blockHash common
 	return logs
 }
 
+// AddDaemonMint records a daemon invocation made by the current transaction.
+// Unlike logs, the record is not reverted with state snapshots.
+func (s *StateDB) AddDaemonMint(mint *types.DaemonMint) {
+	mint.TxHash = s.thash
+	mint.TxIndex = uint64(s.txIndex)
+	s.daemonMints = append(s.daemonMints, mint)
+}
+
+// DaemonMints returns the daemon invocations recorded in the scope of block.
+func (s *StateDB) DaemonMints() []*types.DaemonMint {
+	return s.daemonMints
+}
+
+// AddRoundVotes records the votes of a state connector round finalised by the
+// current transaction. Unlike logs, the record is not reverted with state
+// snapshots.
+func (s *StateDB) AddRoundVotes(votes *types.RoundVotes) {
+	votes.TxHash = s.thash
+	votes.TxIndex = uint64(s.txIndex)
+	s.roundVotes = append(s.roundVotes, votes)
+}
+
+// RoundVotes returns the state connector rounds finalised in the scope of block.
+func (s *StateDB) RoundVotes() []*types.RoundVotes {
+	return s.roundVotes
+}
+
+// AddCallParticipant records an address taking part in an internal call of
+// the current transaction. Unlike logs, the record is not reverted with state
+// snapshots.
+func (s *StateDB) AddCallParticipant(addr common.Address) {
+	if s.callParticipants == nil {
+		s.callParticipants = make(map[int]map[common.Address]struct{})
+	}
+	participants, ok := s.callParticipants[s.txIndex]
+	if !ok {
+		participants = make(map[common.Address]struct{})
+		s.callParticipants[s.txIndex] = participants
+	}
+	participants[addr] = struct{}{}
+}
+
+// CallParticipants returns the participants of internal calls recorded in the
+// scope of block, by transaction index.
+func (s *StateDB) CallParticipants() map[int][]common.Address {
+	participants := make(map[int][]common.Address, len(s.callParticipants))
+	for txIndex, addrs := range s.callParticipants {
+		participants[txIndex] = slices.SortedFunc(maps.Keys(addrs), common.Address.Cmp)
+	}
+	return participants
+}
+
 func (s *StateDB) Logs() []*types.Log {
 	var logs []*types.Log
 	for _, lgs := range s.logs {
@@ -832,6 +892,8 @@ func (s *StateDB) Copy() *StateDB {
 		refund:               s.refund,
 		logs:                 make(map[common.Hash][]*types.Log, len(s.logs)),
 		logSize:              s.logSize,
+		daemonMints:          slices.Clone(s.daemonMints),
+		roundVotes:           slices.Clone(s.roundVotes),
 		preimages:            maps.Clone(s.preimages),
 		journal:              newJournal(),
 		hasher:               crypto.NewKeccakState(),
@@ -842,6 +904,12 @@ func (s *StateDB) Copy() *StateDB {
 		// miner to operate trie-backed only.
 		snap: s.snap,
 	}
+	if s.callParticipants != nil {
+		state.callParticipants = make(map[int]map[common.Address]struct{}, len(s.callParticipants))
+		for txIndex, addrs := range s.callParticipants {
+			state.callParticipants[txIndex] = maps.Clone(addrs)
+		}
+	}
 	// Copy the dirty states, logs, and preimages
 	for addr := range s.journal.dirties {
 		// As documented [here](https://github.com/ethereum/go-ethereum/pull/16485#issuecomment-380438527),
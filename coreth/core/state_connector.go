@@ -4,6 +4,8 @@
 package core
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"math/big"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
 	"github.com/ava-labs/coreth/params"
 	"github.com/ava-labs/coreth/utils"
@@ -34,6 +37,9 @@ var (
 
 	costonActivationTime = uint64(time.Date(2022, time.February, 25, 17, 0, 0, 0, time.UTC).Unix())
 	costonOct22ForkTime  = uint64(time.Date(2022, time.October, 6, 15, 0, 0, 0, time.UTC).Unix())
+
+	submitterContractActivationTimeFlare    = uint64(time.Date(2024, time.March, 26, 12, 0, 0, 0, time.UTC).Unix())
+	submitterContractActivationTimeSongbird = uint64(time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC).Unix())
 )
 
 type AttestationVotes struct {
@@ -54,6 +60,23 @@ var (
 		AddValue(params.LocalChainID, GetStateConnectorIsActivatedAndCalledLocal)
 )
 
+// stateConnectorHook finalises the previous state connector round when an
+// attestation submitted to the state connector contract starts a new one.
+// The state connector is disabled in Durango.
+var stateConnectorHook = &SystemHook{
+	Name:  "stateConnector",
+	Stage: SystemHookAfterCall,
+	Match: func(ctx *SystemHookContext) bool {
+		return GetStateConnectorIsActivatedAndCalled(ctx.IsDurango, ctx.ChainID, ctx.Timestamp, *ctx.Msg.To) &&
+			len(ctx.Msg.Data) >= 36 && len(ctx.Ret) == 32 &&
+			bytes.Equal(ctx.Msg.Data[0:4], SubmitAttestationSelector(ctx.ChainID, ctx.Timestamp)) &&
+			binary.BigEndian.Uint64(ctx.Ret[24:32]) > 0
+	},
+	Handle: func(st *StateTransition, ctx *SystemHookContext) error {
+		return st.FinalisePreviousRound(ctx.ChainID, ctx.Timestamp, ctx.Msg.Data[4:36])
+	},
+}
+
 func GetStateConnectorIsActivatedAndCalled(isDurango bool, chainID *big.Int, blockTime uint64, to common.Address) bool {
 	return !isDurango && stateConnectorActivationVariants.GetValue(chainID)(blockTime, to)
 }
@@ -274,7 +297,7 @@ func GetLocalAttestors() []common.Address {
 }
 
 func (st *StateTransition) GetAttestation(attestor common.Address, instructions []byte) (string, error) {
-	_, merkleRootHash, _, err := st.evm.DaemonCall(vm.AccountRef(attestor), st.to(), instructions, params.TxGas)
+	_, merkleRootHash, _, err := st.evm.SystemCall(vm.SystemCallStateConnector, vm.AccountRef(attestor), st.to(), instructions, params.TxGas)
 	return hex.EncodeToString(merkleRootHash), err
 }
 
@@ -314,13 +337,42 @@ func CountAttestations(attestationVotes AttestationVotes, numAttestors int, hash
 	return attestationVotes
 }
 
-func (st *StateTransition) FinalisePreviousRound(chainID *big.Int, timestamp uint64, currentRoundNumber []byte) error {
+// roundVotesRecorder is implemented by state databases that keep a record of
+// the state connector rounds finalised while processing a block.
+type roundVotesRecorder interface {
+	AddRoundVotes(votes *types.RoundVotes)
+}
+
+// newRoundVotes converts the default attestation votes of a round into a record.
+func newRoundVotes(roundNumber []byte, attestors []common.Address, votes AttestationVotes) *types.RoundVotes {
+	majorityDecision, _ := hex.DecodeString(votes.majorityDecision)
+	return &types.RoundVotes{
+		Round:              new(big.Int).SetBytes(roundNumber),
+		Attestors:          attestors,
+		ReachedMajority:    votes.reachedMajority,
+		MajorityDecision:   majorityDecision,
+		MajorityAttestors:  votes.majorityAttestors,
+		DivergentAttestors: votes.divergentAttestors,
+		AbstainedAttestors: votes.abstainedAttestors,
+	}
+}
+
+func (st *StateTransition) FinalisePreviousRound(chainID *big.Int, timestamp uint64, currentRoundNumber []byte) (err error) {
 	getAttestationSelector := GetAttestationSelector(chainID, timestamp)
 	instructions := append(getAttestationSelector[:], currentRoundNumber[:]...)
 	defaultAttestors := GetDefaultAttestors(chainID, timestamp)
 	defaultAttestationVotes := CountAttestations(st.GetAttestations(defaultAttestors, instructions))
 	localAttestors := GetLocalAttestors()
 	finalityReached := defaultAttestationVotes.reachedMajority
+
+	// Keep a record of the default attestation votes for later inspection
+	if recorder, ok := st.state.(roundVotesRecorder); ok {
+		votes := newRoundVotes(currentRoundNumber, defaultAttestors, defaultAttestationVotes)
+		defer func() {
+			votes.Finalised = finalityReached && err == nil
+			recorder.AddRoundVotes(votes)
+		}()
+	}
 	if len(localAttestors) > 0 {
 		localAttestationVotes := CountAttestations(st.GetAttestations(localAttestors, instructions))
 		if finalityReached && defaultAttestationVotes.majorityDecision != localAttestationVotes.majorityDecision && os.Getenv(forkingEnabledEnv) == "1" {
@@ -336,7 +388,8 @@ func (st *StateTransition) FinalisePreviousRound(chainID *big.Int, timestamp uin
 		// Finalise defaultAttestationVotes.majorityDecision
 		finaliseRoundSelector := FinaliseRoundSelector(chainID, timestamp)
 		finalisedData := append(finaliseRoundSelector[:], currentRoundNumber[:]...)
-		merkleRootHashBytes, err := hex.DecodeString(defaultAttestationVotes.majorityDecision)
+		var merkleRootHashBytes []byte
+		merkleRootHashBytes, err = hex.DecodeString(defaultAttestationVotes.majorityDecision)
 		if err != nil {
 			return err
 		}
@@ -354,7 +407,7 @@ func (st *StateTransition) FinalisePreviousRound(chainID *big.Int, timestamp uin
 		//				by this check: burnAddress == common.HexToAddress("0x0100000000000000000000000000000000000000") on line 373, which occurs
 		//				right before st.FinalisePreviousRound(chainID, timestamp, st.data[4:36]) is called.
 		//		2) Know the private key to the address 0x00000000000000000000000000000000000DEaD1 in order to become msg.sender.
-		_, _, _, err = st.evm.DaemonCall(vm.AccountRef(coinbaseSignal), st.to(), finalisedData, st.evm.Context.GasLimit)
+		_, _, _, err = st.evm.SystemCall(vm.SystemCallStateConnector, vm.AccountRef(coinbaseSignal), st.to(), finalisedData, st.evm.Context.GasLimit)
 		if err != nil {
 			return err
 		}
@@ -27,13 +27,10 @@
 package core
 
 import (
-	"bytes"
-	"encoding/binary"
 	"fmt"
 	"math"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/core/vm"
@@ -523,10 +520,17 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	chainID = st.evm.ChainConfig().ChainID
 	timestamp = st.evm.Context.Time
 
-	burnAddress, nominalGasPrice, isFlare, isSongbird, err := stateTransitionVariants.GetValue(chainID)(st)
+	burnAddress, nominalGasPrice, err := stateTransitionVariants.GetValue(chainID)(st)
 	if err != nil {
 		return nil, err
 	}
+	hookCtx := &SystemHookContext{
+		ChainID:   chainID,
+		Timestamp: timestamp,
+		IsDurango: rules.IsDurango,
+		Coinbase:  st.evm.Context.Coinbase,
+		Msg:       msg,
+	}
 
 	if contractCreation {
 		ret, _, st.gasRemaining, vmerr = st.evm.Create(sender, msg.Data, st.gasRemaining, value)
@@ -535,11 +539,8 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 		st.state.SetNonce(msg.From, st.state.GetNonce(sender.Address())+1)
 		ret, st.gasRemaining, vmerr = st.evm.Call(sender, st.to(), msg.Data, st.gasRemaining, value)
 		if vmerr == nil && chainID != nil {
-			if isSongbird { // Songbird, Coston, Local (Songbird)
-				handleSongbirdTransitionDbContracts(st, rules.IsDurango, chainID, timestamp, msg, ret)
-			} else if isFlare { // Flare, Coston2, Local (Flare)
-				handleFlareTransitionDbContracts(st, rules.IsDurango, chainID, timestamp, msg, ret)
-			}
+			hookCtx.Ret = ret
+			st.runSystemHooks(SystemHookAfterCall, hookCtx)
 		}
 	}
 	price, overflow := uint256.FromBig(msg.GasPrice)
@@ -548,7 +549,7 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	}
 	gasRefund := st.refundGas(rules.IsApricotPhase1)
 
-	if vmerr == nil && IsPrioritisedContractCall(chainID, timestamp, msg.To, msg.Data, ret, st.initialGas) {
+	if vmerr == nil && IsPrioritisedContractCall(st.evm.ChainConfig(), timestamp, msg.To, msg.Data, ret, st.initialGas) {
 		nominalGasUsed := params.TxGas // 21000
 		nominalFee := new(uint256.Int).Mul(uint256.NewInt(nominalGasUsed), uint256.NewInt(nominalGasPrice))
 		actualGasUsed := st.gasUsed()
@@ -566,10 +567,9 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 		st.state.AddBalance(burnAddress, fee)
 	}
 
-	// Call the daemon if there is no vm error
-	if vmerr == nil && (isSongbird || isFlare) {
-		log := log.Root()
-		atomicDaemonAndMint(st, log)
+	// Run the system hooks, such as the daemon, if there is no vm error
+	if vmerr == nil {
+		st.runSystemHooks(SystemHookAfterFees, hookCtx)
 	}
 
 	return &ExecutionResult{
@@ -580,54 +580,6 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	}, nil
 }
 
-func handleSongbirdTransitionDbContracts(st *StateTransition, isDurango bool, chainID *big.Int, timestamp uint64, msg *Message, ret []byte) {
-	if GetStateConnectorIsActivatedAndCalled(isDurango, chainID, timestamp, *msg.To) &&
-		len(msg.Data) >= 36 && len(ret) == 32 &&
-		bytes.Equal(msg.Data[0:4], SubmitAttestationSelector(chainID, timestamp)) &&
-		binary.BigEndian.Uint64(ret[24:32]) > 0 {
-		if err := st.FinalisePreviousRound(chainID, timestamp, msg.Data[4:36]); err != nil {
-			log.Warn("Error finalising state connector round", "error", err)
-		}
-	}
-}
-
-func handleFlareTransitionDbContracts(st *StateTransition, isDurango bool, chainID *big.Int, timestamp uint64, msg *Message, ret []byte) {
-	if st.evm.Context.Coinbase != common.HexToAddress("0x0100000000000000000000000000000000000000") {
-		return
-	}
-
-	if GetStateConnectorIsActivatedAndCalled(isDurango, chainID, timestamp, *msg.To) &&
-		len(msg.Data) >= 36 && len(ret) == 32 &&
-		bytes.Equal(msg.Data[0:4], SubmitAttestationSelector(chainID, timestamp)) &&
-		binary.BigEndian.Uint64(ret[24:32]) > 0 {
-		if err := st.FinalisePreviousRound(chainID, timestamp, msg.Data[4:36]); err != nil {
-			log.Warn("Error finalising state connector round", "error", err)
-		}
-	} else if GetGovernanceSettingIsActivatedAndCalled(chainID, timestamp, *msg.To) && len(msg.Data) == 36 {
-		if bytes.Equal(msg.Data[0:4], SetGovernanceAddressSelector(chainID, timestamp)) {
-			if err := st.SetGovernanceAddress(chainID, timestamp, msg.Data[4:36]); err != nil {
-				log.Warn("Error setting governance address", "error", err)
-			}
-		} else if bytes.Equal(msg.Data[0:4], SetTimelockSelector(chainID, timestamp)) {
-			if err := st.SetTimelock(chainID, timestamp, msg.Data[4:36]); err != nil {
-				log.Warn("Error setting governance timelock", "error", err)
-			}
-		}
-	} else if GetInitialAirdropChangeIsActivatedAndCalled(chainID, timestamp, *msg.To) && len(msg.Data) == 4 {
-		if bytes.Equal(msg.Data[0:4], UpdateInitialAirdropAddressSelector(chainID, timestamp)) {
-			if err := st.UpdateInitialAirdropAddress(chainID, timestamp); err != nil {
-				log.Warn("Error updating initialAirdrop contract", "error", err)
-			}
-		}
-	} else if GetDistributionChangeIsActivatedAndCalled(chainID, timestamp, *msg.To) && len(msg.Data) == 4 {
-		if bytes.Equal(msg.Data[0:4], UpdateDistributionAddressSelector(chainID, timestamp)) {
-			if err := st.UpdateDistributionAddress(chainID, timestamp); err != nil {
-				log.Warn("Error updating distribution contract", "error", err)
-			}
-		}
-	}
-}
-
 func (st *StateTransition) refundGas(apricotPhase1 bool) uint64 {
 	var refund uint64
 	// Inspired by: https://gist.github.com/holiman/460f952716a74eeb9ab358bb1836d821#gistcomment-3642048
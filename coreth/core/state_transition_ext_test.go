@@ -33,7 +33,7 @@ func TestStateTransitionPrioritizedContract(t *testing.T) {
 		key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
 		from := crypto.PubkeyToAddress(key.PublicKey)
 		gas := uint64(3000000)
-		to := prioritisedSubmitterContractAddress
+		to := common.HexToAddress("0x2cA6571Daa15ce734Bbd0Bf27D5C9D16787fc33f")
 		daemon := common.HexToAddress(GetDaemonContractAddr(0))
 		signer := types.LatestSignerForChainID(config.ChainID)
 		tx, err := types.SignNewTx(key, signer,
@@ -102,7 +102,7 @@ func TestStateTransitionPrioritizedContract(t *testing.T) {
 		balanceAfter := st.state.GetBalance(st.msg.From)
 
 		// max fee (funds above which are returned) depends on the chain used
-		_, limit, _, _, _ := stateTransitionVariants.GetValue(config.ChainID)(st)
+		_, limit, _ := stateTransitionVariants.GetValue(config.ChainID)(st)
 		maxFee := new(uint256.Int).Mul(uint256.NewInt(params.TxGas), uint256.NewInt(limit))
 		diff := new(uint256.Int).Sub(balanceBefore, balanceAfter)
 
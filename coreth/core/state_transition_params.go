@@ -17,28 +17,24 @@ var (
 )
 
 // Used in tests
-func nonFlareChain(st *StateTransition) (common.Address, uint64, bool, bool, error) {
+func nonFlareChain(st *StateTransition) (common.Address, uint64, error) {
 	return common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
 		uint64(ap4.MinBaseFee),
-		false,
-		false,
 		nil
 }
 
 // Returns the state transition parameters for the given chain ID
-// burnAddress, nominalGasPrice, isFlare chain, isSongbird chain, error
-func stateTransitionParamsFlare(st *StateTransition) (common.Address, uint64, bool, bool, error) {
+// burnAddress, nominalGasPrice, error
+func stateTransitionParamsFlare(st *StateTransition) (common.Address, uint64, error) {
 	return common.HexToAddress("0x000000000000000000000000000000000000dEaD"),
 		uint64(ap4.MinBaseFee),
-		true,
-		false,
 		nil
 }
 
-func stateTransitionParamsSongbird(st *StateTransition) (common.Address, uint64, bool, bool, error) {
+func stateTransitionParamsSongbird(st *StateTransition) (common.Address, uint64, error) {
 	burnAddress := st.evm.Context.Coinbase
 	if burnAddress != common.HexToAddress("0x0100000000000000000000000000000000000000") {
-		return common.Address{}, 0, false, true, errors.New("invalid value for block.coinbase")
+		return common.Address{}, 0, errors.New("invalid value for block.coinbase")
 	}
-	return burnAddress, 225_000_000_000, false, true, nil
+	return burnAddress, 225_000_000_000, nil
 }
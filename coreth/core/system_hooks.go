@@ -0,0 +1,146 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/utils"
+)
+
+// SystemHookStage is the point of a state transition at which a system hook
+// runs.
+type SystemHookStage uint8
+
+const (
+	// SystemHookAfterCall hooks run after a successful message call, before
+	// the fees are charged. They are not run for contract creations.
+	SystemHookAfterCall SystemHookStage = iota
+	// SystemHookAfterFees hooks run after the fees of a successful message
+	// are charged.
+	SystemHookAfterFees
+)
+
+var (
+	flareSystemHookChainIDs    = []*big.Int{params.FlareChainID, params.CostwoChainID, params.LocalFlareChainID}
+	songbirdSystemHookChainIDs = []*big.Int{params.SongbirdChainID, params.CostonChainID, params.LocalChainID}
+)
+
+// Hooks are run in the order they are listed. Hooks registered with
+// RegisterSystemHook are run after the ones below.
+var systemHooks = utils.NewChainValue[[]*SystemHook](nil).
+	AddValues(flareSystemHookChainIDs, withSystemHookMetrics(
+		withFlareSystemCoinbase(stateConnectorHook),
+		withFlareSystemCoinbase(governanceSettingsHook),
+		withFlareSystemCoinbase(initialAirdropChangeHook),
+		withFlareSystemCoinbase(distributionChangeHook),
+		daemonHook,
+	)).
+	AddValues(songbirdSystemHookChainIDs, withSystemHookMetrics(
+		stateConnectorHook,
+		daemonHook,
+	))
+
+// SystemHookContext is the information about the message being applied that
+// is available to system hooks.
+type SystemHookContext struct {
+	ChainID   *big.Int
+	Timestamp uint64
+	IsDurango bool
+	Coinbase  common.Address
+	Msg       *Message
+	// Return data of the message call. Not set for contract creations.
+	Ret []byte
+}
+
+// SystemHook is a Flare protocol hook that is run as part of a state
+// transition, typically to let a system contract perform a privileged
+// operation.
+//
+// All hooks of a stage which are active in the block and match the message are
+// run, in the order they are registered. The hooks run after a call match
+// messages to distinct system contracts, so at most one of them runs.
+type SystemHook struct {
+	// Name identifies the hook in logs and metrics.
+	Name  string
+	Stage SystemHookStage
+	// Active reports whether the hook runs in the block, independently of the
+	// message. If nil, the hook is always active.
+	Active func(ctx *SystemHookContext) bool
+	// Match reports whether the message triggers the hook, including whether
+	// the hook is activated at the block timestamp.
+	Match func(ctx *SystemHookContext) bool
+	// Handle runs the hook. Errors are logged and do not fail the message.
+	Handle func(st *StateTransition, ctx *SystemHookContext) error
+
+	// Set when the hook is registered.
+	callsCounter  metrics.Counter
+	errorsCounter metrics.Counter
+}
+
+func (h *SystemHook) applies(ctx *SystemHookContext) bool {
+	return (h.Active == nil || h.Active(ctx)) && h.Match(ctx)
+}
+
+// RegisterSystemHook adds [hook] to the system hooks of [chainIDs]. It is not
+// safe to call concurrently with state transitions, so it should be called
+// from an init function.
+func RegisterSystemHook(hook *SystemHook, chainIDs ...*big.Int) {
+	withSystemHookMetrics(hook)
+	for _, chainID := range chainIDs {
+		hooks := slices.Clone(systemHooks.GetValue(chainID))
+		systemHooks.AddValue(chainID, append(hooks, hook))
+	}
+}
+
+// withSystemHookMetrics registers the metrics of [hooks] and returns them.
+func withSystemHookMetrics(hooks ...*SystemHook) []*SystemHook {
+	for _, hook := range hooks {
+		hook.callsCounter = metrics.GetOrRegisterCounter("flare/hooks/"+hook.Name+"/calls", nil)
+		hook.errorsCounter = metrics.GetOrRegisterCounter("flare/hooks/"+hook.Name+"/errors", nil)
+	}
+	return hooks
+}
+
+// SystemHooks returns the system hooks of [chainID] in the order they are
+// run.
+func SystemHooks(chainID *big.Int) []*SystemHook {
+	return slices.Clone(systemHooks.GetValue(chainID))
+}
+
+// runSystemHooks runs the hooks of [stage] that apply to [ctx], in the order
+// they are registered.
+func (st *StateTransition) runSystemHooks(stage SystemHookStage, ctx *SystemHookContext) {
+	for _, hook := range systemHooks.GetValue(ctx.ChainID) {
+		if hook.Stage != stage || !hook.applies(ctx) {
+			continue
+		}
+		hook.callsCounter.Inc(1)
+		if err := hook.Handle(st, ctx); err != nil {
+			hook.errorsCounter.Inc(1)
+			log.Warn("Error running system hook", "hook", hook.Name, "error", err)
+		}
+	}
+}
+
+// withFlareSystemCoinbase returns a copy of [hook] that is only active in
+// blocks with the default coinbase. On the Flare networks, the system hooks
+// run after a call are skipped in blocks with any other coinbase.
+func withFlareSystemCoinbase(hook *SystemHook) *SystemHook {
+	active := hook.Active
+	gated := *hook
+	gated.Active = func(ctx *SystemHookContext) bool {
+		if ctx.Coinbase != common.HexToAddress("0x0100000000000000000000000000000000000000") {
+			return false
+		}
+		return active == nil || active(ctx)
+	}
+	return &gated
+}
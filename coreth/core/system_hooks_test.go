@@ -0,0 +1,309 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm/upgrade/ap3"
+)
+
+var flareSystemCoinbase = common.HexToAddress("0x0100000000000000000000000000000000000000")
+
+func systemHookNames(hooks []*SystemHook) []string {
+	names := make([]string, len(hooks))
+	for i, hook := range hooks {
+		names[i] = hook.Name
+	}
+	return names
+}
+
+func TestSystemHooks(t *testing.T) {
+	for _, chainID := range flareSystemHookChainIDs {
+		require.Equal(t,
+			[]string{"stateConnector", "governanceSettings", "initialAirdropChange", "distributionChange", "daemon"},
+			systemHookNames(SystemHooks(chainID)),
+		)
+	}
+	for _, chainID := range songbirdSystemHookChainIDs {
+		require.Equal(t, []string{"stateConnector", "daemon"}, systemHookNames(SystemHooks(chainID)))
+	}
+	require.Empty(t, SystemHooks(big.NewInt(1)))
+	require.Empty(t, SystemHooks(nil))
+}
+
+func TestSystemHookActivation(t *testing.T) {
+	flareStateConnectorHook := SystemHooks(params.FlareChainID)[0]
+	flareGovernanceSettingsHook := SystemHooks(params.FlareChainID)[1]
+
+	stateConnector := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	submitAttestation := func(chainID *big.Int, timestamp uint64) []byte {
+		return append(SubmitAttestationSelector(chainID, timestamp), make([]byte, 32)...)
+	}
+	newRound := common.LeftPadBytes([]byte{1}, 32)
+	governanceSettings := common.HexToAddress("0x1000000000000000000000000000000000000007")
+	setTimelock := append(SetTimelockSelector(params.CostwoChainID, costwoGovActivationTime), make([]byte, 32)...)
+
+	tests := []struct {
+		name      string
+		hook      *SystemHook
+		chainID   *big.Int
+		timestamp uint64
+		isDurango bool
+		coinbase  common.Address
+		to        common.Address
+		data      []byte
+		ret       []byte
+		want      bool
+	}{
+		{
+			name:      "flare state connector before activation",
+			hook:      flareStateConnectorHook,
+			chainID:   params.FlareChainID,
+			timestamp: flareActivationTime - 1,
+			coinbase:  flareSystemCoinbase,
+			to:        stateConnector,
+			data:      submitAttestation(params.FlareChainID, flareActivationTime),
+			ret:       newRound,
+		},
+		{
+			name:      "flare state connector at activation",
+			hook:      flareStateConnectorHook,
+			chainID:   params.FlareChainID,
+			timestamp: flareActivationTime,
+			coinbase:  flareSystemCoinbase,
+			to:        stateConnector,
+			data:      submitAttestation(params.FlareChainID, flareActivationTime),
+			ret:       newRound,
+			want:      true,
+		},
+		{
+			name:      "flare state connector with other coinbase",
+			hook:      flareStateConnectorHook,
+			chainID:   params.FlareChainID,
+			timestamp: flareActivationTime,
+			coinbase:  common.HexToAddress("0x01"),
+			to:        stateConnector,
+			data:      submitAttestation(params.FlareChainID, flareActivationTime),
+			ret:       newRound,
+		},
+		{
+			name:      "flare state connector in durango",
+			hook:      flareStateConnectorHook,
+			chainID:   params.FlareChainID,
+			timestamp: flareActivationTime,
+			isDurango: true,
+			coinbase:  flareSystemCoinbase,
+			to:        stateConnector,
+			data:      submitAttestation(params.FlareChainID, flareActivationTime),
+			ret:       newRound,
+		},
+		{
+			name:      "songbird state connector at activation",
+			hook:      stateConnectorHook,
+			chainID:   params.SongbirdChainID,
+			timestamp: songbirdActivationTime,
+			to:        common.HexToAddress("0x3A1b3220527aBA427d1e13e4b4c48c31460B4d91"),
+			data:      submitAttestation(params.SongbirdChainID, songbirdActivationTime+1),
+			ret:       newRound,
+		},
+		{
+			name:      "songbird state connector after activation with any coinbase",
+			hook:      stateConnectorHook,
+			chainID:   params.SongbirdChainID,
+			timestamp: songbirdActivationTime + 1,
+			coinbase:  common.HexToAddress("0x01"),
+			to:        common.HexToAddress("0x3A1b3220527aBA427d1e13e4b4c48c31460B4d91"),
+			data:      submitAttestation(params.SongbirdChainID, songbirdActivationTime+1),
+			ret:       newRound,
+			want:      true,
+		},
+		{
+			name:      "costwo governance settings before activation",
+			hook:      flareGovernanceSettingsHook,
+			chainID:   params.CostwoChainID,
+			timestamp: costwoGovActivationTime - 1,
+			coinbase:  flareSystemCoinbase,
+			to:        governanceSettings,
+			data:      setTimelock,
+		},
+		{
+			name:      "costwo governance settings at activation",
+			hook:      flareGovernanceSettingsHook,
+			chainID:   params.CostwoChainID,
+			timestamp: costwoGovActivationTime,
+			coinbase:  flareSystemCoinbase,
+			to:        governanceSettings,
+			data:      setTimelock,
+			want:      true,
+		},
+		{
+			name:      "songbird governance settings",
+			hook:      governanceSettingsHook,
+			chainID:   params.SongbirdChainID,
+			timestamp: flareGovActivationTime,
+			to:        governanceSettings,
+			data:      setTimelock,
+		},
+		{
+			name:      "flare distribution change at activation",
+			hook:      distributionChangeHook,
+			chainID:   params.FlareChainID,
+			timestamp: flareDistributionChangeActivationTime,
+			to:        common.HexToAddress("0x4d1c42F41555Ae35DfC1819bd718f7D9Fb28abdD"),
+			data:      UpdateDistributionAddressSelector(params.FlareChainID, flareDistributionChangeActivationTime),
+			want:      true,
+		},
+		{
+			name:      "daemon",
+			hook:      daemonHook,
+			chainID:   params.SongbirdChainID,
+			isDurango: true,
+			want:      true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := &SystemHookContext{
+				ChainID:   test.chainID,
+				Timestamp: test.timestamp,
+				IsDurango: test.isDurango,
+				Coinbase:  test.coinbase,
+				Msg:       &Message{To: &test.to, Data: test.data},
+				Ret:       test.ret,
+			}
+			require.Equal(t, test.want, test.hook.applies(ctx))
+		})
+	}
+}
+
+func TestGovernanceSettingsHookMatch(t *testing.T) {
+	governanceSettings := common.HexToAddress("0x1000000000000000000000000000000000000007")
+	other := common.HexToAddress("0x1000000000000000000000000000000000000008")
+	setTimelock := append(SetTimelockSelector(params.FlareChainID, flareGovActivationTime), make([]byte, 32)...)
+
+	tests := []struct {
+		name string
+		to   common.Address
+		data []byte
+		want bool
+	}{
+		{
+			name: "set timelock",
+			to:   governanceSettings,
+			data: setTimelock,
+			want: true,
+		},
+		{
+			name: "short call data",
+			to:   governanceSettings,
+			data: setTimelock[:4],
+		},
+		{
+			name: "other contract",
+			to:   other,
+			data: setTimelock,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := &SystemHookContext{
+				ChainID:   params.FlareChainID,
+				Timestamp: flareGovActivationTime,
+				Coinbase:  flareSystemCoinbase,
+				Msg:       &Message{To: &test.to, Data: test.data},
+			}
+			require.Equal(t, test.want, governanceSettingsHook.applies(ctx))
+		})
+	}
+}
+
+func TestRunSystemHooks(t *testing.T) {
+	require := require.New(t)
+
+	chainID := big.NewInt(424242)
+	t.Cleanup(func() {
+		systemHooks.AddValue(chainID, nil)
+	})
+
+	var ran []string
+	newHook := func(name string, stage SystemHookStage, match bool, err error) *SystemHook {
+		return &SystemHook{
+			Name:  name,
+			Stage: stage,
+			Match: func(*SystemHookContext) bool { return match },
+			Handle: func(*StateTransition, *SystemHookContext) error {
+				ran = append(ran, name)
+				return err
+			},
+		}
+	}
+	RegisterSystemHook(newHook("unmatched", SystemHookAfterCall, false, nil), chainID)
+	RegisterSystemHook(newHook("first", SystemHookAfterCall, true, errors.New("test")), chainID)
+	RegisterSystemHook(newHook("second", SystemHookAfterCall, true, nil), chainID)
+	RegisterSystemHook(newHook("afterFees", SystemHookAfterFees, true, nil), chainID)
+
+	// Registering a hook must not change the hooks of other chains.
+	require.Len(SystemHooks(params.FlareChainID), 5)
+
+	st := &StateTransition{}
+	ctx := &SystemHookContext{ChainID: chainID}
+	st.runSystemHooks(SystemHookAfterCall, ctx)
+	require.Equal([]string{"first", "second"}, ran)
+
+	st.runSystemHooks(SystemHookAfterFees, ctx)
+	require.Equal([]string{"first", "second", "afterFees"}, ran)
+}
+
+// TestRunSystemHooksAfterDaemon checks that a hook registered after the daemon
+// hook of a Flare chain is run.
+func TestRunSystemHooksAfterDaemon(t *testing.T) {
+	require := require.New(t)
+
+	chainID := params.LocalFlareChainID
+	hooks := systemHooks.GetValue(chainID)
+	t.Cleanup(func() {
+		systemHooks.AddValue(chainID, hooks)
+	})
+
+	var ran int
+	RegisterSystemHook(&SystemHook{
+		Name:  "afterDaemon",
+		Stage: SystemHookAfterFees,
+		Match: func(*SystemHookContext) bool { return true },
+		Handle: func(*StateTransition, *SystemHookContext) error {
+			ran++
+			return nil
+		},
+	}, chainID)
+
+	config := *params.TestFlareChainConfig
+	config.ChainID = chainID
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &Genesis{
+			Config:  &config,
+			Alloc:   types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(ap3.InitialBaseFee),
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, _, _, err := GenerateChainWithGenesis(gspec, dummy.NewCoinbaseFaker(), 1, 10, func(_ int, b *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(0, common.HexToAddress("0x1234"), common.Big1, params.TxGas, big.NewInt(ap3.InitialBaseFee), nil), signer, key)
+		require.NoError(err)
+		b.AddTx(tx)
+	})
+	require.NoError(err)
+	require.Equal(1, ran)
+}
@@ -106,6 +106,10 @@ func (indexer *txIndexer) run(tail *uint64, head uint64, stop chan struct{}, don
 		// Unindex a part of stale indices and forward index tail to HEAD-limit
 		rawdb.UnindexTransactions(indexer.db, tailValue, head-indexer.limit+1, stop, false)
 	}
+	// The address index is bounded by the same limit, but tracks its own tail.
+	if indexer.chain.cacheConfig.AddressIndexing && head >= indexer.limit {
+		indexer.chain.unindexAddresses(head-indexer.limit+1, stop)
+	}
 }
 
 // loop is the scheduler of the indexer, assigning indexing/unindexing tasks depending
@@ -0,0 +1,37 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DaemonMintError classifies why a daemon invocation did not mint.
+type DaemonMintError string
+
+const (
+	DaemonMintErrorNone        DaemonMintError = ""
+	DaemonMintErrorCallFailed  DaemonMintError = "callFailed"
+	DaemonMintErrorInvalidData DaemonMintError = "invalidDaemonData"
+	DaemonMintErrorEmptyData   DaemonMintError = "daemonDataEmpty"
+	DaemonMintErrorMaxExceeded DaemonMintError = "maxMintExceeded"
+)
+
+// DaemonMint records a single invocation of the inflation daemon made at the
+// end of a transaction.
+type DaemonMint struct {
+	TxHash  common.Hash
+	TxIndex uint64
+
+	// MintRequest is the amount requested by the daemon, Minted the amount
+	// credited to the daemon contract.
+	MintRequest *big.Int
+	Minted      *big.Int
+
+	GasUsed  uint64
+	Reverted bool // Whether the daemon state transition was reverted
+	Error    DaemonMintError
+}
@@ -0,0 +1,30 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RoundVotes records how the default attestors voted when a state connector
+// round was finalised.
+type RoundVotes struct {
+	Round       *big.Int
+	BlockNumber uint64
+	TxHash      common.Hash
+	TxIndex     uint64
+
+	Attestors          []common.Address
+	ReachedMajority    bool
+	MajorityDecision   []byte // Merkle root agreed by the majority, if any
+	MajorityAttestors  []common.Address
+	DivergentAttestors []common.Address
+	AbstainedAttestors []common.Address
+
+	// Finalised is true if the majority decision was submitted to the
+	// state connector contract without error.
+	Finalised bool
+}
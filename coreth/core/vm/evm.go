@@ -299,12 +299,25 @@ func (evm *EVM) Interpreter() *EVMInterpreter {
 // The function returns the snapshot in order to permit another opportunity for reverting to the
 // snapshot in the event that the subsequent call to mint() in coreth/core/daemon.go fails.
 func (evm *EVM) DaemonCall(caller ContractRef, addr common.Address, input []byte, gas uint64) (snapshot int, ret []byte, leftOverGas uint64, err error) {
-	// Temporarily disable EVM debugging
-	oldTracer := evm.Config.Tracer
+	return evm.SystemCall(SystemCallDaemon, caller, addr, input, gas)
+}
+
+// SystemCall behaves as DaemonCall, additionally reporting the call to the
+// configured tracer as a frame of the given type if it implements SystemCallLogger.
+func (evm *EVM) SystemCall(typ SystemCallType, caller ContractRef, addr common.Address, input []byte, gas uint64) (snapshot int, ret []byte, leftOverGas uint64, err error) {
+	if tracer, ok := evm.Config.Tracer.(SystemCallLogger); ok {
+		tracer.CaptureSystemEnter(typ, caller.Address(), addr, input, gas)
+		defer func(gasLimit uint64) {
+			tracer.CaptureSystemExit(ret, gasLimit-leftOverGas, err)
+		}(gas)
+	}
+	// Temporarily disable EVM debugging, and the recording of call
+	// participants as the system call is not part of the transaction
+	oldTracer, oldRecord := evm.Config.Tracer, evm.Config.RecordCallParticipants
 	defer func() {
-		evm.Config.Tracer = oldTracer
+		evm.Config.Tracer, evm.Config.RecordCallParticipants = oldTracer, oldRecord
 	}()
-	evm.Config.Tracer = nil
+	evm.Config.Tracer, evm.Config.RecordCallParticipants = nil, false
 
 	value := uint256.NewInt(0)
 	// Fail if we're trying to execute above the call depth limit
@@ -406,6 +419,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, vmerrs.ErrDepth
 	}
+	evm.recordCallParticipants(caller.Address(), addr)
 	// Fail if we're trying to transfer more than the available balance
 	if !value.IsZero() && !evm.Context.CanTransfer(evm.StateDB, caller.Address(), value) {
 		return nil, gas, vmerrs.ErrInsufficientBalance
@@ -493,6 +507,7 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, vmerrs.ErrDepth
 	}
+	evm.recordCallParticipants(caller.Address(), addr)
 	// Fail if we're trying to transfer more than the available balance
 	// Note although it's noop to transfer X ether to caller itself. But
 	// if caller doesn't have enough balance, it would be an error to allow
@@ -544,6 +559,7 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, vmerrs.ErrDepth
 	}
+	evm.recordCallParticipants(caller.Address(), addr)
 	var snapshot = evm.StateDB.Snapshot()
 
 	// Invoke tracer hooks that signal entering/exiting a call frame
@@ -587,6 +603,7 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, gas, vmerrs.ErrDepth
 	}
+	evm.recordCallParticipants(caller.Address(), addr)
 	// We take a snapshot here. This is a bit counter-intuitive, and could probably be skipped.
 	// However, even a staticcall is considered a 'touch'. On mainnet, static calls were introduced
 	// after all empty accounts were deleted, so this is not required. However, if we omit this,
@@ -653,6 +670,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	if evm.depth > int(params.CallCreateDepth) {
 		return nil, common.Address{}, gas, vmerrs.ErrDepth
 	}
+	evm.recordCallParticipants(caller.Address(), address)
 	// Note: it is not possible for a negative value to be passed in here due to the fact
 	// that [value] will be popped from the stack and decoded to a *big.Int, which will
 	// always yield a positive result.
@@ -761,6 +779,19 @@ func (evm *EVM) Create2(caller ContractRef, code []byte, gas uint64, endowment *
 	return evm.create(caller, codeAndHash, gas, endowment, contractAddr, CREATE2)
 }
 
+// recordCallParticipants records [caller] and [callee] of an internal call
+// if enabled and supported by the state database. Unlike logs, participants of
+// failing or reverted calls are recorded as well.
+func (evm *EVM) recordCallParticipants(caller, callee common.Address) {
+	if !evm.Config.RecordCallParticipants || evm.depth == 0 {
+		return
+	}
+	if recorder, ok := evm.StateDB.(callParticipantRecorder); ok {
+		recorder.AddCallParticipant(caller)
+		recorder.AddCallParticipant(callee)
+	}
+}
+
 // ChainConfig returns the environment's chain configuration
 func (evm *EVM) ChainConfig() *params.ChainConfig { return evm.chainConfig }
 
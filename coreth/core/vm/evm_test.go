@@ -6,8 +6,14 @@ package vm
 import (
 	"testing"
 
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsProhibited(t *testing.T) {
@@ -33,3 +39,28 @@ func TestIsProhibited(t *testing.T) {
 	assert.False(t, IsProhibited(common.HexToAddress("0x0200000000000000000000000000000000000100")))
 	assert.False(t, IsProhibited(common.HexToAddress("0x0300000000000000000000000000000000000100")))
 }
+
+func TestSystemCallDoesNotRecordCallParticipants(t *testing.T) {
+	caller, callee := common.HexToAddress("0xaaaa"), common.HexToAddress("0xbbbb")
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	// CALL(gas, 0xbbbb, 0, 0, 0, 0, 0)
+	statedb.SetCode(caller, common.FromHex("6000600060006000600061bbbb5af100"))
+	statedb.Finalise(true)
+
+	vmctx := BlockContext{
+		BlockNumber: common.Big0,
+		Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+	}
+	evm := NewEVM(vmctx, TxContext{}, statedb, params.TestFlareChainConfig, Config{RecordCallParticipants: true})
+
+	// The internal calls of a system call are not part of the transaction.
+	_, _, _, err = evm.SystemCall(SystemCallDaemon, AccountRef(common.Address{}), caller, nil, 100_000)
+	require.NoError(t, err)
+	require.Empty(t, statedb.CallParticipants())
+	require.True(t, evm.Config.RecordCallParticipants)
+
+	_, _, err = evm.Call(AccountRef(common.Address{}), caller, nil, 100_000, new(uint256.Int))
+	require.NoError(t, err)
+	require.Equal(t, map[int][]common.Address{0: {caller, callee}}, statedb.CallParticipants())
+}
@@ -102,6 +102,12 @@ type StateDB interface {
 	AddPreimage(common.Hash, []byte)
 }
 
+// callParticipantRecorder is implemented by state databases that keep a record
+// of the addresses taking part in internal calls of a transaction.
+type callParticipantRecorder interface {
+	AddCallParticipant(addr common.Address)
+}
+
 // CallContext provides a basic interface for the EVM calling conventions. The EVM
 // depends on this context being implemented for doing subcalls and initialising new EVM contracts.
 type CallContext interface {
@@ -51,3 +51,21 @@ type EVMLogger interface {
 	CaptureState(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, rData []byte, depth int, err error)
 	CaptureFault(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, depth int, err error)
 }
+
+// SystemCallType identifies on whose behalf the node made a system call.
+type SystemCallType string
+
+const (
+	SystemCallDaemon         SystemCallType = "DAEMON"
+	SystemCallStateConnector SystemCallType = "STATE_CONNECTOR"
+	SystemCallGovernance     SystemCallType = "GOVERNANCE"
+)
+
+// SystemCallLogger is an optional extension of EVMLogger. System calls are
+// made by the node itself (see EVM.SystemCall) and are hidden from ordinary
+// loggers; loggers implementing this interface are notified of each system
+// call as a single frame. Calls nested within a system call are not traced.
+type SystemCallLogger interface {
+	CaptureSystemEnter(typ SystemCallType, from common.Address, to common.Address, input []byte, gas uint64)
+	CaptureSystemExit(output []byte, gasUsed uint64, err error)
+}
@@ -0,0 +1,151 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// defaultAddressTxsLimit is the page size of eth_getTransactionsByAddress
+	// if none is requested.
+	defaultAddressTxsLimit = 100
+	// maxAddressTxsLimit is the largest page size of eth_getTransactionsByAddress.
+	maxAddressTxsLimit = 1000
+)
+
+var (
+	errAddressIndexingDisabled = errors.New("address indexing is disabled, enable it with address-indexing")
+	errInvalidAddressTxsCursor = errors.New("invalid cursor")
+)
+
+// AddressIndexAPI serves the transactions of an address from the address index.
+type AddressIndexAPI struct {
+	e *Ethereum
+}
+
+// NewAddressIndexAPI creates a new address index API.
+func NewAddressIndexAPI(e *Ethereum) *AddressIndexAPI {
+	return &AddressIndexAPI{e}
+}
+
+// AddressTxsArgs are the arguments of eth_getTransactionsByAddress.
+type AddressTxsArgs struct {
+	FromBlock *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock   *rpc.BlockNumber `json:"toBlock"`
+	Cursor    hexutil.Bytes    `json:"cursor"`
+	Limit     *hexutil.Uint64  `json:"limit"`
+}
+
+// AddressTx is a transaction an address took part in.
+type AddressTx struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	TxHash      common.Hash    `json:"transactionHash"`
+	TxIndex     hexutil.Uint64 `json:"transactionIndex"`
+	Sender      bool           `json:"sender"`
+	Recipient   bool           `json:"recipient"`
+	Internal    bool           `json:"internal"`
+}
+
+// AddressTxsResult is a page of the transactions of an address. Cursor is
+// set if there are more transactions, and must be passed to fetch them.
+type AddressTxsResult struct {
+	Transactions []*AddressTx  `json:"transactions"`
+	Cursor       hexutil.Bytes `json:"cursor,omitempty"`
+}
+
+// GetTransactionsByAddress returns the accepted transactions [address] sent,
+// received or, if enabled, took part in through internal calls, in ascending
+// order. The block range defaults to all indexed blocks, which are the last
+// transaction-history blocks if it is set. Results are paginated with the
+// returned cursor.
+func (api *AddressIndexAPI) GetTransactionsByAddress(ctx context.Context, address common.Address, args *AddressTxsArgs) (*AddressTxsResult, error) {
+	if !api.e.config.AddressIndexing {
+		return nil, errAddressIndexingDisabled
+	}
+	if args == nil {
+		args = new(AddressTxsArgs)
+	}
+	limit := uint64(defaultAddressTxsLimit)
+	if args.Limit != nil {
+		limit = uint64(*args.Limit)
+	}
+	if limit == 0 || limit > maxAddressTxsLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxAddressTxsLimit)
+	}
+
+	result := &AddressTxsResult{Transactions: make([]*AddressTx, 0)}
+	db := api.e.ChainDb()
+	tail, head := rawdb.ReadAddressIndexTail(db), rawdb.ReadAddressIndexHead(db)
+	if tail == nil || head == nil || *tail > *head {
+		return result, nil
+	}
+	// Unindexing happens in the background, so the tail may lag behind.
+	first := *tail
+	if history := api.e.config.TransactionHistory; history > 0 && *head >= history {
+		first = max(first, *head-history+1)
+	}
+	resolve := func(number *rpc.BlockNumber, def uint64) uint64 {
+		switch {
+		case number == nil:
+			return def
+		case *number < 0 || uint64(*number) > *head:
+			return *head
+		default:
+			return max(uint64(*number), first)
+		}
+	}
+	from, to := resolve(args.FromBlock, first), resolve(args.ToBlock, *head)
+	if from > to {
+		return nil, fmt.Errorf("fromBlock (%d) is after toBlock (%d)", from, to)
+	}
+
+	var index uint32
+	if len(args.Cursor) > 0 {
+		if len(args.Cursor) != 12 {
+			return nil, errInvalidAddressTxsCursor
+		}
+		number := binary.BigEndian.Uint64(args.Cursor)
+		if number < from || number > to {
+			return nil, fmt.Errorf("%w: block %d is outside of [%d, %d]", errInvalidAddressTxsCursor, number, from, to)
+		}
+		from, index = number, binary.BigEndian.Uint32(args.Cursor[8:])
+	}
+
+	entries := rawdb.ReadAddressTxEntries(db, address, from, index, to, int(limit)+1)
+	if uint64(len(entries)) > limit {
+		next := entries[limit]
+		result.Cursor = binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint64(nil, next.BlockNumber), next.TxIndex)
+		entries = entries[:limit]
+	}
+	bc := api.e.BlockChain()
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block := bc.GetBlockByNumber(entry.BlockNumber)
+		if block == nil || int(entry.TxIndex) >= len(block.Transactions()) {
+			return nil, fmt.Errorf("transaction %d of block %d not found", entry.TxIndex, entry.BlockNumber)
+		}
+		result.Transactions = append(result.Transactions, &AddressTx{
+			BlockNumber: hexutil.Uint64(entry.BlockNumber),
+			BlockHash:   block.Hash(),
+			TxHash:      block.Transactions()[entry.TxIndex].Hash(),
+			TxIndex:     hexutil.Uint64(entry.TxIndex),
+			Sender:      entry.Roles&rawdb.AddressRoleSender != 0,
+			Recipient:   entry.Roles&rawdb.AddressRoleRecipient != 0,
+			Internal:    entry.Roles&rawdb.AddressRoleInternal != 0,
+		})
+	}
+	return result, nil
+}
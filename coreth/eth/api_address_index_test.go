@@ -0,0 +1,125 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/eth/ethconfig"
+	"github.com/ava-labs/coreth/node"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm/upgrade/ap3"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// newAddressIndexBackend returns a backend with [n] accepted blocks, each
+// transferring 1 wei from the returned address to [to].
+func newAddressIndexBackend(t *testing.T, n int, to common.Address, addressIndexing bool) (*Ethereum, common.Address, []*types.Block) {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	gspec := &core.Genesis{
+		Config:  params.TestFlareChainConfig,
+		Alloc:   types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		BaseFee: big.NewInt(ap3.InitialBaseFee),
+	}
+	stack, err := node.New(&node.Config{})
+	require.NoError(t, err)
+
+	config := ethconfig.NewDefaultConfig()
+	config.Genesis = gspec
+	config.AddressIndexing = addressIndexing
+	engine := dummy.NewETHFaker()
+	backend, err := New(stack, &config, nil, rawdb.NewMemoryDatabase(), Settings{}, common.Hash{}, engine, &mockable.Clock{})
+	require.NoError(t, err)
+	backend.Start()
+	t.Cleanup(func() { require.NoError(t, backend.Stop()) })
+
+	signer := types.LatestSigner(gspec.Config)
+	_, blocks, _, err := core.GenerateChainWithGenesis(gspec, engine, n, 10, func(i int, b *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(uint64(i), to, common.Big1, params.TxGas, b.BaseFee(), nil), signer, key)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	})
+	require.NoError(t, err)
+
+	chain := backend.BlockChain()
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		require.NoError(t, chain.Accept(block))
+	}
+	chain.DrainAcceptorQueue()
+	return backend, addr, blocks
+}
+
+func TestGetTransactionsByAddress(t *testing.T) {
+	to := common.HexToAddress("0x0dad")
+	backend, from, blocks := newAddressIndexBackend(t, 5, to, true)
+	api := NewAddressIndexAPI(backend)
+	ctx := context.Background()
+
+	// Pages through the transactions sent by [from] two at a time.
+	var (
+		limit  = hexutil.Uint64(2)
+		args   = &AddressTxsArgs{Limit: &limit}
+		hashes []common.Hash
+		pages  int
+	)
+	for {
+		res, err := api.GetTransactionsByAddress(ctx, from, args)
+		require.NoError(t, err)
+		for _, tx := range res.Transactions {
+			require.True(t, tx.Sender)
+			require.False(t, tx.Recipient)
+			require.Equal(t, blocks[tx.BlockNumber-1].Hash(), tx.BlockHash)
+			hashes = append(hashes, tx.TxHash)
+		}
+		pages++
+		if res.Cursor == nil {
+			break
+		}
+		args.Cursor = res.Cursor
+	}
+	require.Equal(t, 3, pages)
+	require.Len(t, hashes, 5)
+	for i, block := range blocks {
+		require.Equal(t, block.Transactions()[0].Hash(), hashes[i])
+	}
+
+	fromBlock, toBlock := rpc.BlockNumber(2), rpc.BlockNumber(3)
+	res, err := api.GetTransactionsByAddress(ctx, to, &AddressTxsArgs{FromBlock: &fromBlock, ToBlock: &toBlock})
+	require.NoError(t, err)
+	require.Len(t, res.Transactions, 2)
+	require.Equal(t, hexutil.Uint64(2), res.Transactions[0].BlockNumber)
+	require.True(t, res.Transactions[0].Recipient)
+	require.Nil(t, res.Cursor)
+
+	res, err = api.GetTransactionsByAddress(ctx, common.HexToAddress("0xdead"), nil)
+	require.NoError(t, err)
+	require.Empty(t, res.Transactions)
+
+	_, err = api.GetTransactionsByAddress(ctx, from, &AddressTxsArgs{Cursor: hexutil.Bytes{1}})
+	require.ErrorIs(t, err, errInvalidAddressTxsCursor)
+
+	limit = 0
+	_, err = api.GetTransactionsByAddress(ctx, from, &AddressTxsArgs{Limit: &limit})
+	require.ErrorContains(t, err, "limit must be between")
+}
+
+func TestGetTransactionsByAddressDisabled(t *testing.T) {
+	backend, from, _ := newAddressIndexBackend(t, 1, common.HexToAddress("0x0dad"), false)
+	_, err := NewAddressIndexAPI(backend).GetTransactionsByAddress(context.Background(), from, nil)
+	require.ErrorIs(t, err, errAddressIndexingDisabled)
+}
@@ -29,7 +29,9 @@ package eth
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
+	"runtime"
 	"time"
 
 	"github.com/ava-labs/coreth/accounts"
@@ -83,6 +85,22 @@ func (b *EthAPIBackend) HistoricalProofQueryWindow() uint64 {
 	return b.historicalProofQueryWindow
 }
 
+// HistoricalStateRegeneration returns true if states pruned from a non-archive
+// node are regenerated for state queries.
+func (b *EthAPIBackend) HistoricalStateRegeneration() bool {
+	return b.eth.historicalStateRegeneration()
+}
+
+// HistoricalStateReexec returns the maximum number of blocks re-executed to
+// regenerate a historical state, or 0 if historical state regeneration is
+// disabled.
+func (b *EthAPIBackend) HistoricalStateReexec() uint64 {
+	if !b.eth.historicalStateRegeneration() {
+		return 0
+	}
+	return b.eth.config.HistoricalStateReexec
+}
+
 func (b *EthAPIBackend) IsAllowUnfinalizedQueries() bool {
 	return b.allowUnfinalizedQueries
 }
@@ -254,7 +272,7 @@ func (b *EthAPIBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.B
 	if header == nil {
 		return nil, nil, errors.New("header not found")
 	}
-	stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+	stateDb, err := b.stateAt(ctx, header)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -276,7 +294,7 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 		if header == nil {
 			return nil, nil, errors.New("header for hash not found")
 		}
-		stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+		stateDb, err := b.stateAt(ctx, header)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -285,6 +303,33 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 	return nil, nil, errors.New("invalid arguments; neither block nor hash specified")
 }
 
+// stateAt returns the state at [header]. If it is not available and historical
+// state regeneration is enabled, it is regenerated from the nearest committed
+// state and held until [ctx] is done, or until the returned state is garbage
+// collected if [ctx] is never done.
+func (b *EthAPIBackend) stateAt(ctx context.Context, header *types.Header) (*state.StateDB, error) {
+	stateDb, err := b.eth.BlockChain().StateAt(header.Root)
+	if err == nil || !b.HistoricalStateRegeneration() {
+		return stateDb, err
+	}
+	block := b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64())
+	if block == nil {
+		return nil, fmt.Errorf("block %s not found", header.Hash())
+	}
+	stateDb, release, err := b.eth.stateAtBlock(ctx, block, b.HistoricalStateReexec(), nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	// A context that is never done does not bound the use of the state, which
+	// is then held until it is garbage collected.
+	if ctx.Done() == nil {
+		runtime.SetFinalizer(stateDb, func(*state.StateDB) { release() })
+	} else {
+		context.AfterFunc(ctx, release)
+	}
+	return stateDb, nil
+}
+
 func (b *EthAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
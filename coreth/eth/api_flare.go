@@ -0,0 +1,106 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var errDaemonMintIndexingDisabled = errors.New("daemon mint indexing is disabled, enable it with daemon-mint-indexing")
+
+// FlareAPI provides access to Flare specific chain data.
+type FlareAPI struct {
+	e *Ethereum
+}
+
+// NewFlareAPI creates a new Flare API.
+func NewFlareAPI(e *Ethereum) *FlareAPI {
+	return &FlareAPI{e}
+}
+
+// DaemonMintResult is a daemon invocation of an accepted block.
+type DaemonMintResult struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	TxHash      common.Hash    `json:"transactionHash"`
+	TxIndex     hexutil.Uint64 `json:"transactionIndex"`
+	MintRequest *hexutil.Big   `json:"mintRequest"`
+	Minted      *hexutil.Big   `json:"minted"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Reverted    bool           `json:"reverted"`
+	Error       string         `json:"error,omitempty"`
+}
+
+// GetDaemonMints returns the daemon invocations of the accepted blocks in
+// [fromBlock, toBlock]. The latest, pending, safe and finalized tags resolve
+// to the last accepted block. Requires daemon mint indexing to be enabled.
+func (api *FlareAPI) GetDaemonMints(ctx context.Context, fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) ([]*DaemonMintResult, error) {
+	if !api.e.config.DaemonMintIndexing {
+		return nil, errDaemonMintIndexingDisabled
+	}
+	lastAccepted := api.e.BlockChain().LastAcceptedBlock().NumberU64()
+	from, err := resolveAcceptedBlockNumber(fromBlock, lastAccepted)
+	if err != nil {
+		return nil, fmt.Errorf("fromBlock: %w", err)
+	}
+	to, err := resolveAcceptedBlockNumber(toBlock, lastAccepted)
+	if err != nil {
+		return nil, fmt.Errorf("toBlock: %w", err)
+	}
+	if from > to {
+		return nil, fmt.Errorf("fromBlock (%d) is after toBlock (%d)", from, to)
+	}
+	if maxBlocks := api.e.settings.MaxBlocksPerRequest; maxBlocks > 0 && to-from >= uint64(maxBlocks) {
+		return nil, fmt.Errorf("requested too many blocks from %d to %d, maximum is set to %d", from, to, maxBlocks)
+	}
+
+	results := make([]*DaemonMintResult, 0)
+	for number := from; number <= to; number++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		mints := rawdb.ReadDaemonMints(api.e.ChainDb(), number)
+		if len(mints) == 0 {
+			continue
+		}
+		blockHash := rawdb.ReadCanonicalHash(api.e.ChainDb(), number)
+		for _, mint := range mints {
+			results = append(results, &DaemonMintResult{
+				BlockNumber: hexutil.Uint64(number),
+				BlockHash:   blockHash,
+				TxHash:      mint.TxHash,
+				TxIndex:     hexutil.Uint64(mint.TxIndex),
+				MintRequest: (*hexutil.Big)(mint.MintRequest),
+				Minted:      (*hexutil.Big)(mint.Minted),
+				GasUsed:     hexutil.Uint64(mint.GasUsed),
+				Reverted:    mint.Reverted,
+				Error:       string(mint.Error),
+			})
+		}
+	}
+	return results, nil
+}
+
+// resolveAcceptedBlockNumber returns the accepted block [number] refers to,
+// given the [lastAccepted] block.
+func resolveAcceptedBlockNumber(number rpc.BlockNumber, lastAccepted uint64) (uint64, error) {
+	switch {
+	case number == rpc.LatestBlockNumber || number == rpc.PendingBlockNumber ||
+		number == rpc.SafeBlockNumber || number == rpc.FinalizedBlockNumber:
+		return lastAccepted, nil
+	case number < 0:
+		return 0, fmt.Errorf("unsupported block number %d", number)
+	case uint64(number) > lastAccepted:
+		return 0, fmt.Errorf("block %d is after the last accepted block %d", number, lastAccepted)
+	default:
+		return uint64(number), nil
+	}
+}
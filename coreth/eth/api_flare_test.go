@@ -0,0 +1,42 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/rpc"
+)
+
+func TestResolveAcceptedBlockNumber(t *testing.T) {
+	const lastAccepted = 10
+	tests := []struct {
+		number  rpc.BlockNumber
+		want    uint64
+		wantErr bool
+	}{
+		{number: rpc.EarliestBlockNumber, want: 0},
+		{number: 5, want: 5},
+		{number: lastAccepted, want: lastAccepted},
+		{number: lastAccepted + 1, wantErr: true},
+		{number: rpc.LatestBlockNumber, want: lastAccepted},
+		{number: rpc.PendingBlockNumber, want: lastAccepted},
+		{number: rpc.SafeBlockNumber, want: lastAccepted},
+		{number: rpc.FinalizedBlockNumber, want: lastAccepted},
+		{number: -5, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.number.String(), func(t *testing.T) {
+			got, err := resolveAcceptedBlockNumber(test.number, lastAccepted)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
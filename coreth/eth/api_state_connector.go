@@ -0,0 +1,122 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/core/vm"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// defaultRoundVotesReexec is the number of blocks the state connector API is
+// willing to re-execute to rebuild the pre-state of a replayed block.
+const defaultRoundVotesReexec = uint64(128)
+
+var errRoundVotesNotFound = errors.New("round votes not found, enable state-connector-vote-indexing or provide the finalising block")
+
+// StateConnectorAPI provides access to the votes of finalised state connector rounds.
+type StateConnectorAPI struct {
+	e *Ethereum
+}
+
+// NewStateConnectorAPI creates a new state connector API.
+func NewStateConnectorAPI(e *Ethereum) *StateConnectorAPI {
+	return &StateConnectorAPI{e}
+}
+
+// RoundVotesResult is the outcome of a finalised state connector round.
+type RoundVotesResult struct {
+	Round              *hexutil.Big     `json:"round"`
+	BlockNumber        hexutil.Uint64   `json:"blockNumber"`
+	BlockHash          common.Hash      `json:"blockHash"`
+	TxHash             common.Hash      `json:"transactionHash"`
+	TxIndex            hexutil.Uint64   `json:"transactionIndex"`
+	Attestors          []common.Address `json:"attestors"`
+	ReachedMajority    bool             `json:"reachedMajority"`
+	MajorityDecision   hexutil.Bytes    `json:"majorityDecision"`
+	MajorityAttestors  []common.Address `json:"majorityAttestors"`
+	DivergentAttestors []common.Address `json:"divergentAttestors"`
+	AbstainedAttestors []common.Address `json:"abstainedAttestors"`
+	Finalised          bool             `json:"finalised"`
+}
+
+// GetRoundVotes returns how the default attestors voted on the given round.
+// Indexed rounds are served from the database. Otherwise, if the block that
+// finalised the round is given, it is replayed on top of its parent state to
+// recover the votes.
+func (api *StateConnectorAPI) GetRoundVotes(ctx context.Context, round hexutil.Big, blockNrOrHash *rpc.BlockNumberOrHash) (*RoundVotesResult, error) {
+	if votes := rawdb.ReadRoundVotes(api.e.ChainDb(), round.ToInt()); votes != nil {
+		return newRoundVotesResult(votes, rawdb.ReadCanonicalHash(api.e.ChainDb(), votes.BlockNumber)), nil
+	}
+	if blockNrOrHash == nil {
+		return nil, errRoundVotesNotFound
+	}
+	block, err := api.e.APIBackend.BlockByNumberOrHash(ctx, *blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %v not found", *blockNrOrHash)
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis does not finalise rounds")
+	}
+	all, err := api.replayRoundVotes(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	for _, votes := range all {
+		if votes.Round.Cmp(round.ToInt()) == 0 {
+			votes.BlockNumber = block.NumberU64()
+			return newRoundVotesResult(votes, block.Hash()), nil
+		}
+	}
+	return nil, fmt.Errorf("round %v was not finalised in block %d", round.ToInt(), block.NumberU64())
+}
+
+// replayRoundVotes re-executes [block] and returns the rounds it finalised.
+func (api *StateConnectorAPI) replayRoundVotes(ctx context.Context, block *types.Block) ([]*types.RoundVotes, error) {
+	parent, err := api.e.APIBackend.BlockByHash(ctx, block.ParentHash())
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, release, err := api.e.stateAtBlock(ctx, parent, defaultRoundVotesReexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	bc := api.e.BlockChain()
+	if _, _, _, err := bc.Processor().Process(block, parent.Header(), statedb, vm.Config{}); err != nil {
+		return nil, fmt.Errorf("replaying block %d failed: %w", block.NumberU64(), err)
+	}
+	return statedb.RoundVotes(), nil
+}
+
+func newRoundVotesResult(votes *types.RoundVotes, blockHash common.Hash) *RoundVotesResult {
+	return &RoundVotesResult{
+		Round:              (*hexutil.Big)(votes.Round),
+		BlockNumber:        hexutil.Uint64(votes.BlockNumber),
+		BlockHash:          blockHash,
+		TxHash:             votes.TxHash,
+		TxIndex:            hexutil.Uint64(votes.TxIndex),
+		Attestors:          votes.Attestors,
+		ReachedMajority:    votes.ReachedMajority,
+		MajorityDecision:   votes.MajorityDecision,
+		MajorityAttestors:  votes.MajorityAttestors,
+		DivergentAttestors: votes.DivergentAttestors,
+		AbstainedAttestors: votes.AbstainedAttestors,
+		Finalised:          votes.Finalised,
+	}
+}
@@ -49,6 +49,7 @@ import (
 	"github.com/ava-labs/coreth/eth/filters"
 	"github.com/ava-labs/coreth/eth/gasprice"
 	"github.com/ava-labs/coreth/eth/tracers"
+	"github.com/ava-labs/coreth/eth/tracers/flatindex"
 	"github.com/ava-labs/coreth/internal/ethapi"
 	"github.com/ava-labs/coreth/internal/shutdowncheck"
 	"github.com/ava-labs/coreth/miner"
@@ -68,7 +69,9 @@ type Config = ethconfig.Config
 var DefaultSettings Settings = Settings{MaxBlocksPerRequest: 2000}
 
 type Settings struct {
-	MaxBlocksPerRequest int64 // Maximum number of blocks to serve per getLogs request
+	MaxBlocksPerRequest int64               // Maximum number of blocks to serve per getLogs request
+	LogsReplayLimit     uint64              // Maximum number of blocks a logs subscription may replay
+	FlatTraceDB         ethdb.KeyValueStore // Database to store flat call traces in if FlatTraceIndexing is enabled
 }
 
 // PushGossiper sends pushes pending transactions to peers until they are
@@ -113,6 +116,10 @@ type Ethereum struct {
 	stackRPCs []rpc.API
 
 	settings Settings // Settings for Ethereum API
+
+	flatTraceIndexer *flatindex.Indexer // Indexer of flat call traces, nil if FlatTraceIndexing is disabled
+
+	regeneratedStates *regeneratedStates // Cache of regenerated historical states, nil if HistoricalStateRegeneration is disabled
 }
 
 // roundUpCacheSize returns [input] rounded up to the next multiple of [allocSize]
@@ -224,6 +231,10 @@ func New(
 			AcceptedCacheSize:               config.AcceptedCacheSize,
 			TransactionHistory:              config.TransactionHistory,
 			SkipTxIndexing:                  config.SkipTxIndexing,
+			DaemonMintIndexing:              config.DaemonMintIndexing,
+			StateConnectorVoteIndexing:      config.StateConnectorVoteIndexing,
+			AddressIndexing:                 config.AddressIndexing,
+			AddressIndexInternalCalls:       config.AddressIndexInternalCalls,
 			StateHistory:                    config.StateHistory,
 			StateScheme:                     scheme,
 		}
@@ -280,6 +291,17 @@ func New(
 		return nil, err
 	}
 
+	if eth.historicalStateRegeneration() {
+		eth.regeneratedStates = newRegeneratedStates(common.StorageSize(config.HistoricalStateCache) * 1024 * 1024)
+	}
+
+	if config.FlatTraceIndexing {
+		if settings.FlatTraceDB == nil {
+			return nil, errors.New("flat trace indexing requires a FlatTraceDB")
+		}
+		eth.flatTraceIndexer = flatindex.NewIndexer(eth.APIBackend, settings.FlatTraceDB)
+	}
+
 	// Start the RPC service
 	eth.netRPCService = ethapi.NewNetAPI(eth.NetVersion())
 
@@ -298,13 +320,17 @@ func (s *Ethereum) APIs() []rpc.API {
 
 	// Append tracing APIs
 	apis = append(apis, tracers.APIs(s.APIBackend)...)
+	if s.flatTraceIndexer != nil {
+		apis = append(apis, flatindex.APIs(s.APIBackend, s.settings.FlatTraceDB, s.settings.MaxBlocksPerRequest)...)
+	}
 
 	// Add the APIs from the node
 	apis = append(apis, s.stackRPCs...)
 
 	// Create [filterSystem] with the log cache size set in the config.
 	filterSystem := filters.NewFilterSystem(s.APIBackend, filters.Config{
-		Timeout: 5 * time.Minute,
+		Timeout:         5 * time.Minute,
+		LogsReplayLimit: s.settings.LogsReplayLimit,
 	})
 
 	// Append all the local APIs and return
@@ -329,6 +355,18 @@ func (s *Ethereum) APIs() []rpc.API {
 			Namespace: "net",
 			Service:   s.netRPCService,
 			Name:      "net",
+		}, {
+			Namespace: "flare",
+			Service:   NewFlareAPI(s),
+			Name:      "flare",
+		}, {
+			Namespace: "stateConnector",
+			Service:   NewStateConnectorAPI(s),
+			Name:      "state-connector",
+		}, {
+			Namespace: "eth",
+			Service:   NewAddressIndexAPI(s),
+			Name:      "eth-address-index",
 		},
 	}...)
 }
@@ -374,12 +412,19 @@ func (s *Ethereum) Start() {
 
 	// Regularly update shutdown marker
 	s.shutdownTracker.Start()
+
+	if s.flatTraceIndexer != nil {
+		s.flatTraceIndexer.Start()
+	}
 }
 
 // Stop implements node.Lifecycle, terminating all internal goroutines used by the
 // Ethereum protocol.
 // FIXME remove error from type if this will never return an error
 func (s *Ethereum) Stop() error {
+	if s.flatTraceIndexer != nil {
+		s.flatTraceIndexer.Stop()
+	}
 	s.bloomIndexer.Close()
 	close(s.closeBloomHandler)
 	s.txPool.Close()
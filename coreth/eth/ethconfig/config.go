@@ -186,6 +186,14 @@ type Config struct {
 	// For non-archive nodes, it is forcibly set to the value of [core.TipBufferSize].
 	HistoricalProofQueryWindow uint64
 
+	// HistoricalStateRegeneration allows state queries on blocks whose state
+	// was pruned by re-executing at most HistoricalStateReexec blocks on top of
+	// the nearest committed state. The states regenerated this way are cached
+	// in up to HistoricalStateCache MB.
+	HistoricalStateRegeneration bool
+	HistoricalStateReexec       uint64
+	HistoricalStateCache        int
+
 	// AllowUnprotectedTxs allow unprotected transactions to be locally issued.
 	// Unprotected transactions are transactions that are signed without EIP-155
 	// replay protection.
@@ -223,6 +231,27 @@ type Config struct {
 	// TransactionHistory can be still used to control unindexing old transactions.
 	SkipTxIndexing bool
 
+	// DaemonMintIndexing persists the daemon invocations of accepted blocks
+	// so they can be served by the flare API.
+	DaemonMintIndexing bool
+
+	// StateConnectorVoteIndexing persists the default attestor votes of
+	// finalised state connector rounds so they can be served by the
+	// stateConnector API.
+	StateConnectorVoteIndexing bool
+
+	// FlatTraceIndexing traces accepted blocks with the flatCallTracer and
+	// persists the results so they can be served by the trace API. Indexing
+	// starts from the last accepted block when first enabled.
+	FlatTraceIndexing bool
+
+	// AddressIndexing maintains an index of the transactions of each address,
+	// bounded by TransactionHistory, or unbounded if it is 0, in which case
+	// entries are never removed. AddressIndexInternalCalls extends it with the
+	// participants of internal calls.
+	AddressIndexing           bool
+	AddressIndexInternalCalls bool
+
 	// TODO: remove once we move SuggestPriceOptions to AVAX/custom API
 	PriceOptionConfig ethapi.PriceOptionConfig
 }
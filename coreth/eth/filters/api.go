@@ -163,12 +163,20 @@ func (api *FilterAPI) NewPendingTransactionFilter(fullTx *bool) rpc.ID {
 
 // NewPendingTransactions creates a subscription that is triggered each time a
 // transaction enters the transaction pool. If fullTx is true the full tx is
-// sent to the client, otherwise the hash is sent.
-func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool) (*rpc.Subscription, error) {
+// sent to the client, otherwise the hash is sent. If crit is given, only the
+// transactions matching it are sent.
+func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool, crit *PendingTxCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
+	var matcher *pendingTxMatcher
+	if crit != nil {
+		var err error
+		if matcher, err = newPendingTxMatcher(crit, api.sys.backend.ChainConfig()); err != nil {
+			return nil, err
+		}
+	}
 
 	rpcSub := notifier.CreateSubscription()
 
@@ -186,6 +194,9 @@ func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool)
 				// TODO(rjl493456442) Send a batch of tx hashes in one notification
 				latest := api.sys.backend.CurrentHeader()
 				for _, tx := range txs {
+					if matcher != nil && !matcher.match(tx, latest.BaseFee) {
+						continue
+					}
 					if fullTx != nil && *fullTx {
 						rpcTx := ethapi.NewRPCTransaction(tx, latest, latest.BaseFee, chainConfig)
 						notifier.Notify(rpcSub.ID, rpcTx)
@@ -324,15 +335,29 @@ func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 }
 
 // Logs creates a subscription that fires for all new log that match the given filter criteria.
-func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
+// If replay is given, the accepted logs matching the criteria since the requested
+// block or cursor are delivered first, and each log is delivered exactly once.
+func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria, replay *LogsReplay) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
 		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
 	}
 
+	// Resolve the replay before subscribing, so that every block accepted
+	// after [tip] may be delivered by both the replay and the subscription.
+	var start, tip uint64
+	if replay != nil {
+		tip = api.sys.backend.LastAcceptedBlock().NumberU64()
+		var err error
+		if start, err = api.logReplayStart(ctx, replay, tip); err != nil {
+			return nil, err
+		}
+	}
+
 	var (
 		rpcSub      = notifier.CreateSubscription()
 		matchedLogs = make(chan []*types.Log)
+		replayed    = make(chan map[logKey]struct{}, 1)
 		logsSub     event.Subscription
 		err         error
 	)
@@ -349,15 +374,41 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 		}
 	}
 
+	replayCtx, cancelReplay := context.WithCancel(context.Background())
 	go func() {
 		defer logsSub.Unsubscribe()
+		defer cancelReplay()
+
+		// Live logs are buffered until the replay is done, after which the
+		// ones already replayed are skipped.
+		var (
+			waiting = replayed
+			pending [][]*types.Log
+			seen    map[logKey]struct{}
+		)
+		notify := func(logs []*types.Log) {
+			for _, log := range logs {
+				if _, ok := seen[logKey{log.BlockHash, log.Index}]; ok {
+					continue
+				}
+				notifier.Notify(rpcSub.ID, &log)
+			}
+		}
 		for {
 			select {
+			case s := <-waiting:
+				seen, waiting = s, nil
+				for _, logs := range pending {
+					notify(logs)
+				}
+				pending = nil
 			case logs := <-matchedLogs:
-				for _, log := range logs {
-					notifier.Notify(rpcSub.ID, &log)
+				if waiting != nil {
+					pending = append(pending, logs)
+					continue
 				}
-			case <-rpcSub.Err(): // client send an unsubscribe request
+				notify(logs)
+			case <-rpcSub.Err(): // client send an unsubscribe request or the replay failed
 				return
 			case <-notifier.Closed(): // connection dropped
 				return
@@ -365,6 +416,22 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 		}
 	}()
 
+	if replay == nil {
+		replayed <- nil
+		return rpcSub, nil
+	}
+	// The replay is streamed once the subscription is returned, outliving the
+	// request context. Live logs are held back until it is done, so the
+	// replayed logs are delivered first. If the replay fails, the subscription
+	// is ended with its error.
+	go func() {
+		seen, err := api.replayLogs(replayCtx, notifier, rpcSub.ID, crit, start, replay.Cursor, tip)
+		if err != nil {
+			notifier.Unsubscribe(fmt.Errorf("failed to replay logs: %w", err))
+			return
+		}
+		replayed <- seen
+	}()
 	return rpcSub, nil
 }
 
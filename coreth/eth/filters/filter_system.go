@@ -48,13 +48,17 @@ import (
 
 // Config represents the configuration of the filter system.
 type Config struct {
-	Timeout time.Duration // how long filters stay active (default: 5min)
+	Timeout         time.Duration // how long filters stay active (default: 5min)
+	LogsReplayLimit uint64        // maximum number of blocks a logs subscription may replay (default: 10000)
 }
 
 func (cfg Config) withDefaults() Config {
 	if cfg.Timeout == 0 {
 		cfg.Timeout = 5 * time.Minute
 	}
+	if cfg.LogsReplayLimit == 0 {
+		cfg.LogsReplayLimit = 10_000
+	}
 	return cfg
 }
 
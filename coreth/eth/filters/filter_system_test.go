@@ -28,6 +28,7 @@ package filters
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"math/big"
@@ -47,6 +48,8 @@ import (
 	"github.com/ava-labs/coreth/params"
 	"github.com/ava-labs/coreth/rpc"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/stretchr/testify/require"
@@ -393,6 +396,104 @@ func TestPendingTxFilterFullTx(t *testing.T) {
 	}
 }
 
+// TestPendingTxSubscriptionCriteria tests that a pending transaction
+// subscription only delivers the transactions matching its criteria.
+func TestPendingTxSubscriptionCriteria(t *testing.T) {
+	t.Parallel()
+
+	var (
+		require      = require.New(t)
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(t, db, Config{})
+		api          = NewFilterAPI(sys)
+
+		config    = backend.ChainConfig()
+		signer    = types.LatestSigner(config)
+		baseFee   = big.NewInt(100)
+		submitter = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		other     = common.HexToAddress("0x1000000000000000000000000000000000000002")
+		selector  = hexutil.Bytes{0xe1, 0xb1, 0x57, 0xe7}
+	)
+	head := &types.Header{Number: big.NewInt(0), BaseFee: baseFee}
+	rawdb.WriteHeader(db, head)
+	rawdb.WriteCanonicalHash(db, head.Hash(), 0)
+	rawdb.WriteHeadBlockHash(db, head.Hash())
+
+	key, err := crypto.GenerateKey()
+	require.NoError(err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(err)
+	newTx := func(key *ecdsa.PrivateKey, nonce uint64, to common.Address, data []byte, tip, feeCap int64) *types.Transaction {
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     nonce,
+			To:        &to,
+			Gas:       params.TxGas,
+			GasTipCap: big.NewInt(tip),
+			GasFeeCap: big.NewInt(feeCap),
+			Data:      data,
+		})
+	}
+	call := append(common.CopyBytes(selector), 0x01)
+	var (
+		matching = []*types.Transaction{
+			newTx(key, 0, submitter, call, 10, 200),
+			newTx(key, 1, submitter, selector, 5, 105),
+		}
+		transactions = []*types.Transaction{
+			matching[0],
+			newTx(key, 2, other, call, 10, 200), // wrong recipient
+			newTx(key, 3, submitter, []byte{0x01, 0x02, 0x03}, 10, 200), // no selector
+			newTx(key, 4, submitter, []byte{0, 0, 0, 0}, 10, 200),       // wrong selector
+			newTx(key, 5, submitter, call, 1, 200),                      // tip too low
+			newTx(key, 6, submitter, call, 10, 104),                     // effective tip too low
+			newTx(otherKey, 0, submitter, call, 10, 200),                // wrong sender
+			matching[1],
+		}
+	)
+
+	server := rpc.NewServer(0)
+	defer server.Stop()
+	require.NoError(server.RegisterName("eth", api))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	// Invalid criteria are rejected at subscription time.
+	_, err = client.EthSubscribe(context.Background(), make(chan common.Hash), "newPendingTransactions", false, PendingTxCriteria{
+		Selectors: []hexutil.Bytes{{0x01, 0x02}},
+	})
+	require.ErrorContains(err, "invalid selector")
+
+	hashes := make(chan common.Hash, len(transactions))
+	sub, err := client.EthSubscribe(context.Background(), hashes, "newPendingTransactions", false, PendingTxCriteria{
+		From:      []common.Address{crypto.PubkeyToAddress(key.PublicKey)},
+		To:        []common.Address{submitter},
+		Selectors: []hexutil.Bytes{selector},
+		MinTip:    (*hexutil.Big)(big.NewInt(5)),
+	})
+	require.NoError(err)
+	defer sub.Unsubscribe()
+
+	time.Sleep(1 * time.Second)
+	backend.txFeed.Send(core.NewTxsEvent{Txs: transactions})
+
+	for _, tx := range matching {
+		select {
+		case hash := <-hashes:
+			require.Equal(tx.Hash(), hash)
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for transaction %x", tx.Hash())
+		}
+	}
+	select {
+	case hash := <-hashes:
+		t.Fatalf("unexpected transaction %x", hash)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 // TestLogFilterCreation test whether a given filter criteria makes sense.
 // If not it must return an error.
 func TestLogFilterCreation(t *testing.T) {
@@ -0,0 +1,122 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package filters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	errInvalidLogsReplay  = errors.New("exactly one of fromBlock and cursor must be set")
+	errUnknownLogCursor   = errors.New("log cursor is not in an accepted block")
+	errLogsReplayTooLarge = errors.New("logs replay exceeds the maximum number of blocks")
+)
+
+// LogsReplay requests a logs subscription to first replay the accepted logs
+// matching its criteria, either from a block or right after the last log a
+// client received, before delivering live logs.
+type LogsReplay struct {
+	FromBlock *hexutil.Uint64 `json:"fromBlock"`
+	Cursor    *LogCursor      `json:"cursor"`
+}
+
+// LogCursor identifies a log delivered by a logs subscription.
+type LogCursor struct {
+	BlockHash common.Hash  `json:"blockHash"`
+	LogIndex  hexutil.Uint `json:"logIndex"`
+}
+
+// logKey uniquely identifies a log.
+type logKey struct {
+	blockHash common.Hash
+	index     uint
+}
+
+// logReplayStart returns the first block to replay logs from, given the last
+// accepted block number [lastAccepted]. Replays of more blocks than the
+// configured limit are rejected.
+func (api *FilterAPI) logReplayStart(ctx context.Context, replay *LogsReplay, lastAccepted uint64) (uint64, error) {
+	var start uint64
+	switch {
+	case (replay.FromBlock == nil) == (replay.Cursor == nil):
+		return 0, errInvalidLogsReplay
+
+	case replay.FromBlock != nil:
+		start = uint64(*replay.FromBlock)
+		if start > lastAccepted {
+			return 0, fmt.Errorf("requested from block %d after last accepted block %d", start, lastAccepted)
+		}
+
+	default:
+		header, err := api.sys.backend.HeaderByHash(ctx, replay.Cursor.BlockHash)
+		if err != nil {
+			return 0, err
+		}
+		if header == nil {
+			return 0, errUnknownLogCursor
+		}
+		start = header.Number.Uint64()
+		if start > lastAccepted || rawdb.ReadCanonicalHash(api.sys.backend.ChainDb(), start) != header.Hash() {
+			return 0, errUnknownLogCursor
+		}
+	}
+	if limit := api.sys.cfg.LogsReplayLimit; lastAccepted-start >= limit {
+		return 0, fmt.Errorf("%w: replaying from block %d to %d, maximum is %d blocks", errLogsReplayTooLarge, start, lastAccepted, limit)
+	}
+	return start, nil
+}
+
+// replayLogs notifies the accepted logs matching [crit] from block [start] up
+// to the last accepted block, skipping the logs up to and including [cursor].
+// The logs are read from the bloom index in batches of at most the maximum
+// number of blocks per request, and the replay stops at the first log failing
+// to be notified.
+//
+// It returns the keys of the replayed logs in blocks after [tip], as these
+// blocks may have been accepted after the live subscription started and their
+// logs delivered again.
+func (api *FilterAPI) replayLogs(
+	ctx context.Context,
+	notifier *rpc.Notifier,
+	id rpc.ID,
+	crit FilterCriteria,
+	start uint64,
+	cursor *LogCursor,
+	tip uint64,
+) (map[logKey]struct{}, error) {
+	var (
+		head  = api.sys.backend.LastAcceptedBlock().NumberU64()
+		batch = head - start + 1
+		seen  = make(map[logKey]struct{})
+	)
+	if maxBlocks := api.sys.backend.GetMaxBlocksPerRequest(); maxBlocks > 0 {
+		batch = uint64(maxBlocks)
+	}
+	for begin := start; begin <= head; begin += batch {
+		end := min(begin+batch-1, head)
+		logs, err := api.sys.NewRangeFilter(int64(begin), int64(end), crit.Addresses, crit.Topics).Logs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range logs {
+			if cursor != nil && log.BlockHash == cursor.BlockHash && log.Index <= uint(cursor.LogIndex) {
+				continue
+			}
+			if log.BlockNumber > tip {
+				seen[logKey{log.BlockHash, log.Index}] = struct{}{}
+			}
+			if err := notifier.Notify(id, &log); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return seen, nil
+}
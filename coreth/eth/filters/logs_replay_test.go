@@ -0,0 +1,171 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package filters
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// replayTestBackend accepts [onReplay] when the replay reads the last accepted
+// block, emulating blocks accepted between subscribing and replaying. Reading
+// logs fails with [logsErr], if set.
+type replayTestBackend struct {
+	*testBackend
+	calls    int
+	onReplay func() *types.Block
+	logsErr  error
+}
+
+func (b *replayTestBackend) GetLogs(ctx context.Context, hash common.Hash, number uint64) ([][]*types.Log, error) {
+	if b.logsErr != nil {
+		return nil, b.logsErr
+	}
+	return b.testBackend.GetLogs(ctx, hash, number)
+}
+
+func (b *replayTestBackend) LastAcceptedBlock() *types.Block {
+	b.calls++
+	if b.calls == 2 && b.onReplay != nil {
+		return b.onReplay()
+	}
+	return b.testBackend.LastAcceptedBlock()
+}
+
+func TestLogsReplay(t *testing.T) {
+	var (
+		require = require.New(t)
+		db      = rawdb.NewMemoryDatabase()
+		addr    = common.HexToAddress("0x1000000000000000000000000000000000000001")
+		gspec   = &core.Genesis{
+			BaseFee: big.NewInt(1),
+			Config:  params.TestFlareChainConfig,
+		}
+	)
+	// Every block has two logs of [addr].
+	_, chain, receipts, err := core.GenerateChainWithGenesis(gspec, dummy.NewFaker(), 8, 10, func(i int, gen *core.BlockGen) {
+		for j := 0; j < 2; j++ {
+			gen.AddUncheckedReceipt(makeReceipt(addr))
+			gen.AddUncheckedTx(types.NewTransaction(uint64(j), common.HexToAddress("0x999"), big.NewInt(999), 999, gen.BaseFee(), nil))
+		}
+	})
+	require.NoError(err)
+	accept := func(block *types.Block) []*types.Log {
+		i := block.NumberU64() - 1
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+		var logs []*types.Log
+		for _, receipt := range rawdb.ReadReceipts(db, block.Hash(), block.NumberU64(), block.Time(), gspec.Config) {
+			logs = append(logs, receipt.Logs...)
+		}
+		return logs
+	}
+	var expected []*types.Log
+	for _, block := range chain[:5] {
+		expected = append(expected, accept(block)...)
+	}
+
+	backend := &replayTestBackend{testBackend: &testBackend{db: db}}
+	api := NewFilterAPI(NewFilterSystem(backend, Config{LogsReplayLimit: 4}))
+	server := rpc.NewServer(0)
+	defer server.Stop()
+	require.NoError(server.RegisterName("eth", api))
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	crit := FilterCriteria{Addresses: []common.Address{addr}}
+	subscribe := func(replay LogsReplay) (chan types.Log, *rpc.ClientSubscription, error) {
+		logs := make(chan types.Log, 20)
+		sub, err := client.EthSubscribe(context.Background(), logs, "logs", crit, replay)
+		return logs, sub, err
+	}
+	checkLogs := func(logs chan types.Log, sub *rpc.ClientSubscription, expected []*types.Log) {
+		for _, want := range expected {
+			select {
+			case log := <-logs:
+				require.Equal(want.BlockHash, log.BlockHash)
+				require.Equal(want.Index, log.Index)
+			case err := <-sub.Err():
+				t.Fatalf("subscription failed: %v", err)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timeout waiting for log %d of block %d", want.Index, want.BlockNumber)
+			}
+		}
+		select {
+		case log := <-logs:
+			t.Fatalf("unexpected log %d of block %d", log.Index, log.BlockNumber)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	// Invalid replays are rejected.
+	_, _, err = subscribe(LogsReplay{})
+	require.ErrorContains(err, errInvalidLogsReplay.Error())
+	_, _, err = subscribe(LogsReplay{Cursor: &LogCursor{BlockHash: common.HexToHash("0x01")}})
+	require.ErrorContains(err, errUnknownLogCursor.Error())
+	from := hexutil.Uint64(6)
+	_, _, err = subscribe(LogsReplay{FromBlock: &from})
+	require.ErrorContains(err, "after last accepted block")
+	from = 1
+	_, _, err = subscribe(LogsReplay{FromBlock: &from})
+	require.ErrorContains(err, errLogsReplayTooLarge.Error())
+
+	// Replay from a block.
+	from = 4
+	logs, sub, err := subscribe(LogsReplay{FromBlock: &from})
+	require.NoError(err)
+	checkLogs(logs, sub, expected[6:])
+	sub.Unsubscribe()
+
+	// Resume after the first log of block 2, while block 6 is accepted after
+	// subscribing and its logs are delivered by both the replay and the
+	// subscription.
+	var (
+		live     []*types.Log
+		replayed = make(chan struct{})
+	)
+	backend.calls = 0
+	backend.onReplay = func() *types.Block {
+		live = accept(chain[5])
+		backend.logsFeed.Send(live)
+		close(replayed)
+		return chain[5]
+	}
+	cursor := &LogCursor{BlockHash: expected[2].BlockHash, LogIndex: hexutil.Uint(expected[2].Index)}
+	logs, sub, err = subscribe(LogsReplay{Cursor: cursor})
+	require.NoError(err)
+	defer sub.Unsubscribe()
+	<-replayed
+	checkLogs(logs, sub, append(expected[3:], live...))
+
+	// Logs of later blocks are delivered live.
+	live = accept(chain[6])
+	backend.logsFeed.Send(live)
+	checkLogs(logs, sub, live)
+
+	// A failed replay ends the subscription, so later logs are not delivered.
+	backend.calls = 0
+	backend.onReplay = nil
+	backend.logsErr = errors.New("test")
+	logs, sub, err = subscribe(LogsReplay{FromBlock: &from})
+	require.NoError(err)
+	defer sub.Unsubscribe()
+	backend.logsFeed.Send(accept(chain[7]))
+	checkLogs(logs, sub, nil)
+}
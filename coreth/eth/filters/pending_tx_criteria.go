@@ -0,0 +1,118 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package filters
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// selectorLength is the length of the function selector that prefixes
+	// the data of a contract call.
+	selectorLength = 4
+
+	// maxPendingTxCriteria is the maximum number of addresses or selectors
+	// a pending transaction subscription can be restricted to.
+	maxPendingTxCriteria = 1000
+)
+
+// PendingTxCriteria restricts a pending transaction subscription to the
+// transactions matching all of its non-empty fields.
+type PendingTxCriteria struct {
+	From      []common.Address `json:"from"`      // the sender is any of From
+	To        []common.Address `json:"to"`        // the recipient is any of To
+	Selectors []hexutil.Bytes  `json:"selectors"` // the data starts with any of the 4-byte Selectors
+	MinTip    *hexutil.Big     `json:"minTip"`    // the effective tip at the latest base fee is at least MinTip
+}
+
+// pendingTxMatcher matches pending transactions against a [PendingTxCriteria].
+type pendingTxMatcher struct {
+	signer    types.Signer
+	from      map[common.Address]struct{}
+	to        map[common.Address]struct{}
+	selectors map[[selectorLength]byte]struct{}
+	minTip    *big.Int
+}
+
+func newPendingTxMatcher(crit *PendingTxCriteria, config *params.ChainConfig) (*pendingTxMatcher, error) {
+	if len(crit.From) > maxPendingTxCriteria || len(crit.To) > maxPendingTxCriteria || len(crit.Selectors) > maxPendingTxCriteria {
+		return nil, fmt.Errorf("exceed max criteria of %d addresses or selectors", maxPendingTxCriteria)
+	}
+	m := &pendingTxMatcher{
+		signer: types.LatestSigner(config),
+	}
+	if len(crit.From) > 0 {
+		m.from = make(map[common.Address]struct{}, len(crit.From))
+		for _, addr := range crit.From {
+			m.from[addr] = struct{}{}
+		}
+	}
+	if len(crit.To) > 0 {
+		m.to = make(map[common.Address]struct{}, len(crit.To))
+		for _, addr := range crit.To {
+			m.to[addr] = struct{}{}
+		}
+	}
+	if len(crit.Selectors) > 0 {
+		m.selectors = make(map[[selectorLength]byte]struct{}, len(crit.Selectors))
+		for _, selector := range crit.Selectors {
+			if len(selector) != selectorLength {
+				return nil, fmt.Errorf("invalid selector %s, expected %d bytes", selector, selectorLength)
+			}
+			m.selectors[[selectorLength]byte(selector)] = struct{}{}
+		}
+	}
+	if crit.MinTip != nil {
+		if crit.MinTip.ToInt().Sign() < 0 {
+			return nil, fmt.Errorf("invalid negative min tip %s", crit.MinTip)
+		}
+		m.minTip = crit.MinTip.ToInt()
+	}
+	return m, nil
+}
+
+// match returns whether [tx] matches the criteria, given the latest [baseFee].
+// The sender is only recovered if the other criteria match.
+func (m *pendingTxMatcher) match(tx *types.Transaction, baseFee *big.Int) bool {
+	if m.to != nil {
+		to := tx.To()
+		if to == nil {
+			return false
+		}
+		if _, ok := m.to[*to]; !ok {
+			return false
+		}
+	}
+	if m.selectors != nil {
+		data := tx.Data()
+		if len(data) < selectorLength {
+			return false
+		}
+		if _, ok := m.selectors[[selectorLength]byte(data)]; !ok {
+			return false
+		}
+	}
+	if m.minTip != nil {
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil || tip.Cmp(m.minTip) < 0 {
+			return false
+		}
+	}
+	if m.from != nil {
+		from, err := types.Sender(m.signer, tx)
+		if err != nil {
+			return false
+		}
+		if _, ok := m.from[from]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -49,13 +49,25 @@ import (
 var noopReleaser = tracers.StateReleaseFunc(func() {})
 
 func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec uint64, base *state.StateDB, readOnly bool, preferDisk bool) (statedb *state.StateDB, release tracers.StateReleaseFunc, err error) {
-	reexec = 0 // Do not support re-executing historical blocks to grab state
+	// Historical blocks are only re-executed to grab state if enabled, with
+	// the read-only states regenerated that way kept in a bounded cache.
+	var regenerated *regeneratedStates
+	if eth.historicalStateRegeneration() {
+		reexec = min(reexec, eth.config.HistoricalStateReexec)
+		if readOnly {
+			regenerated = eth.regeneratedStates
+		}
+	} else {
+		reexec = 0
+	}
 	var (
 		current  *types.Block
 		database state.Database
 		tdb      *triedb.Database
+		rdb      *regeneratedDB // database of tdb cached in regenerated, if any
 		report   = true
 		origin   = block.NumberU64()
+		baseRoot common.Hash // root of the cached state regeneration started from, if any
 	)
 	// The state is only for reading purposes, check the state presence in
 	// live database.
@@ -69,6 +81,14 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 				eth.blockchain.TrieDB().Dereference(block.Root())
 			}, nil
 		}
+		if regenerated != nil {
+			if cached, ok := regenerated.get(block.Root()); ok {
+				if statedb, err = state.New(block.Root(), state.NewDatabaseWithNodeDB(eth.chainDb, cached.tdb), nil); err == nil {
+					return statedb, func() { cached.tdb.Dereference(block.Root()) }, nil
+				}
+				cached.tdb.Dereference(block.Root())
+			}
+		}
 	}
 	// The state is both for reading and writing, or it's unavailable in disk,
 	// try to construct/recover the state over an ephemeral trie.Database for
@@ -98,6 +118,9 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 		// please re-enable it for better performance.
 		tdb = triedb.NewDatabase(eth.chainDb, triedb.HashDefaults)
 		database = state.NewDatabaseWithNodeDB(eth.chainDb, tdb)
+		if regenerated != nil {
+			rdb = &regeneratedDB{tdb: tdb}
+		}
 
 		// If we didn't check the live database, do check state over ephemeral database,
 		// otherwise we would rewind past a persisted block (specific corner case is
@@ -122,6 +145,18 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 			}
 			current = parent
 
+			// Continue from a previously regenerated state if there is one,
+			// in the database shared with the cached state.
+			if regenerated != nil {
+				if cached, ok := regenerated.get(current.Root()); ok {
+					cachedDatabase := state.NewDatabaseWithNodeDB(eth.chainDb, cached.tdb)
+					if statedb, err = state.New(current.Root(), cachedDatabase, nil); err == nil {
+						rdb, tdb, database, baseRoot = cached, cached.tdb, cachedDatabase, current.Root()
+						break
+					}
+					cached.tdb.Dereference(current.Root())
+				}
+			}
 			statedb, err = state.New(current.Root(), database, nil)
 			if err == nil {
 				break
@@ -143,6 +178,19 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 		logged time.Time
 		parent common.Hash
 	)
+	// Drop the references held if the regeneration fails, as the database may
+	// be shared with cached states.
+	defer func() {
+		if err == nil {
+			return
+		}
+		if parent != (common.Hash{}) {
+			tdb.Dereference(parent)
+		}
+		if baseRoot != (common.Hash{}) {
+			tdb.Dereference(baseRoot)
+		}
+	}()
 	for current.NumberU64() < origin {
 		if err := ctx.Err(); err != nil {
 			return nil, nil, err
@@ -184,6 +232,12 @@ func (eth *Ethereum) hashState(ctx context.Context, block *types.Block, reexec u
 		_, nodes, imgs := tdb.Size() // all memory is contained within the nodes return in hashdb
 		log.Info("Historical state regenerated", "block", current.NumberU64(), "elapsed", time.Since(start), "nodes", nodes, "preimages", imgs)
 	}
+	if regenerated != nil {
+		regenerated.add(block.Root(), rdb)
+	}
+	if baseRoot != (common.Hash{}) {
+		tdb.Dereference(baseRoot)
+	}
 	return statedb, func() { tdb.Dereference(block.Root()) }, nil
 }
 
@@ -0,0 +1,96 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"math"
+	"sync"
+
+	"github.com/ava-labs/coreth/triedb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+)
+
+// regeneratedStates is a cache of the historical states regenerated by
+// re-executing blocks on top of the nearest committed state. Each entry holds a
+// reference to its root in the ephemeral trie database it was built in, which
+// is dropped once it is evicted.
+//
+// Regenerating a state on top of a cached one continues in the database of the
+// cached state, so a database may hold several cached states. The cache is
+// bounded by the memory of the databases, each accounted for once, evicting
+// the least recently used states.
+type regeneratedStates struct {
+	lock    sync.Mutex
+	maxSize common.StorageSize
+	size    common.StorageSize // memory of the databases of the cached states
+	cache   lru.BasicLRU[common.Hash, *regeneratedDB]
+}
+
+// regeneratedDB is an ephemeral trie database holding regenerated states.
+type regeneratedDB struct {
+	tdb    *triedb.Database
+	states int                // number of cached states held by tdb
+	size   common.StorageSize // memory of tdb accounted in the cache
+}
+
+// historicalStateRegeneration returns true if states pruned from a non-archive
+// node are regenerated by re-executing blocks.
+func (eth *Ethereum) historicalStateRegeneration() bool {
+	return eth.config.Pruning && eth.config.HistoricalStateRegeneration
+}
+
+func newRegeneratedStates(maxSize common.StorageSize) *regeneratedStates {
+	return &regeneratedStates{
+		maxSize: maxSize,
+		cache:   lru.NewBasicLRU[common.Hash, *regeneratedDB](math.MaxInt),
+	}
+}
+
+// get returns the database holding the state [root], if it is cached.
+// The caller must dereference [root] once it is done with the state.
+func (s *regeneratedStates) get(root common.Hash) (*regeneratedDB, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	db, ok := s.cache.Get(root)
+	if ok {
+		db.tdb.Reference(root, common.Hash{})
+	}
+	return db, ok
+}
+
+// add caches the state [root] held by [db], evicting the least recently used
+// states while the cache exceeds its size. The state just added is kept even
+// if its database alone exceeds the size.
+func (s *regeneratedStates) add(root common.Hash, db *regeneratedDB) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.cache.Contains(root) {
+		return
+	}
+	db.tdb.Reference(root, common.Hash{})
+	db.states++
+	s.cache.Add(root, db)
+	s.resize(db)
+
+	for s.size > s.maxSize && s.cache.Len() > 1 {
+		oldRoot, oldDB, _ := s.cache.RemoveOldest()
+		oldDB.tdb.Dereference(oldRoot)
+		oldDB.states--
+		s.resize(oldDB)
+	}
+}
+
+// resize updates the memory of [db] accounted in the cache.
+// Assumes [s.lock] is held.
+func (s *regeneratedStates) resize(db *regeneratedDB) {
+	var size common.StorageSize
+	if db.states > 0 {
+		_, size, _ = db.tdb.Size()
+	}
+	s.size += size - db.size
+	db.size = size
+}
@@ -0,0 +1,168 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/eth/ethconfig"
+	"github.com/ava-labs/coreth/node"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm/upgrade/ap3"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var regenerationRecipient = common.HexToAddress("0x0dad")
+
+// newRegenerationBackend returns a pruning backend committing its state every
+// 16 blocks, with 64 accepted blocks each transferring 1 wei to
+// regenerationRecipient.
+func newRegenerationBackend(t *testing.T, regeneration bool, reexec uint64) *Ethereum {
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	gspec := &core.Genesis{
+		Config:  params.TestFlareChainConfig,
+		Alloc:   types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		BaseFee: big.NewInt(ap3.InitialBaseFee),
+	}
+	stack, err := node.New(&node.Config{})
+	require.NoError(t, err)
+
+	config := ethconfig.NewDefaultConfig()
+	config.Genesis = gspec
+	config.Pruning = true
+	config.CommitInterval = 16
+	config.HistoricalStateRegeneration = regeneration
+	config.HistoricalStateReexec = reexec
+	config.HistoricalStateCache = 1
+	engine := dummy.NewETHFaker()
+	backend, err := New(stack, &config, nil, rawdb.NewMemoryDatabase(), Settings{}, common.Hash{}, engine, &mockable.Clock{})
+	require.NoError(t, err)
+	backend.Start()
+	t.Cleanup(func() { require.NoError(t, backend.Stop()) })
+
+	signer := types.LatestSigner(gspec.Config)
+	_, blocks, _, err := core.GenerateChainWithGenesis(gspec, engine, 64, 10, func(i int, b *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(uint64(i), regenerationRecipient, common.Big1, params.TxGas, b.BaseFee(), nil), signer, key)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	})
+	require.NoError(t, err)
+
+	chain := backend.BlockChain()
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		require.NoError(t, chain.Accept(block))
+	}
+	chain.DrainAcceptorQueue()
+	return backend
+}
+
+// requireRecipientBalance checks the balance of regenerationRecipient at
+// block [number], which is [number] wei.
+func requireRecipientBalance(t *testing.T, backend *Ethereum, number int64) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	statedb, header, err := backend.APIBackend.StateAndHeaderByNumber(ctx, rpc.BlockNumber(number))
+	require.NoError(t, err)
+	require.Equal(t, uint64(number), header.Number.Uint64())
+	require.Equal(t, uint64(number), statedb.GetBalance(regenerationRecipient).Uint64())
+}
+
+func TestHistoricalStateRegeneration(t *testing.T) {
+	backend := newRegenerationBackend(t, true, 16)
+	require.True(t, backend.APIBackend.HistoricalStateRegeneration())
+	require.Equal(t, uint64(16), backend.APIBackend.HistoricalStateReexec())
+	regenerated := backend.regeneratedStates
+	root := func(number uint64) common.Hash {
+		return backend.BlockChain().GetBlockByNumber(number).Root()
+	}
+	// requireSize checks the size of the cache is the memory of the distinct
+	// databases of the cached states.
+	requireSize := func() {
+		dbs := make(map[*regeneratedDB]struct{})
+		for _, root := range regenerated.cache.Keys() {
+			db, _ := regenerated.cache.Peek(root)
+			dbs[db] = struct{}{}
+		}
+		var size common.StorageSize
+		for db := range dbs {
+			_, nodes, _ := db.tdb.Size()
+			size += nodes
+		}
+		require.Equal(t, size, regenerated.size)
+	}
+
+	// Block 5 is regenerated from the genesis state and cached.
+	_, err := backend.BlockChain().StateAt(root(5))
+	require.Error(t, err)
+	requireRecipientBalance(t, backend, 5)
+	require.Equal(t, 1, regenerated.cache.Len())
+	requireSize()
+
+	// Block 6 is regenerated on top of the cached state of block 5, in the
+	// database of block 5, which is accounted for once.
+	requireRecipientBalance(t, backend, 6)
+	db5, _ := regenerated.cache.Peek(root(5))
+	db6, _ := regenerated.cache.Peek(root(6))
+	require.Same(t, db5, db6)
+	require.Equal(t, 2, db5.states)
+	requireSize()
+
+	// Block 20 is regenerated from the state committed at block 16, and the
+	// least recently used states are evicted once the cache is full.
+	regenerated.maxSize = regenerated.size
+	requireRecipientBalance(t, backend, 20)
+	require.True(t, regenerated.cache.Contains(root(20)))
+	require.False(t, regenerated.cache.Contains(root(5)))
+	require.LessOrEqual(t, regenerated.size, regenerated.maxSize)
+	requireSize()
+
+	// States more recent than the tip buffer are still available.
+	requireRecipientBalance(t, backend, 64)
+}
+
+func TestHistoricalStateRegenerationBackgroundContext(t *testing.T) {
+	backend := newRegenerationBackend(t, true, 16)
+	regenerated := backend.regeneratedStates
+
+	// The state of block 5 is held by the caller past its eviction from the
+	// cache, as its context is never done.
+	statedb, _, err := backend.APIBackend.StateAndHeaderByNumber(context.Background(), 5)
+	require.NoError(t, err)
+	regenerated.maxSize = 0
+	requireRecipientBalance(t, backend, 20)
+	require.False(t, regenerated.cache.Contains(backend.BlockChain().GetBlockByNumber(5).Root()))
+	require.Equal(t, uint64(5), statedb.GetBalance(regenerationRecipient).Uint64())
+	require.NoError(t, statedb.Error())
+}
+
+func TestHistoricalStateRegenerationReexecLimit(t *testing.T) {
+	backend := newRegenerationBackend(t, true, 4)
+	requireRecipientBalance(t, backend, 20)
+
+	// Block 27 is 11 blocks after the last committed state.
+	_, _, err := backend.APIBackend.StateAndHeaderByNumber(context.Background(), 27)
+	require.ErrorContains(t, err, "required historical state unavailable (reexec=4)")
+}
+
+func TestHistoricalStateRegenerationDisabled(t *testing.T) {
+	backend := newRegenerationBackend(t, false, 16)
+	require.False(t, backend.APIBackend.HistoricalStateRegeneration())
+	require.Zero(t, backend.APIBackend.HistoricalStateReexec())
+	_, _, err := backend.APIBackend.StateAndHeaderByNumber(context.Background(), 5)
+	require.Error(t, err)
+	require.Nil(t, backend.regeneratedStates)
+}
@@ -98,6 +98,9 @@ type Backend interface {
 	StateAtBlock(ctx context.Context, block *types.Block, reexec uint64, base *state.StateDB, readOnly bool, preferDisk bool) (*state.StateDB, StateReleaseFunc, error)
 	StateAtNextBlock(ctx context.Context, parent, block *types.Block, reexec uint64, base *state.StateDB, readOnly bool, preferDisk bool) (*state.StateDB, StateReleaseFunc, error)
 	StateAtTransaction(ctx context.Context, block *types.Block, txIndex int, reexec uint64) (*core.Message, vm.BlockContext, *state.StateDB, StateReleaseFunc, error)
+	// HistoricalStateReexec returns the number of blocks re-executed to
+	// regenerate a historical state, or 0 if states are not regenerated.
+	HistoricalStateReexec() uint64
 }
 
 // baseAPI holds the collection of common methods for API and FileTracerAPI.
@@ -127,6 +130,16 @@ func NewFileTracerAPI(backend Backend) *FileTracerAPI {
 	return &FileTracerAPI{baseAPI{backend: backend}}
 }
 
+// defaultReexec returns the number of blocks to reexecute by default to
+// produce missing historical state, which is the regeneration limit of the
+// backend if historical state regeneration is enabled.
+func (api *baseAPI) defaultReexec() uint64 {
+	if reexec := api.backend.HistoricalStateReexec(); reexec > 0 {
+		return reexec
+	}
+	return defaultTraceReexec
+}
+
 // chainContext constructs the context reader which is used by the evm for reading
 // the necessary chain context.
 func (api *baseAPI) chainContext(ctx context.Context) core.ChainContext {
@@ -273,7 +286,7 @@ func (api *API) TraceChain(ctx context.Context, start, end rpc.BlockNumber, conf
 // transaction, dependent on the requested tracer.
 // The tracing procedure should be aborted in case the closed signal is received.
 func (api *API) traceChain(start, end *types.Block, config *TraceConfig, closed <-chan interface{}) chan *blockTraceResult {
-	reexec := defaultTraceReexec
+	reexec := api.defaultReexec()
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
@@ -547,7 +560,7 @@ func (api *API) IntermediateRoots(ctx context.Context, hash common.Hash, config
 	if err != nil {
 		return nil, err
 	}
-	reexec := defaultTraceReexec
+	reexec := api.defaultReexec()
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
@@ -624,7 +637,7 @@ func (api *baseAPI) traceBlock(ctx context.Context, block *types.Block, config *
 	if err != nil {
 		return nil, err
 	}
-	reexec := defaultTraceReexec
+	reexec := api.defaultReexec()
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
@@ -766,7 +779,7 @@ func (api *FileTracerAPI) standardTraceBlockToFile(ctx context.Context, block *t
 	if err != nil {
 		return nil, err
 	}
-	reexec := defaultTraceReexec
+	reexec := api.defaultReexec()
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
@@ -886,7 +899,7 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 	if blockNumber == 0 {
 		return nil, errors.New("genesis is not traceable")
 	}
-	reexec := defaultTraceReexec
+	reexec := api.defaultReexec()
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
@@ -943,7 +956,7 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 		return nil, err
 	}
 	// try to recompute the state
-	reexec := defaultTraceReexec
+	reexec := api.defaultReexec()
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
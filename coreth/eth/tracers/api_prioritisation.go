@@ -0,0 +1,92 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package tracers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/vm"
+	"github.com/ava-labs/coreth/internal/ethapi"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// PrioritisationExplanation describes whether a transaction was charged the
+// nominal prioritised fee and which rule decided it.
+type PrioritisationExplanation struct {
+	TxHash         common.Hash                       `json:"txHash"`
+	BlockNumber    hexutil.Uint64                    `json:"blockNumber"`
+	BlockTimestamp hexutil.Uint64                    `json:"blockTimestamp"`
+	GasLimit       hexutil.Uint64                    `json:"gasLimit"`
+	Prioritised    bool                              `json:"prioritised"`
+	Reason         core.PrioritisationReason         `json:"reason"`
+	ReturnData     hexutil.Bytes                     `json:"returnData"`
+	Error          string                            `json:"error,omitempty"`
+	Config         *params.PrioritisedContractConfig `json:"config"`
+}
+
+// ExplainPrioritisation re-executes the transaction with the given hash and
+// reports whether it was treated as a prioritised contract call, and why.
+func (api *API) ExplainPrioritisation(ctx context.Context, hash common.Hash, reexec *uint64) (*PrioritisationExplanation, error) {
+	found, _, blockHash, blockNumber, index, err := api.backend.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, ethapi.NewTxIndexingError()
+	}
+	// Only mined txes are supported
+	if !found {
+		return nil, errTxNotFound
+	}
+	// It shouldn't happen in practice.
+	if blockNumber == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	if reexec == nil {
+		reexec = new(uint64)
+		*reexec = api.defaultReexec()
+	}
+	block, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(blockNumber), blockHash)
+	if err != nil {
+		return nil, err
+	}
+	msg, vmctx, statedb, release, err := api.backend.StateAtTransaction(ctx, block, int(index), *reexec)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var (
+		chainConfig = api.backend.ChainConfig()
+		txContext   = core.NewEVMTxContext(msg)
+		vmenv       = vm.NewEVM(vmctx, txContext, statedb, chainConfig, vm.Config{})
+	)
+	statedb.SetTxContext(hash, int(index))
+	result, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.GasLimit))
+	if err != nil {
+		return nil, fmt.Errorf("re-executing transaction failed: %w", err)
+	}
+
+	explanation := &PrioritisationExplanation{
+		TxHash:         hash,
+		BlockNumber:    hexutil.Uint64(blockNumber),
+		BlockTimestamp: hexutil.Uint64(block.Time()),
+		GasLimit:       hexutil.Uint64(msg.GasLimit),
+		ReturnData:     result.ReturnData,
+		Config:         chainConfig.GetPrioritisedContractConfig(block.Time()),
+	}
+	if result.Err != nil {
+		// Failed calls are always charged in full.
+		explanation.Reason = core.PrioritisationExecutionFailed
+		explanation.Error = result.Err.Error()
+		return explanation, nil
+	}
+	explanation.Prioritised, explanation.Reason = core.ExplainPrioritisedContractCall(
+		chainConfig, block.Time(), msg.To, msg.Data, result.ReturnData, msg.GasLimit,
+	)
+	return explanation, nil
+}
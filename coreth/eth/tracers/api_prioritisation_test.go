@@ -0,0 +1,78 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package tracers
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestExplainPrioritisation(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestFlareChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	var (
+		ftso     = common.HexToAddress("0x1000000000000000000000000000000000000003")
+		transfer common.Hash
+		ftsoCall common.Hash
+		signer   = types.HomesteadSigner{}
+	)
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		for j, to := range []common.Address{accounts[1].addr, ftso} {
+			tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+				Nonce:    uint64(j),
+				To:       &to,
+				Value:    big.NewInt(1000),
+				Gas:      params.TxGas,
+				GasPrice: new(big.Int).Add(b.BaseFee(), big.NewInt(int64(500*params.GWei))),
+			}), signer, accounts[0].key)
+			b.AddTx(tx)
+			if j == 0 {
+				transfer = tx.Hash()
+			} else {
+				ftsoCall = tx.Hash()
+			}
+		}
+	})
+	defer backend.chain.Stop()
+	api := NewAPI(backend)
+
+	result, err := api.ExplainPrioritisation(context.Background(), transfer, nil)
+	if err != nil {
+		t.Fatalf("failed to explain transaction: %v", err)
+	}
+	if result.Prioritised || result.Reason != core.PrioritisationNotPrioritisedContract {
+		t.Errorf("unexpected explanation for transfer: prioritised %v, reason %s", result.Prioritised, result.Reason)
+	}
+
+	result, err = api.ExplainPrioritisation(context.Background(), ftsoCall, nil)
+	if err != nil {
+		t.Fatalf("failed to explain transaction: %v", err)
+	}
+	if !result.Prioritised || result.Reason != core.PrioritisationFTSOCall {
+		t.Errorf("unexpected explanation for FTSO call: prioritised %v, reason %s", result.Prioritised, result.Reason)
+	}
+	if result.Config == nil {
+		t.Errorf("expected prioritised contract config to be reported")
+	}
+
+	_, err = api.ExplainPrioritisation(context.Background(), common.Hash{42}, nil)
+	if !errors.Is(err, errTxNotFound) {
+		t.Fatalf("want %v, have %v", errTxNotFound, err)
+	}
+}
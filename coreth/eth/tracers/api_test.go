@@ -68,6 +68,8 @@ type testBackend struct {
 
 	refHook func() // Hook is invoked when the requested state is referenced
 	relHook func() // Hook is invoked when the requested state is released
+
+	historicalStateReexec uint64
 }
 
 // testBackend creates a new test backend. OBS: After test is done, teardown must be
@@ -220,6 +222,10 @@ func (b *testBackend) StateAtTransaction(ctx context.Context, block *types.Block
 	return nil, vm.BlockContext{}, nil, nil, fmt.Errorf("transaction index %d out of range for block %#x", txIndex, block.Hash())
 }
 
+func (b *testBackend) HistoricalStateReexec() uint64 {
+	return b.historicalStateReexec
+}
+
 func TestTraceCall(t *testing.T) {
 	t.Parallel()
 
@@ -1038,3 +1044,15 @@ func TestTraceChain(t *testing.T) {
 		}
 	}
 }
+
+func TestDefaultReexec(t *testing.T) {
+	backend := &testBackend{}
+	api := NewAPI(backend)
+	if reexec := api.defaultReexec(); reexec != defaultTraceReexec {
+		t.Fatalf("default reexec mismatch, want %d, got %d", defaultTraceReexec, reexec)
+	}
+	backend.historicalStateReexec = 16
+	if reexec := api.defaultReexec(); reexec != 16 {
+		t.Fatalf("regeneration reexec mismatch, want %d, got %d", 16, reexec)
+	}
+}
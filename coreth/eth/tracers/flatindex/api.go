@@ -0,0 +1,235 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package flatindex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ava-labs/coreth/internal/ethapi"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+var (
+	errNothingIndexed = errors.New("no blocks have been indexed yet")
+	errSkippedBlock   = errors.New("block was skipped as it failed to be traced")
+)
+
+// API serves the parity style trace namespace from the flat trace index.
+type API struct {
+	backend   Backend
+	db        ethdb.KeyValueStore
+	maxBlocks int64
+}
+
+// NewAPI creates a new trace API reading from [db]. If [maxBlocks] is
+// positive, it bounds the block range of filters without addresses.
+func NewAPI(backend Backend, db ethdb.KeyValueStore, maxBlocks int64) *API {
+	return &API{
+		backend:   backend,
+		db:        db,
+		maxBlocks: maxBlocks,
+	}
+}
+
+// APIs returns the collection of RPC services the flat trace index offers.
+func APIs(backend Backend, db ethdb.KeyValueStore, maxBlocks int64) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "trace",
+			Service:   NewAPI(backend, db, maxBlocks),
+			Name:      "trace",
+		},
+	}
+}
+
+// FilterArgs are the arguments of trace_filter.
+type FilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// resolve converts [number] to an indexed block number, where the latest,
+// accepted and pending tags refer to the last indexed block.
+func (api *API) resolve(number rpc.BlockNumber) (uint64, error) {
+	first, last, ok := ReadIndexedRange(api.db)
+	if !ok || last < first {
+		return 0, errNothingIndexed
+	}
+	if number < 0 {
+		return last, nil
+	}
+	n := uint64(number)
+	if n < first || n > last {
+		return 0, fmt.Errorf("block #%d is not indexed, indexed range is [%d, %d]", n, first, last)
+	}
+	if IsSkippedBlock(api.db, n) {
+		return 0, fmt.Errorf("%w: #%d", errSkippedBlock, n)
+	}
+	return n, nil
+}
+
+// Block returns the flat call traces of all transactions in the block.
+func (api *API) Block(ctx context.Context, number rpc.BlockNumber) ([]json.RawMessage, error) {
+	n, err := api.resolve(number)
+	if err != nil {
+		return nil, err
+	}
+	return nonNil(ReadBlockTraces(api.db, n)), nil
+}
+
+// Transaction returns the flat call traces of the transaction with the given
+// hash, or nil if the transaction is unknown.
+func (api *API) Transaction(ctx context.Context, hash common.Hash) ([]json.RawMessage, error) {
+	found, _, _, blockNumber, _, err := api.backend.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, ethapi.NewTxIndexingError()
+	}
+	if !found {
+		return nil, nil
+	}
+	if _, err := api.resolve(rpc.BlockNumber(blockNumber)); err != nil {
+		return nil, err
+	}
+	var traces []json.RawMessage
+	for _, enc := range ReadBlockTraces(api.db, blockNumber) {
+		var f frame
+		if err := json.Unmarshal(enc, &f); err != nil {
+			return nil, err
+		}
+		if f.TransactionHash != nil && *f.TransactionHash == hash {
+			traces = append(traces, enc)
+		}
+	}
+	return nonNil(traces), nil
+}
+
+// Filter returns the flat call traces in the given block range matching the
+// address filters. A trace matches if its sender is in [FromAddress] and its
+// recipient is in [ToAddress], where an empty list matches any address. The
+// range defaults to the last indexed block and is clamped to the indexed
+// blocks, so that filters starting from genesis cover everything indexed.
+// Blocks skipped as they failed to be traced have no traces to match.
+func (api *API) Filter(ctx context.Context, args FilterArgs) ([]json.RawMessage, error) {
+	first, last, ok := ReadIndexedRange(api.db)
+	if !ok || last < first {
+		return nil, errNothingIndexed
+	}
+	clamp := func(number *rpc.BlockNumber) uint64 {
+		switch {
+		case number == nil || *number < 0 || uint64(*number) > last:
+			return last
+		case uint64(*number) < first:
+			return first
+		default:
+			return uint64(*number)
+		}
+	}
+	from, to := clamp(args.FromBlock), clamp(args.ToBlock)
+	if from > to {
+		return nil, fmt.Errorf("fromBlock (%d) is after toBlock (%d)", from, to)
+	}
+
+	var (
+		fromSet = addressSet(args.FromAddress)
+		toSet   = addressSet(args.ToAddress)
+		numbers []uint64
+	)
+	if fromSet == nil && toSet == nil {
+		if api.maxBlocks > 0 && to-from >= uint64(api.maxBlocks) {
+			return nil, fmt.Errorf("requested too many blocks from %d to %d, maximum is set to %d", from, to, api.maxBlocks)
+		}
+		for number := from; number <= to; number++ {
+			numbers = append(numbers, number)
+		}
+	} else {
+		// Only the blocks containing one of the addresses can match. The
+		// sender filter alone suffices when both are set.
+		lookup := args.FromAddress
+		if fromSet == nil {
+			lookup = args.ToAddress
+		}
+		seen := make(map[uint64]struct{})
+		for _, addr := range lookup {
+			for _, number := range ReadAddressBlocks(api.db, addr, from, to) {
+				if _, ok := seen[number]; !ok {
+					seen[number] = struct{}{}
+					numbers = append(numbers, number)
+				}
+			}
+		}
+		sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	}
+
+	var (
+		traces  = make([]json.RawMessage, 0)
+		skipped uint64
+	)
+	for _, number := range numbers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, enc := range ReadBlockTraces(api.db, number) {
+			var f frame
+			if err := json.Unmarshal(enc, &f); err != nil {
+				return nil, err
+			}
+			if !matches(fromSet, f.from()) || !matches(toSet, f.to()) {
+				continue
+			}
+			if args.After != nil && skipped < *args.After {
+				skipped++
+				continue
+			}
+			traces = append(traces, enc)
+			if args.Count != nil && uint64(len(traces)) >= *args.Count {
+				return traces, nil
+			}
+		}
+	}
+	return traces, nil
+}
+
+// addressSet returns the set of [addresses], or nil if there are none.
+func addressSet(addresses []common.Address) map[common.Address]struct{} {
+	if len(addresses) == 0 {
+		return nil
+	}
+	set := make(map[common.Address]struct{}, len(addresses))
+	for _, addr := range addresses {
+		set[addr] = struct{}{}
+	}
+	return set
+}
+
+// matches reports whether [addr] is in [set], where a nil set matches any
+// address.
+func matches(set map[common.Address]struct{}, addr *common.Address) bool {
+	if set == nil {
+		return true
+	}
+	if addr == nil {
+		return false
+	}
+	_, ok := set[*addr]
+	return ok
+}
+
+// nonNil returns [traces], or an empty list if it is nil so that it is
+// encoded as an empty JSON array.
+func nonNil(traces []json.RawMessage) []json.RawMessage {
+	if traces == nil {
+		return []json.RawMessage{}
+	}
+	return traces
+}
@@ -0,0 +1,319 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+// Package flatindex persists the flat call traces of accepted blocks so that
+// the parity style trace namespace can be served without re-executing them.
+package flatindex
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/eth/tracers"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	// Register the flatCallTracer used to produce the traces.
+	_ "github.com/ava-labs/coreth/eth/tracers/native"
+)
+
+const (
+	// flatCallTracer is the name of the native tracer producing the traces.
+	flatCallTracer = "flatCallTracer"
+
+	// acceptedChanSize is the size of the channel listening to accepted
+	// block events.
+	acceptedChanSize = 64
+
+	// defaultRetryDelay is the delay before a block failing to be indexed is
+	// retried, doubling with every failed attempt.
+	defaultRetryDelay = time.Second
+
+	// maxIndexAttempts is the number of attempts to index a block after which
+	// it is skipped.
+	maxIndexAttempts = 5
+)
+
+var (
+	indexFailuresCounter = metrics.NewRegisteredCounter("flatindex/failures", nil)
+	indexSkippedCounter  = metrics.NewRegisteredCounter("flatindex/skipped", nil)
+)
+
+// Backend provides the indexer with the chain data it traces.
+type Backend interface {
+	tracers.Backend
+	LastAcceptedBlock() *types.Block
+	SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription
+}
+
+// frame holds the fields of a flat call frame the index is built from.
+type frame struct {
+	Action struct {
+		From          *common.Address `json:"from"`
+		To            *common.Address `json:"to"`
+		Address       *common.Address `json:"address"`
+		RefundAddress *common.Address `json:"refundAddress"`
+	} `json:"action"`
+	Result *struct {
+		Address *common.Address `json:"address"`
+	} `json:"result"`
+	TransactionHash *common.Hash `json:"transactionHash"`
+	Type            string       `json:"type"`
+}
+
+// from returns the address the frame originates from.
+func (f *frame) from() *common.Address {
+	if f.Type == "suicide" {
+		return f.Action.Address
+	}
+	return f.Action.From
+}
+
+// to returns the address the frame is directed at. For contract creations
+// this is the created contract and for self-destructs the beneficiary.
+func (f *frame) to() *common.Address {
+	switch f.Type {
+	case "create":
+		if f.Result != nil {
+			return f.Result.Address
+		}
+		return nil
+	case "suicide":
+		return f.Action.RefundAddress
+	default:
+		return f.Action.To
+	}
+}
+
+// Indexer traces every accepted block with the flatCallTracer and stores the
+// resulting call frames, together with an index of the addresses involved.
+type Indexer struct {
+	backend Backend
+	api     *tracers.API
+	db      ethdb.KeyValueStore
+
+	// head is the last accepted block to index up to, and wake signals the
+	// indexing worker that it changed.
+	head       atomic.Uint64
+	wake       chan struct{}
+	retryDelay time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewIndexer creates an indexer storing traces in [db].
+func NewIndexer(backend Backend, db ethdb.KeyValueStore) *Indexer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Indexer{
+		backend:    backend,
+		api:        tracers.NewAPI(backend),
+		db:         db,
+		wake:       make(chan struct{}, 1),
+		retryDelay: defaultRetryDelay,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start catches up with the last accepted block and keeps indexing blocks as
+// they are accepted. When nothing has been indexed yet, indexing starts from
+// the last accepted block: blocks accepted before the index was enabled are
+// never traced.
+//
+// Accepted block events only update the head to index up to, so that a slow
+// indexer never blocks their delivery. Blocks are indexed by a separate
+// worker, which retries a block failing to be indexed with exponential
+// backoff and skips it after [maxIndexAttempts] attempts.
+func (i *Indexer) Start() {
+	events := make(chan core.ChainEvent, acceptedChanSize)
+	sub := i.backend.SubscribeChainAcceptedEvent(events)
+
+	if _, _, ok := ReadIndexedRange(i.db); !ok {
+		start := i.backend.LastAcceptedBlock().NumberU64()
+		if start == 0 {
+			// The genesis block is not traceable.
+			start = 1
+		}
+		writeNumber(i.db, firstIndexedKey, start)
+		writeNumber(i.db, lastIndexedKey, start-1)
+		log.Info("Initialised flat trace index", "start", start)
+	}
+	i.setHead(i.backend.LastAcceptedBlock().NumberU64())
+
+	i.wg.Add(2)
+	go func() {
+		defer i.wg.Done()
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev := <-events:
+				i.setHead(ev.Block.NumberU64())
+			case err := <-sub.Err():
+				if err != nil {
+					log.Error("Flat trace indexer subscription failed", "err", err)
+				}
+				return
+			case <-i.ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		defer i.wg.Done()
+		i.run()
+	}()
+}
+
+// Stop terminates the indexer, waiting for the block being indexed.
+func (i *Indexer) Stop() {
+	i.cancel()
+	i.wg.Wait()
+}
+
+// setHead sets the block to index up to and wakes the indexing worker,
+// without blocking.
+func (i *Indexer) setHead(number uint64) {
+	i.head.Store(number)
+	select {
+	case i.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run indexes blocks up to the head whenever it changes, until the indexer is
+// stopped. A block failing to be indexed is retried after a delay doubling
+// with every attempt, during which head changes are deferred, and skipped
+// after [maxIndexAttempts] attempts.
+func (i *Indexer) run() {
+	var (
+		retry    <-chan time.Time
+		failing  uint64
+		attempts int
+	)
+	for {
+		wake := i.wake
+		if retry != nil {
+			wake = nil
+		}
+		select {
+		case <-wake:
+		case <-retry:
+		case <-i.ctx.Done():
+			return
+		}
+		retry = nil
+
+		number, err := i.indexTo(i.head.Load())
+		if err == nil {
+			attempts = 0
+			continue
+		}
+		indexFailuresCounter.Inc(1)
+		if number != failing {
+			failing, attempts = number, 0
+		}
+		attempts++
+		if attempts < maxIndexAttempts {
+			delay := i.retryDelay << (attempts - 1)
+			log.Warn("Failed to index flat traces, retrying", "number", number, "attempt", attempts, "delay", delay, "err", err)
+			retry = time.After(delay)
+			continue
+		}
+
+		log.Error("Failed to index flat traces, skipping block", "number", number, "attempts", attempts, "err", err)
+		indexSkippedCounter.Inc(1)
+		batch := i.db.NewBatch()
+		WriteSkippedBlock(batch, number)
+		writeNumber(batch, lastIndexedKey, number)
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed to skip flat trace indexing", "number", number, "err", err)
+		}
+		attempts = 0
+		i.setHead(i.head.Load())
+	}
+}
+
+// indexTo indexes every block after the last indexed one up to [head]. If a
+// block fails to be indexed, it returns its number and the error.
+func (i *Indexer) indexTo(head uint64) (uint64, error) {
+	_, last, _ := ReadIndexedRange(i.db)
+	for number := last + 1; number <= head; number++ {
+		if i.ctx.Err() != nil {
+			return 0, nil
+		}
+		block, err := i.backend.BlockByNumber(i.ctx, rpc.BlockNumber(number))
+		if err == nil && block == nil {
+			err = fmt.Errorf("block #%d not found", number)
+		}
+		if err == nil {
+			err = i.indexBlock(block)
+		}
+		if err != nil {
+			return number, err
+		}
+	}
+	return 0, nil
+}
+
+// indexBlock traces [block] and atomically stores its call frames, the
+// address index entries and the new last indexed block.
+func (i *Indexer) indexBlock(block *types.Block) error {
+	tracer := flatCallTracer
+	results, err := i.api.TraceBlockByHash(i.ctx, block.Hash(), &tracers.TraceConfig{Tracer: &tracer})
+	if err != nil {
+		return err
+	}
+	var (
+		number    = block.NumberU64()
+		traces    []json.RawMessage
+		addresses = make(map[common.Address]struct{})
+	)
+	for _, result := range results {
+		if result.Error != "" {
+			return fmt.Errorf("tracing transaction %s failed: %s", result.TxHash, result.Error)
+		}
+		raw, ok := result.Result.(json.RawMessage)
+		if !ok {
+			return fmt.Errorf("unexpected trace result %T for transaction %s", result.Result, result.TxHash)
+		}
+		var frames []json.RawMessage
+		if err := json.Unmarshal(raw, &frames); err != nil {
+			return err
+		}
+		for _, enc := range frames {
+			var f frame
+			if err := json.Unmarshal(enc, &f); err != nil {
+				return err
+			}
+			for _, addr := range []*common.Address{f.from(), f.to()} {
+				if addr != nil {
+					addresses[*addr] = struct{}{}
+				}
+			}
+		}
+		traces = append(traces, frames...)
+	}
+
+	batch := i.db.NewBatch()
+	if len(traces) > 0 {
+		WriteBlockTraces(batch, number, traces)
+	}
+	for addr := range addresses {
+		WriteAddressIndex(batch, addr, number)
+	}
+	writeNumber(batch, lastIndexedKey, number)
+	return batch.Write()
+}
@@ -0,0 +1,330 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package flatindex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/coreth/consensus"
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/core/vm"
+	"github.com/ava-labs/coreth/eth/tracers"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/rpc"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+
+	// callerAddr holds a contract calling calleeAddr with all its gas.
+	callerAddr = common.HexToAddress("0xaaaa")
+	calleeAddr = common.HexToAddress("0xbbbb")
+	otherAddr  = common.HexToAddress("0xcccc")
+)
+
+type testBackend struct {
+	chainConfig *params.ChainConfig
+	engine      consensus.Engine
+	chaindb     ethdb.Database
+	chain       *core.BlockChain
+	failBlock   uint64 // Number of a block failing to be traced, if not 0
+}
+
+func (b *testBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return b.chain.GetHeaderByHash(hash), nil
+}
+
+func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	if number < 0 {
+		return b.chain.CurrentHeader(), nil
+	}
+	return b.chain.GetHeaderByNumber(uint64(number)), nil
+}
+
+func (b *testBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return b.chain.GetBlockByHash(hash), nil
+}
+
+func (b *testBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	if number < 0 {
+		return b.chain.GetBlockByNumber(b.chain.CurrentBlock().Number.Uint64()), nil
+	}
+	return b.chain.GetBlockByNumber(uint64(number)), nil
+}
+
+func (b *testBackend) BadBlocks() ([]*types.Block, []*core.BadBlockReason) { return nil, nil }
+
+func (b *testBackend) GetTransaction(ctx context.Context, txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64, error) {
+	tx, hash, blockNumber, index := rawdb.ReadTransaction(b.chaindb, txHash)
+	return tx != nil, tx, hash, blockNumber, index, nil
+}
+
+func (b *testBackend) RPCGasCap() uint64                { return 25000000 }
+func (b *testBackend) ChainConfig() *params.ChainConfig { return b.chainConfig }
+func (b *testBackend) Engine() consensus.Engine         { return b.engine }
+func (b *testBackend) ChainDb() ethdb.Database          { return b.chaindb }
+func (b *testBackend) LastAcceptedBlock() *types.Block  { return b.chain.LastAcceptedBlock() }
+
+func (b *testBackend) SubscribeChainAcceptedEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return b.chain.SubscribeChainAcceptedEvent(ch)
+}
+
+func (b *testBackend) StateAtBlock(ctx context.Context, block *types.Block, reexec uint64, base *state.StateDB, readOnly bool, preferDisk bool) (*state.StateDB, tracers.StateReleaseFunc, error) {
+	statedb, err := b.chain.StateAt(block.Root())
+	if err != nil {
+		return nil, nil, err
+	}
+	return statedb, func() {}, nil
+}
+
+func (b *testBackend) StateAtNextBlock(ctx context.Context, parent, nextBlock *types.Block, reexec uint64, base *state.StateDB, readOnly bool, preferDisk bool) (*state.StateDB, tracers.StateReleaseFunc, error) {
+	if b.failBlock != 0 && nextBlock.NumberU64() == b.failBlock {
+		return nil, nil, errors.New("test failure")
+	}
+	statedb, release, err := b.StateAtBlock(ctx, parent, reexec, base, readOnly, preferDisk)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := core.ApplyUpgrades(b.chainConfig, &parent.Header().Time, nextBlock, statedb); err != nil {
+		release()
+		return nil, nil, err
+	}
+	return statedb, release, nil
+}
+
+func (b *testBackend) StateAtTransaction(ctx context.Context, block *types.Block, txIndex int, reexec uint64) (*core.Message, vm.BlockContext, *state.StateDB, tracers.StateReleaseFunc, error) {
+	return nil, vm.BlockContext{}, nil, nil, errors.New("not implemented")
+}
+
+func (b *testBackend) HistoricalStateReexec() uint64 { return 0 }
+
+// newTestBackend returns a backend with an empty chain and the blocks to be
+// inserted into it:
+//   - block 1 calls callerAddr, which calls calleeAddr
+//   - block 2 transfers to otherAddr
+//   - block 3 is empty
+//   - block 4 transfers to calleeAddr
+func newTestBackend(t *testing.T) (*testBackend, []*types.Block, []common.Hash) {
+	var (
+		gspec = &core.Genesis{
+			Config: params.TestFlareChainConfig,
+			Alloc: types.GenesisAlloc{
+				testAddr: {Balance: big.NewInt(params.Ether)},
+				// CALL(gas, 0xbbbb, 0, 0, 0, 0, 0)
+				callerAddr: {Code: common.FromHex("6000600060006000600061bbbb5af100")},
+			},
+		}
+		backend = &testBackend{
+			chainConfig: gspec.Config,
+			engine:      dummy.NewETHFaker(),
+			chaindb:     rawdb.NewMemoryDatabase(),
+		}
+		signer = types.LatestSigner(gspec.Config)
+		hashes []common.Hash
+	)
+	_, blocks, _, err := core.GenerateChainWithGenesis(gspec, backend.engine, 4, 10, func(i int, b *core.BlockGen) {
+		var to common.Address
+		switch i {
+		case 0:
+			to = callerAddr
+		case 1:
+			to = otherAddr
+		case 2:
+			return
+		case 3:
+			to = calleeAddr
+		}
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    b.TxNonce(testAddr),
+			To:       &to,
+			Value:    big.NewInt(1000),
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+		}), signer, testKey)
+		require.NoError(t, err)
+		b.AddTx(tx)
+		hashes = append(hashes, tx.Hash())
+	})
+	require.NoError(t, err)
+
+	backend.chain, err = core.NewBlockChain(backend.chaindb, core.DefaultCacheConfig, gspec, backend.engine, vm.Config{}, common.Hash{}, false)
+	require.NoError(t, err)
+	t.Cleanup(backend.chain.Stop)
+	return backend, blocks, hashes
+}
+
+// accept inserts and accepts [blocks] into the chain of [backend].
+func (b *testBackend) accept(t *testing.T, blocks []*types.Block) {
+	_, err := b.chain.InsertChain(blocks)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		require.NoError(t, b.chain.Accept(block))
+	}
+	b.chain.DrainAcceptorQueue()
+}
+
+// waitIndexed waits until [number] is the last indexed block of [db].
+func waitIndexed(t *testing.T, db ethdb.KeyValueReader, number uint64) {
+	require.Eventually(t, func() bool {
+		_, last, ok := ReadIndexedRange(db)
+		return ok && last == number
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// frames decodes the frames of [traces].
+func frames(t *testing.T, traces []json.RawMessage) []frame {
+	decoded := make([]frame, len(traces))
+	for i, enc := range traces {
+		require.NoError(t, json.Unmarshal(enc, &decoded[i]))
+	}
+	return decoded
+}
+
+func TestIndexer(t *testing.T) {
+	backend, blocks, hashes := newTestBackend(t)
+	db := memorydb.New()
+
+	indexer := NewIndexer(backend, db)
+	indexer.Start()
+	backend.accept(t, blocks[:3])
+	waitIndexed(t, db, 3)
+	indexer.Stop()
+
+	first, _, _ := ReadIndexedRange(db)
+	require.Equal(t, uint64(1), first)
+
+	// Blocks accepted while the indexer is stopped are indexed on restart.
+	backend.accept(t, blocks[3:])
+	indexer = NewIndexer(backend, db)
+	indexer.Start()
+	defer indexer.Stop()
+	waitIndexed(t, db, 4)
+
+	api := NewAPI(backend, db, 0)
+	ctx := context.Background()
+
+	traces, err := api.Block(ctx, 1)
+	require.NoError(t, err)
+	block1 := frames(t, traces)
+	require.Len(t, block1, 2)
+	require.Equal(t, testAddr, *block1[0].from())
+	require.Equal(t, callerAddr, *block1[0].to())
+	require.Equal(t, callerAddr, *block1[1].from())
+	require.Equal(t, calleeAddr, *block1[1].to())
+
+	traces, err = api.Block(ctx, 3)
+	require.NoError(t, err)
+	require.Empty(t, traces)
+
+	_, err = api.Block(ctx, 5)
+	require.ErrorContains(t, err, "not indexed")
+
+	traces, err = api.Transaction(ctx, hashes[0])
+	require.NoError(t, err)
+	require.Len(t, traces, 2)
+
+	traces, err = api.Transaction(ctx, common.Hash{1})
+	require.NoError(t, err)
+	require.Nil(t, traces)
+
+	count := func(n uint64) *uint64 { return &n }
+	tests := []struct {
+		name string
+		args FilterArgs
+		want []common.Hash
+	}{
+		{
+			name: "all",
+			args: FilterArgs{FromBlock: new(rpc.BlockNumber)},
+			want: []common.Hash{hashes[0], hashes[0], hashes[1], hashes[2]},
+		},
+		{
+			name: "from address",
+			args: FilterArgs{FromAddress: []common.Address{testAddr}, FromBlock: new(rpc.BlockNumber)},
+			want: []common.Hash{hashes[0], hashes[1], hashes[2]},
+		},
+		{
+			name: "internal call sender",
+			args: FilterArgs{FromAddress: []common.Address{callerAddr}, FromBlock: new(rpc.BlockNumber)},
+			want: []common.Hash{hashes[0]},
+		},
+		{
+			name: "to address",
+			args: FilterArgs{ToAddress: []common.Address{calleeAddr}, FromBlock: new(rpc.BlockNumber)},
+			want: []common.Hash{hashes[0], hashes[2]},
+		},
+		{
+			name: "from and to address",
+			args: FilterArgs{FromAddress: []common.Address{testAddr}, ToAddress: []common.Address{otherAddr, calleeAddr}, FromBlock: new(rpc.BlockNumber)},
+			want: []common.Hash{hashes[1], hashes[2]},
+		},
+		{
+			name: "latest block",
+			args: FilterArgs{ToAddress: []common.Address{calleeAddr}},
+			want: []common.Hash{hashes[2]},
+		},
+		{
+			name: "after and count",
+			args: FilterArgs{FromAddress: []common.Address{testAddr}, FromBlock: new(rpc.BlockNumber), After: count(1), Count: count(1)},
+			want: []common.Hash{hashes[1]},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			traces, err := api.Filter(ctx, test.args)
+			require.NoError(t, err)
+			have := make([]common.Hash, 0, len(traces))
+			for _, f := range frames(t, traces) {
+				have = append(have, *f.TransactionHash)
+			}
+			require.Equal(t, test.want, have)
+		})
+	}
+
+	limited := NewAPI(backend, db, 2)
+	_, err = limited.Filter(ctx, FilterArgs{FromBlock: new(rpc.BlockNumber)})
+	require.ErrorContains(t, err, "requested too many blocks")
+	_, err = limited.Filter(ctx, FilterArgs{FromBlock: new(rpc.BlockNumber), ToAddress: []common.Address{calleeAddr}})
+	require.NoError(t, err)
+}
+
+func TestIndexerSkipsFailingBlock(t *testing.T) {
+	backend, blocks, _ := newTestBackend(t)
+	backend.failBlock = 2
+	db := memorydb.New()
+
+	indexer := NewIndexer(backend, db)
+	indexer.retryDelay = time.Millisecond
+	indexer.Start()
+	defer indexer.Stop()
+	backend.accept(t, blocks)
+
+	// The failing block is retried, then skipped to index the next blocks.
+	waitIndexed(t, db, 4)
+	require.True(t, IsSkippedBlock(db, 2))
+	require.False(t, IsSkippedBlock(db, 3))
+
+	api := NewAPI(backend, db, 0)
+	_, err := api.Block(context.Background(), 2)
+	require.ErrorIs(t, err, errSkippedBlock)
+	traces, err := api.Block(context.Background(), 4)
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+}
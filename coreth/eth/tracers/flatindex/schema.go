@@ -0,0 +1,157 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package flatindex
+
+import (
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// The index is kept in its own database, so the keys below do not need to
+// avoid the rawdb schema.
+var (
+	// firstIndexedKey tracks the number of the first block with stored traces.
+	firstIndexedKey = []byte("FirstIndexed")
+
+	// lastIndexedKey tracks the number of the last block with stored traces.
+	lastIndexedKey = []byte("LastIndexed")
+
+	blockTracesPrefix  = []byte("b") // blockTracesPrefix + num (uint64 big endian) -> JSON encoded flat traces
+	addressIndexPrefix = []byte("a") // addressIndexPrefix + address + num (uint64 big endian) -> nil
+	skippedBlockPrefix = []byte("s") // skippedBlockPrefix + num (uint64 big endian) -> nil
+)
+
+// encodeBlockNumber encodes a block number as big endian uint64
+func encodeBlockNumber(number uint64) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, number)
+	return enc
+}
+
+// blockTracesKey = blockTracesPrefix + num (uint64 big endian)
+func blockTracesKey(number uint64) []byte {
+	return append(append([]byte{}, blockTracesPrefix...), encodeBlockNumber(number)...)
+}
+
+// skippedBlockKey = skippedBlockPrefix + num (uint64 big endian)
+func skippedBlockKey(number uint64) []byte {
+	return append(append([]byte{}, skippedBlockPrefix...), encodeBlockNumber(number)...)
+}
+
+// addressIndexKey = addressIndexPrefix + address + num (uint64 big endian)
+func addressIndexKey(address common.Address, number uint64) []byte {
+	return append(addressPrefix(address), encodeBlockNumber(number)...)
+}
+
+// addressPrefix returns the prefix shared by all index entries of the
+// given address.
+func addressPrefix(address common.Address) []byte {
+	return append(append([]byte{}, addressIndexPrefix...), address.Bytes()...)
+}
+
+// readNumber retrieves the block number stored under the given key.
+func readNumber(db ethdb.KeyValueReader, key []byte) (uint64, bool) {
+	data, _ := db.Get(key)
+	if len(data) != 8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(data), true
+}
+
+// writeNumber stores the block number under the given key.
+func writeNumber(db ethdb.KeyValueWriter, key []byte, number uint64) {
+	if err := db.Put(key, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store flat trace index marker", "err", err)
+	}
+}
+
+// ReadIndexedRange returns the first and last block numbers with stored
+// traces, and false if nothing has been indexed yet.
+func ReadIndexedRange(db ethdb.KeyValueReader) (uint64, uint64, bool) {
+	first, ok := readNumber(db, firstIndexedKey)
+	if !ok {
+		return 0, 0, false
+	}
+	last, ok := readNumber(db, lastIndexedKey)
+	if !ok {
+		return 0, 0, false
+	}
+	return first, last, true
+}
+
+// ReadBlockTraces retrieves the flat call frames of all transactions in the
+// block with the given number. It returns nil if the block has no traces.
+func ReadBlockTraces(db ethdb.KeyValueReader, number uint64) []json.RawMessage {
+	data, _ := db.Get(blockTracesKey(number))
+	if len(data) == 0 {
+		return nil
+	}
+	var traces []json.RawMessage
+	if err := json.Unmarshal(data, &traces); err != nil {
+		log.Error("Invalid flat traces JSON", "number", number, "err", err)
+		return nil
+	}
+	return traces
+}
+
+// WriteBlockTraces stores the flat call frames of all transactions in the
+// block with the given number.
+func WriteBlockTraces(db ethdb.KeyValueWriter, number uint64, traces []json.RawMessage) {
+	data, err := json.Marshal(traces)
+	if err != nil {
+		log.Crit("Failed to encode flat traces", "err", err)
+	}
+	if err := db.Put(blockTracesKey(number), data); err != nil {
+		log.Crit("Failed to store flat traces", "err", err)
+	}
+}
+
+// IsSkippedBlock returns whether the block with the given number was skipped
+// because it failed to be traced.
+func IsSkippedBlock(db ethdb.KeyValueReader, number uint64) bool {
+	ok, _ := db.Has(skippedBlockKey(number))
+	return ok
+}
+
+// WriteSkippedBlock records that the block with the given number was skipped
+// because it failed to be traced.
+func WriteSkippedBlock(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Put(skippedBlockKey(number), nil); err != nil {
+		log.Crit("Failed to store skipped flat trace block", "err", err)
+	}
+}
+
+// WriteAddressIndex records that the given address appears in the traces of
+// the block with the given number.
+func WriteAddressIndex(db ethdb.KeyValueWriter, address common.Address, number uint64) {
+	if err := db.Put(addressIndexKey(address, number), nil); err != nil {
+		log.Crit("Failed to store flat trace address index", "err", err)
+	}
+}
+
+// ReadAddressBlocks returns the numbers of the blocks in [from, to] whose
+// traces contain the given address, in ascending order.
+func ReadAddressBlocks(db ethdb.Iteratee, address common.Address, from uint64, to uint64) []uint64 {
+	prefix := addressPrefix(address)
+	it := db.NewIterator(prefix, encodeBlockNumber(from))
+	defer it.Release()
+
+	var numbers []uint64
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(prefix):])
+		if number > to {
+			break
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers
+}
@@ -408,3 +408,96 @@ func TestInternals(t *testing.T) {
 		})
 	}
 }
+
+// TestSystemCalls tests that the call tracers report the daemon invocation
+// made by the node at the end of a transaction as a system call frame.
+func TestSystemCalls(t *testing.T) {
+	var (
+		config    = params.TestFlareLaunchConfig
+		to        = common.HexToAddress("0x00000000000000000000000000000000deadbeef")
+		origin    = common.HexToAddress("0x00000000000000000000000000000000feed")
+		daemon    = common.HexToAddress(core.GetDaemonContractAddr(5))
+		txContext = vm.TxContext{
+			Origin:   origin,
+			GasPrice: big.NewInt(1),
+		}
+		context = vm.BlockContext{
+			CanTransfer: core.CanTransfer,
+			Transfer:    core.Transfer,
+			Coinbase:    common.Address{},
+			BlockNumber: new(big.Int).SetUint64(8000000),
+			Time:        5,
+			Difficulty:  big.NewInt(0x30000),
+			GasLimit:    uint64(6000000),
+		}
+	)
+	mkTracer := func(name string, cfg json.RawMessage) tracers.Tracer {
+		tr, err := tracers.DefaultDirectory.New(name, &tracers.Context{}, cfg)
+		if err != nil {
+			t.Fatalf("failed to create call tracer: %v", err)
+		}
+		return tr
+	}
+
+	for _, tc := range []struct {
+		name   string
+		tracer tracers.Tracer
+		want   string
+	}{
+		{
+			name:   "callTracer",
+			tracer: mkTracer("callTracer", json.RawMessage(`{ "withSystemCalls": true }`)),
+			want:   `{"from":"0x000000000000000000000000000000000000feed","gas":"0x13880","gasUsed":"0x5208","to":"0x00000000000000000000000000000000deadbeef","input":"0x","calls":[{"from":"0x1000000000000000000000000000000000000002","gas":"0x23c34600","gasUsed":"0x9","to":"0x1000000000000000000000000000000000000002","input":"0x7fec8d38","output":"0x0000000000000000000000000000000000000000000000000000000000000000","value":"0x0","type":"DAEMON"}],"value":"0x0","type":"CALL"}`,
+		},
+		{
+			name:   "callTracer without system calls",
+			tracer: mkTracer("callTracer", nil),
+			want:   `{"from":"0x000000000000000000000000000000000000feed","gas":"0x13880","gasUsed":"0x5208","to":"0x00000000000000000000000000000000deadbeef","input":"0x","value":"0x0","type":"CALL"}`,
+		},
+		{
+			name:   "flatCallTracer",
+			tracer: mkTracer("flatCallTracer", json.RawMessage(`{ "withSystemCalls": true }`)),
+			want:   `[{"action":{"callType":"call","from":"0x000000000000000000000000000000000000feed","gas":"0x13880","input":"0x","to":"0x00000000000000000000000000000000deadbeef","value":"0x0"},"blockHash":null,"blockNumber":0,"result":{"gasUsed":"0x5208","output":"0x"},"subtraces":1,"traceAddress":[],"transactionHash":null,"transactionPosition":0,"type":"call"},{"action":{"callType":"daemon","from":"0x1000000000000000000000000000000000000002","gas":"0x23c34600","input":"0x7fec8d38","to":"0x1000000000000000000000000000000000000002","value":"0x0"},"blockHash":null,"blockNumber":0,"result":{"gasUsed":"0x9","output":"0x0000000000000000000000000000000000000000000000000000000000000000"},"subtraces":0,"traceAddress":[0],"transactionHash":null,"transactionPosition":0,"type":"call"}]`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := tests.MakePreState(rawdb.NewMemoryDatabase(),
+				types.GenesisAlloc{
+					to: types.GenesisAccount{},
+					daemon: types.GenesisAccount{
+						// Return a zero mint request
+						Code: []byte{
+							byte(vm.PUSH1), 0x20, byte(vm.PUSH1), 0x0, byte(vm.RETURN),
+						},
+					},
+					origin: types.GenesisAccount{
+						Balance: big.NewInt(500000000000000),
+					},
+				}, false, rawdb.HashScheme)
+			defer state.Close()
+
+			evm := vm.NewEVM(context, txContext, state.StateDB, config, vm.Config{Tracer: tc.tracer})
+			msg := &core.Message{
+				To:                &to,
+				From:              origin,
+				Value:             big.NewInt(0),
+				GasLimit:          80000,
+				GasPrice:          big.NewInt(0),
+				GasFeeCap:         big.NewInt(0),
+				GasTipCap:         big.NewInt(0),
+				SkipAccountChecks: false,
+			}
+			st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(msg.GasLimit))
+			if _, err := st.TransitionDb(); err != nil {
+				t.Fatalf("test %v: failed to execute transaction: %v", tc.name, err)
+			}
+			res, err := tc.tracer.GetResult()
+			if err != nil {
+				t.Fatalf("test %v: failed to retrieve trace result: %v", tc.name, err)
+			}
+			if string(res) != tc.want {
+				t.Errorf("test %v: trace mismatch\n have: %v\n want: %v\n", tc.name, string(res), tc.want)
+			}
+		})
+	}
+}
@@ -57,23 +57,27 @@ type callLog struct {
 }
 
 type callFrame struct {
-	Type         vm.OpCode       `json:"-"`
-	From         common.Address  `json:"from"`
-	Gas          uint64          `json:"gas"`
-	GasUsed      uint64          `json:"gasUsed"`
-	To           *common.Address `json:"to,omitempty" rlp:"optional"`
-	Input        []byte          `json:"input" rlp:"optional"`
-	Output       []byte          `json:"output,omitempty" rlp:"optional"`
-	Error        string          `json:"error,omitempty" rlp:"optional"`
-	RevertReason string          `json:"revertReason,omitempty"`
-	Calls        []callFrame     `json:"calls,omitempty" rlp:"optional"`
-	Logs         []callLog       `json:"logs,omitempty" rlp:"optional"`
+	Type         vm.OpCode         `json:"-"`
+	SystemType   vm.SystemCallType `json:"-" rlp:"-"` // Set if the frame is a system call made by the node
+	From         common.Address    `json:"from"`
+	Gas          uint64            `json:"gas"`
+	GasUsed      uint64            `json:"gasUsed"`
+	To           *common.Address   `json:"to,omitempty" rlp:"optional"`
+	Input        []byte            `json:"input" rlp:"optional"`
+	Output       []byte            `json:"output,omitempty" rlp:"optional"`
+	Error        string            `json:"error,omitempty" rlp:"optional"`
+	RevertReason string            `json:"revertReason,omitempty"`
+	Calls        []callFrame       `json:"calls,omitempty" rlp:"optional"`
+	Logs         []callLog         `json:"logs,omitempty" rlp:"optional"`
 	// Placed at end on purpose. The RLP will be decoded to 0 instead of
 	// nil if there are non-empty elements after in the struct.
 	Value *big.Int `json:"value,omitempty" rlp:"optional"`
 }
 
 func (f callFrame) TypeString() string {
+	if f.SystemType != "" {
+		return string(f.SystemType)
+	}
 	return f.Type.String()
 }
 
@@ -124,6 +128,9 @@ type callTracer struct {
 type callTracerConfig struct {
 	OnlyTopCall bool `json:"onlyTopCall"` // If true, call tracer won't collect any subcalls
 	WithLog     bool `json:"withLog"`     // If true, call tracer will collect event logs
+	// If true, call tracer will collect the system calls made by the node
+	// (daemon, state connector and governance) as children of the top call
+	WithSystemCalls bool `json:"withSystemCalls"`
 }
 
 // newCallTracer returns a native go tracer which tracks
@@ -254,6 +261,39 @@ func (t *callTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
 	t.callstack[size-1].Calls = append(t.callstack[size-1].Calls, call)
 }
 
+// CaptureSystemEnter implements the SystemCallLogger interface and is called
+// when the node makes a system call after the top call has finished.
+func (t *callTracer) CaptureSystemEnter(typ vm.SystemCallType, from common.Address, to common.Address, input []byte, gas uint64) {
+	if !t.config.WithSystemCalls {
+		return
+	}
+	// Skip if tracing was interrupted
+	if t.interrupt.Load() {
+		return
+	}
+
+	toCopy := to
+	call := callFrame{
+		Type:       vm.CALL,
+		SystemType: typ,
+		From:       from,
+		To:         &toCopy,
+		Input:      common.CopyBytes(input),
+		Gas:        gas,
+		Value:      new(big.Int),
+	}
+	t.callstack = append(t.callstack, call)
+}
+
+// CaptureSystemExit implements the SystemCallLogger interface and is called
+// when a system call returns.
+func (t *callTracer) CaptureSystemExit(output []byte, gasUsed uint64, err error) {
+	if !t.config.WithSystemCalls {
+		return
+	}
+	t.CaptureExit(output, gasUsed, err)
+}
+
 func (t *callTracer) CaptureTxStart(gasLimit uint64) {
 	t.gasLimit = gasLimit
 }
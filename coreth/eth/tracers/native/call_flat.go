@@ -129,6 +129,7 @@ type flatCallTracer struct {
 type flatCallTracerConfig struct {
 	ConvertParityErrors bool `json:"convertParityErrors"` // If true, call tracer converts errors to parity format
 	IncludePrecompiles  bool `json:"includePrecompiles"`  // If true, call tracer includes calls to precompiled contracts
+	WithSystemCalls     bool `json:"withSystemCalls"`     // If true, call tracer includes the system calls made by the node
 }
 
 // newFlatCallTracer returns a new flatCallTracer.
@@ -142,7 +143,11 @@ func newFlatCallTracer(ctx *tracers.Context, cfg json.RawMessage) (tracers.Trace
 
 	// Create inner call tracer with default configuration, don't forward
 	// the OnlyTopCall or WithLog to inner for now
-	tracer, err := tracers.DefaultDirectory.New("callTracer", ctx, nil)
+	innerConfig, err := json.Marshal(callTracerConfig{WithSystemCalls: config.WithSystemCalls})
+	if err != nil {
+		return nil, err
+	}
+	tracer, err := tracers.DefaultDirectory.New("callTracer", ctx, innerConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -212,6 +217,16 @@ func (t *flatCallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
 	}
 }
 
+// CaptureSystemEnter implements the SystemCallLogger interface.
+func (t *flatCallTracer) CaptureSystemEnter(typ vm.SystemCallType, from common.Address, to common.Address, input []byte, gas uint64) {
+	t.tracer.CaptureSystemEnter(typ, from, to, input, gas)
+}
+
+// CaptureSystemExit implements the SystemCallLogger interface.
+func (t *flatCallTracer) CaptureSystemExit(output []byte, gasUsed uint64, err error) {
+	t.tracer.CaptureSystemExit(output, gasUsed, err)
+}
+
 func (t *flatCallTracer) CaptureTxStart(gasLimit uint64) {
 	t.tracer.CaptureTxStart(gasLimit)
 }
@@ -331,7 +346,7 @@ func newFlatCall(input *callFrame) *flatCallFrame {
 			To:       input.To,
 			Gas:      &input.Gas,
 			Value:    input.Value,
-			CallType: strings.ToLower(input.Type.String()),
+			CallType: strings.ToLower(input.TypeString()),
 			Input:    &actionInput,
 		},
 		Result: &flatCallResult{
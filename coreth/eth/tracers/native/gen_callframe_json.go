@@ -16,22 +16,24 @@ var _ = (*callFrameMarshaling)(nil)
 // MarshalJSON marshals as JSON.
 func (c callFrame) MarshalJSON() ([]byte, error) {
 	type callFrame0 struct {
-		Type         vm.OpCode       `json:"-"`
-		From         common.Address  `json:"from"`
-		Gas          hexutil.Uint64  `json:"gas"`
-		GasUsed      hexutil.Uint64  `json:"gasUsed"`
-		To           *common.Address `json:"to,omitempty" rlp:"optional"`
-		Input        hexutil.Bytes   `json:"input" rlp:"optional"`
-		Output       hexutil.Bytes   `json:"output,omitempty" rlp:"optional"`
-		Error        string          `json:"error,omitempty" rlp:"optional"`
-		RevertReason string          `json:"revertReason,omitempty"`
-		Calls        []callFrame     `json:"calls,omitempty" rlp:"optional"`
-		Logs         []callLog       `json:"logs,omitempty" rlp:"optional"`
-		Value        *hexutil.Big    `json:"value,omitempty" rlp:"optional"`
-		TypeString   string          `json:"type"`
+		Type         vm.OpCode         `json:"-"`
+		SystemType   vm.SystemCallType `json:"-" rlp:"-"`
+		From         common.Address    `json:"from"`
+		Gas          hexutil.Uint64    `json:"gas"`
+		GasUsed      hexutil.Uint64    `json:"gasUsed"`
+		To           *common.Address   `json:"to,omitempty" rlp:"optional"`
+		Input        hexutil.Bytes     `json:"input" rlp:"optional"`
+		Output       hexutil.Bytes     `json:"output,omitempty" rlp:"optional"`
+		Error        string            `json:"error,omitempty" rlp:"optional"`
+		RevertReason string            `json:"revertReason,omitempty"`
+		Calls        []callFrame       `json:"calls,omitempty" rlp:"optional"`
+		Logs         []callLog         `json:"logs,omitempty" rlp:"optional"`
+		Value        *hexutil.Big      `json:"value,omitempty" rlp:"optional"`
+		TypeString   string            `json:"type"`
 	}
 	var enc callFrame0
 	enc.Type = c.Type
+	enc.SystemType = c.SystemType
 	enc.From = c.From
 	enc.Gas = hexutil.Uint64(c.Gas)
 	enc.GasUsed = hexutil.Uint64(c.GasUsed)
@@ -50,18 +52,19 @@ func (c callFrame) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON unmarshals from JSON.
 func (c *callFrame) UnmarshalJSON(input []byte) error {
 	type callFrame0 struct {
-		Type         *vm.OpCode      `json:"-"`
-		From         *common.Address `json:"from"`
-		Gas          *hexutil.Uint64 `json:"gas"`
-		GasUsed      *hexutil.Uint64 `json:"gasUsed"`
-		To           *common.Address `json:"to,omitempty" rlp:"optional"`
-		Input        *hexutil.Bytes  `json:"input" rlp:"optional"`
-		Output       *hexutil.Bytes  `json:"output,omitempty" rlp:"optional"`
-		Error        *string         `json:"error,omitempty" rlp:"optional"`
-		RevertReason *string         `json:"revertReason,omitempty"`
-		Calls        []callFrame     `json:"calls,omitempty" rlp:"optional"`
-		Logs         []callLog       `json:"logs,omitempty" rlp:"optional"`
-		Value        *hexutil.Big    `json:"value,omitempty" rlp:"optional"`
+		Type         *vm.OpCode         `json:"-"`
+		SystemType   *vm.SystemCallType `json:"-" rlp:"-"`
+		From         *common.Address    `json:"from"`
+		Gas          *hexutil.Uint64    `json:"gas"`
+		GasUsed      *hexutil.Uint64    `json:"gasUsed"`
+		To           *common.Address    `json:"to,omitempty" rlp:"optional"`
+		Input        *hexutil.Bytes     `json:"input" rlp:"optional"`
+		Output       *hexutil.Bytes     `json:"output,omitempty" rlp:"optional"`
+		Error        *string            `json:"error,omitempty" rlp:"optional"`
+		RevertReason *string            `json:"revertReason,omitempty"`
+		Calls        []callFrame        `json:"calls,omitempty" rlp:"optional"`
+		Logs         []callLog          `json:"logs,omitempty" rlp:"optional"`
+		Value        *hexutil.Big       `json:"value,omitempty" rlp:"optional"`
 	}
 	var dec callFrame0
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -70,6 +73,9 @@ func (c *callFrame) UnmarshalJSON(input []byte) error {
 	if dec.Type != nil {
 		c.Type = *dec.Type
 	}
+	if dec.SystemType != nil {
+		c.SystemType = *dec.SystemType
+	}
 	if dec.From != nil {
 		c.From = *dec.From
 	}
@@ -111,6 +111,25 @@ func (t *muxTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
 	}
 }
 
+// CaptureSystemEnter is called when the node makes a system call, forwarded to
+// the tracers that observe system calls.
+func (t *muxTracer) CaptureSystemEnter(typ vm.SystemCallType, from common.Address, to common.Address, input []byte, gas uint64) {
+	for _, t := range t.tracers {
+		if t, ok := t.(vm.SystemCallLogger); ok {
+			t.CaptureSystemEnter(typ, from, to, input, gas)
+		}
+	}
+}
+
+// CaptureSystemExit is called when a system call returns.
+func (t *muxTracer) CaptureSystemExit(output []byte, gasUsed uint64, err error) {
+	for _, t := range t.tracers {
+		if t, ok := t.(vm.SystemCallLogger); ok {
+			t.CaptureSystemExit(output, gasUsed, err)
+		}
+	}
+}
+
 func (t *muxTracer) CaptureTxStart(gasLimit uint64) {
 	for _, t := range t.tracers {
 		t.CaptureTxStart(gasLimit)
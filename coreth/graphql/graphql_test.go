@@ -0,0 +1,212 @@
+// (c) 2025, Ava Labs, Inc.
+//
+// This file is a derived work, based on the go-ethereum library whose original
+// notices appear below.
+//
+// It is distributed under a license compatible with the licensing terms of the
+// original code from which it is derived.
+//
+// Much love to the original authors for their work.
+// **********
+// Copyright 2019 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/eth"
+	"github.com/ava-labs/coreth/eth/ethconfig"
+	"github.com/ava-labs/coreth/eth/filters"
+	"github.com/ava-labs/coreth/node"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm/upgrade/ap3"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+	dad        = common.HexToAddress("0x0000000000000000000000000000000000000dad")
+)
+
+// newGQLService creates an Ethereum backend with [genBlocks] blocks
+// transferring 1 wei to dad each, of which the first [accepted] are accepted,
+// and returns a GraphQL handler on top of it.
+func newGQLService(t *testing.T, genBlocks int, accepted int, allowUnfinalized bool, settings eth.Settings) http.Handler {
+	gspec := &core.Genesis{
+		Config:  params.TestFlareChainConfig,
+		Alloc:   types.GenesisAlloc{testAddr: {Balance: big.NewInt(params.Ether)}},
+		BaseFee: big.NewInt(ap3.InitialBaseFee),
+	}
+	stack, err := node.New(&node.Config{})
+	require.NoError(t, err)
+
+	ethConf := ethconfig.NewDefaultConfig()
+	ethConf.Genesis = gspec
+	ethConf.AllowUnfinalizedQueries = allowUnfinalized
+	engine := dummy.NewETHFaker()
+	backend, err := eth.New(stack, &ethConf, nil, rawdb.NewMemoryDatabase(), settings, common.Hash{}, engine, &mockable.Clock{})
+	require.NoError(t, err)
+	backend.Start()
+	t.Cleanup(func() { require.NoError(t, backend.Stop()) })
+
+	signer := types.LatestSigner(gspec.Config)
+	_, blocks, _, err := core.GenerateChainWithGenesis(gspec, engine, genBlocks, 10, func(i int, b *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(uint64(i), dad, common.Big1, params.TxGas, b.BaseFee(), nil), signer, testKey)
+		require.NoError(t, err)
+		b.AddTx(tx)
+	})
+	require.NoError(t, err)
+
+	chain := backend.BlockChain()
+	_, err = chain.InsertChain(blocks)
+	require.NoError(t, err)
+	for _, block := range blocks[:accepted] {
+		require.NoError(t, chain.Accept(block))
+	}
+	chain.DrainAcceptorQueue()
+
+	filterSystem := filters.NewFilterSystem(backend.APIBackend, filters.Config{})
+	handler, err := NewHandler(backend.APIBackend, filterSystem, time.Second)
+	require.NoError(t, err)
+	return handler
+}
+
+// query posts [query] to [handler] and returns the status code and body.
+func query(t *testing.T, handler http.Handler, query string) (int, string) {
+	body := fmt.Sprintf(`{"query": %q}`, query)
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	res, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return rec.Code, string(res)
+}
+
+func TestGraphQLQueries(t *testing.T) {
+	handler := newGQLService(t, 3, 3, false, eth.Settings{})
+
+	for i, tt := range []struct {
+		body string
+		want string
+		code int
+	}{
+		{
+			body: `{block(number: 1) { number transactionCount gasUsed }}`,
+			want: `{"data":{"block":{"number":"0x1","transactionCount":"0x1","gasUsed":"0x5208"}}}`,
+			code: 200,
+		},
+		{
+			body: `{block { number totalDifficulty }}`,
+			want: `{"data":{"block":{"number":"0x3","totalDifficulty":"0x3"}}}`,
+			code: 200,
+		},
+		{
+			body: `{blocks(from: 1) { number transactions { to { address } value } }}`,
+			want: `{"data":{"blocks":[` +
+				`{"number":"0x1","transactions":[{"to":{"address":"0x0000000000000000000000000000000000000dad"},"value":"0x1"}]},` +
+				`{"number":"0x2","transactions":[{"to":{"address":"0x0000000000000000000000000000000000000dad"},"value":"0x1"}]},` +
+				`{"number":"0x3","transactions":[{"to":{"address":"0x0000000000000000000000000000000000000dad"},"value":"0x1"}]}]}}`,
+			code: 200,
+		},
+		{
+			body: `{block(number: 2) { account(address: "0x0000000000000000000000000000000000000dad") { balance transactionCount } }}`,
+			want: `{"data":{"block":{"account":{"balance":"0x2","transactionCount":"0x0"}}}}`,
+			code: 200,
+		},
+		{
+			body: `{block(hash: "0x0000000000000000000000000000000000000000000000000000000000000001") { number }}`,
+			want: `{"errors":[{"message":"header for hash not found","path":["block"]}],"data":{"block":null}}`,
+			code: 400,
+		},
+		{
+			body: `{blocks(from: 2, to: 1) { number }}`,
+			want: `{"errors":[{"message":"invalid from and to block combination: from > to","path":["blocks"]}],"data":null}`,
+			code: 400,
+		},
+	} {
+		code, res := query(t, handler, tt.body)
+		require.Equal(t, tt.code, code, "testcase %d %s", i, tt.body)
+		require.JSONEq(t, tt.want, res, "testcase %d %s", i, tt.body)
+	}
+}
+
+func TestGraphQLMaxBlocksPerRequest(t *testing.T) {
+	handler := newGQLService(t, 5, 5, false, eth.Settings{MaxBlocksPerRequest: 3})
+
+	code, res := query(t, handler, `{blocks(from: 1, to: 3) { number }}`)
+	require.Equal(t, 200, code)
+	require.JSONEq(t, `{"data":{"blocks":[{"number":"0x1"},{"number":"0x2"},{"number":"0x3"}]}}`, res)
+
+	code, res = query(t, handler, `{blocks(from: 1, to: 4) { number }}`)
+	require.Equal(t, 400, code)
+	require.Contains(t, res, "requested too many blocks from 1 to 4, maximum is set to 3")
+
+	code, res = query(t, handler, `{logs(filter: {fromBlock: 0, toBlock: 4}) { index }}`)
+	require.Equal(t, 400, code)
+	require.Contains(t, res, "requested too many blocks from 0 to 4, maximum is set to 3")
+}
+
+func TestGraphQLUnfinalizedQueries(t *testing.T) {
+	tests := []struct {
+		allowUnfinalized bool
+		want             string
+	}{
+		{
+			allowUnfinalized: false,
+			want:             `{"data":{"blocks":[{"number":"0x1"},{"number":"0x2"}]}}`,
+		},
+		{
+			allowUnfinalized: true,
+			want:             `{"data":{"blocks":[{"number":"0x1"},{"number":"0x2"},{"number":"0x3"}]}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("allowUnfinalized=%t", test.allowUnfinalized), func(t *testing.T) {
+			handler := newGQLService(t, 3, 2, test.allowUnfinalized, eth.Settings{})
+
+			code, res := query(t, handler, `{blocks(from: 1) { number }}`)
+			require.Equal(t, 200, code)
+			require.JSONEq(t, test.want, res)
+
+			code, res = query(t, handler, `{block(number: 3) { number }}`)
+			if test.allowUnfinalized {
+				require.Equal(t, 200, code)
+				require.JSONEq(t, `{"data":{"block":{"number":"0x3"}}}`, res)
+			} else {
+				require.Equal(t, 400, code)
+				require.Contains(t, res, eth.ErrUnfinalizedData.Error())
+			}
+		})
+	}
+}
@@ -21,6 +21,30 @@ func (api *BlockChainAPI) GetChainConfig(ctx context.Context) *params.ChainConfi
 	return api.b.ChainConfig()
 }
 
+// GetPrioritisedContractConfig returns the prioritised contract config in
+// effect at the given block, or the latest block if none is given. Returns nil
+// if calls are never prioritised at that block.
+func (api *BlockChainAPI) GetPrioritisedContractConfig(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash) (*params.PrioritisedContractConfig, error) {
+	if blockNrOrHash == nil {
+		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		blockNrOrHash = &latest
+	}
+	header, err := api.b.HeaderByNumberOrHash(ctx, *blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errors.New("header not found")
+	}
+	return api.b.ChainConfig().GetPrioritisedContractConfig(header.Time), nil
+}
+
+// GetPrioritisedContractSchedule returns every prioritised contract config of
+// the chain, ordered by activation time.
+func (api *BlockChainAPI) GetPrioritisedContractSchedule(ctx context.Context) []params.PrioritisedContractConfig {
+	return api.b.ChainConfig().PrioritisedContractSchedule()
+}
+
 type DetailedExecutionResult struct {
 	UsedGas    uint64        `json:"gas"`        // Total used gas but include the refunded gas
 	ErrCode    int           `json:"errCode"`    // EVM error code
@@ -97,12 +121,13 @@ func (s *BlockChainAPI) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, erro
 // stateQueryBlockNumberAllowed returns a nil error if:
 //   - the node is configured to accept any state query (the query window is zero)
 //   - the block given has its number within the query window before the last accepted block.
-//     This query window is set to [core.TipBufferSize] when running in a non-archive mode.
+//     This query window is set to [core.TipBufferSize] when running in a non-archive mode,
+//     unless historical states are regenerated.
 //
 // Otherwise, it returns a non-nil error containing block number information.
 func (s *BlockChainAPI) stateQueryBlockNumberAllowed(blockNumOrHash rpc.BlockNumberOrHash) (err error) {
 	queryWindow := uint64(core.TipBufferSize)
-	if s.b.IsArchive() {
+	if s.b.IsArchive() || s.b.HistoricalStateRegeneration() {
 		queryWindow = s.b.HistoricalProofQueryWindow()
 		if queryWindow == 0 {
 			return nil
@@ -104,12 +104,24 @@ func TestBlockChainAPI_stateQueryBlockNumberAllowed(t *testing.T) {
 			makeBackend: func(ctrl *gomock.Controller) *MockBackend {
 				backend := NewMockBackend(ctrl)
 				backend.EXPECT().IsArchive().Return(false)
+				backend.EXPECT().HistoricalStateRegeneration().Return(false)
 				// query window is 32 as set to core.TipBufferSize
 				backend.EXPECT().LastAcceptedBlock().Return(makeBlockWithNumber(1033))
 				return backend
 			},
 			wantErrMessage: "block number 1000 is before the oldest allowed block number 1001 (window of 32 blocks)",
 		},
+		"block_number_in_window_historical_state_regeneration": {
+			blockNumOrHash: rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(1000)),
+			makeBackend: func(ctrl *gomock.Controller) *MockBackend {
+				backend := NewMockBackend(ctrl)
+				backend.EXPECT().IsArchive().Return(false)
+				backend.EXPECT().HistoricalStateRegeneration().Return(true)
+				backend.EXPECT().HistoricalProofQueryWindow().Return(queryWindow)
+				backend.EXPECT().LastAcceptedBlock().Return(makeBlockWithNumber(1033))
+				return backend
+			},
+		},
 	}
 
 	for name, testCase := range testCases {
@@ -654,6 +654,9 @@ func (b testBackend) IsArchive() bool {
 func (b testBackend) HistoricalProofQueryWindow() (queryWindow uint64) {
 	panic("implement me")
 }
+func (b testBackend) HistoricalStateRegeneration() bool {
+	panic("implement me")
+}
 
 func TestEstimateGas(t *testing.T) {
 	t.Parallel()
@@ -2094,3 +2097,173 @@ func testRPCResponseWithFile(t *testing.T, testid int, result interface{}, rpc s
 	}
 	require.JSONEqf(t, string(want), string(data), "test %d: json not match, want: %s, have: %s", testid, string(want), string(data))
 }
+
+func TestSimulateV1(t *testing.T) {
+	t.Parallel()
+	const genBlocks = 2
+	var (
+		accounts = newAccounts(3)
+		genesis  = &core.Genesis{
+			Config: params.TestFlareChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		// Returns the coinbase and the timestamp of the block.
+		blockInfoCode = hex2Bytes("416000524260205260406000f3")
+		// Emits a log with the caller as data.
+		logCode       = hex2Bytes("3360005260206000a000")
+		blockInfoAddr = common.HexToAddress("0x1234")
+		logAddr       = common.HexToAddress("0x5678")
+		coinbase      = common.HexToAddress("0xc0ffee")
+	)
+	api := NewBlockChainAPI(newTestBackend(t, genBlocks, genesis, dummy.NewCoinbaseFaker(), func(i int, b *core.BlockGen) {}))
+	base, err := api.b.HeaderByNumber(context.Background(), rpc.BlockNumber(genBlocks))
+	require.NoError(t, err)
+
+	blockInfo := func(coinbase common.Address, time uint64) hexutil.Bytes {
+		return append(common.LeftPadBytes(coinbase.Bytes(), 32), common.LeftPadBytes(new(big.Int).SetUint64(time).Bytes(), 32)...)
+	}
+	value := func(v *big.Int) *hexutil.Big { return (*hexutil.Big)(v) }
+	nonce := func(n uint64) *hexutil.Uint64 { return (*hexutil.Uint64)(&n) }
+	number := func(n uint64) *hexutil.Big { return (*hexutil.Big)(new(big.Int).SetUint64(n)) }
+	timestamp := func(t uint64) *hexutil.Uint64 { return (*hexutil.Uint64)(&t) }
+
+	type callRes struct {
+		ReturnValue hexutil.Bytes
+		Logs        int
+		Status      hexutil.Uint64
+	}
+	tests := []struct {
+		name          string
+		opts          simOpts
+		wantNumbers   []uint64
+		wantCalls     [][]callRes
+		wantErrorCode int
+	}{
+		{
+			name: "chained transfers",
+			opts: simOpts{BlockStateCalls: []simBlock{
+				{Calls: []TransactionArgs{{
+					From:  &accounts[0].addr,
+					To:    &accounts[1].addr,
+					Value: value(big.NewInt(1000)),
+				}}},
+				{Calls: []TransactionArgs{{
+					// Only possible with the transfer of the previous block.
+					From:  &accounts[1].addr,
+					To:    &accounts[2].addr,
+					Value: value(new(big.Int).Add(big.NewInt(params.Ether), big.NewInt(1000))),
+				}}},
+			}},
+			wantNumbers: []uint64{genBlocks + 1, genBlocks + 2},
+			wantCalls: [][]callRes{
+				{{Status: 1}},
+				{{Status: 1}},
+			},
+		},
+		{
+			name: "block overrides",
+			opts: simOpts{BlockStateCalls: []simBlock{
+				{
+					StateOverrides: &StateOverride{blockInfoAddr: OverrideAccount{Code: blockInfoCode}},
+					Calls:          []TransactionArgs{{From: &accounts[0].addr, To: &blockInfoAddr}},
+				},
+				{
+					BlockOverrides: &BlockOverrides{Coinbase: &coinbase, Time: timestamp(base.Time + 100)},
+					Calls:          []TransactionArgs{{From: &accounts[0].addr, To: &blockInfoAddr}},
+				},
+			}},
+			wantNumbers: []uint64{genBlocks + 1, genBlocks + 2},
+			wantCalls: [][]callRes{
+				{{ReturnValue: blockInfo(base.Coinbase, base.Time+1), Status: 1}},
+				{{ReturnValue: blockInfo(coinbase, base.Time+100), Status: 1}},
+			},
+		},
+		{
+			name: "logs",
+			opts: simOpts{BlockStateCalls: []simBlock{{
+				StateOverrides: &StateOverride{logAddr: OverrideAccount{Code: logCode}},
+				Calls: []TransactionArgs{
+					{From: &accounts[0].addr, To: &logAddr},
+					{From: &accounts[1].addr, To: &logAddr},
+				},
+			}}},
+			wantNumbers: []uint64{genBlocks + 1},
+			wantCalls: [][]callRes{{
+				{Logs: 1, Status: 1},
+				{Logs: 1, Status: 1},
+			}},
+		},
+		{
+			name: "gap filled with empty blocks",
+			opts: simOpts{BlockStateCalls: []simBlock{{
+				BlockOverrides: &BlockOverrides{Number: number(genBlocks + 3)},
+			}}},
+			wantNumbers: []uint64{genBlocks + 1, genBlocks + 2, genBlocks + 3},
+			wantCalls:   [][]callRes{{}, {}, {}},
+		},
+		{
+			name: "block numbers out of order",
+			opts: simOpts{BlockStateCalls: []simBlock{
+				{BlockOverrides: &BlockOverrides{Number: number(genBlocks + 2)}},
+				{BlockOverrides: &BlockOverrides{Number: number(genBlocks + 1)}},
+			}},
+			wantErrorCode: errCodeBlockNumberInvalid,
+		},
+		{
+			name: "validation",
+			opts: simOpts{
+				BlockStateCalls: []simBlock{{Calls: []TransactionArgs{{
+					From:  &accounts[0].addr,
+					To:    &accounts[1].addr,
+					Nonce: nonce(5),
+				}}}},
+				Validation: true,
+			},
+			wantErrorCode: errCodeNonceTooHigh,
+		},
+		{
+			name: "insufficient funds",
+			opts: simOpts{BlockStateCalls: []simBlock{{Calls: []TransactionArgs{{
+				From:  &accounts[2].addr,
+				To:    &accounts[1].addr,
+				Value: value(big.NewInt(1)),
+			}}}}},
+			wantErrorCode: errCodeInsufficientFunds,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+			results, err := api.SimulateV1(context.Background(), test.opts, &latest)
+			if test.wantErrorCode != 0 {
+				var rpcErr rpc.Error
+				require.ErrorAs(t, err, &rpcErr)
+				require.Equal(t, test.wantErrorCode, rpcErr.ErrorCode())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, results, len(test.wantNumbers))
+
+			for i, result := range results {
+				require.Equal(t, test.wantNumbers[i], result["number"].(*hexutil.Big).ToInt().Uint64())
+				calls := result["calls"].([]simCallResult)
+				require.Len(t, calls, len(test.wantCalls[i]))
+				for j, call := range calls {
+					require.Equal(t, test.wantCalls[i][j], callRes{
+						ReturnValue: call.ReturnValue,
+						Logs:        len(call.Logs),
+						Status:      call.Status,
+					})
+					for _, log := range call.Logs {
+						require.Equal(t, result["hash"], log.BlockHash)
+						require.Equal(t, uint(j), log.Index)
+						require.Equal(t, uint(j), log.TxIndex)
+					}
+				}
+			}
+		})
+	}
+}
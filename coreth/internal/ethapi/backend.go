@@ -83,6 +83,7 @@ type Backend interface {
 	BadBlocks() ([]*types.Block, []*core.BadBlockReason)
 	IsArchive() bool
 	HistoricalProofQueryWindow() uint64
+	HistoricalStateRegeneration() bool
 
 	// Transaction pool API
 	SendTx(ctx context.Context, signedTx *types.Transaction) error
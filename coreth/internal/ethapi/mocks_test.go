@@ -441,6 +441,20 @@ func (mr *MockBackendMockRecorder) HistoricalProofQueryWindow() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistoricalProofQueryWindow", reflect.TypeOf((*MockBackend)(nil).HistoricalProofQueryWindow))
 }
 
+// HistoricalStateRegeneration mocks base method.
+func (m *MockBackend) HistoricalStateRegeneration() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HistoricalStateRegeneration")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HistoricalStateRegeneration indicates an expected call of HistoricalStateRegeneration.
+func (mr *MockBackendMockRecorder) HistoricalStateRegeneration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistoricalStateRegeneration", reflect.TypeOf((*MockBackend)(nil).HistoricalStateRegeneration))
+}
+
 // IsArchive mocks base method.
 func (m *MockBackend) IsArchive() bool {
 	m.ctrl.T.Helper()
@@ -23,9 +23,15 @@ const (
 // UpgradeConfig includes the following configs that may be specified in upgradeBytes:
 // - Timestamps that enable avalanche network upgrades,
 // - Enabling or disabling precompiles as network upgrades.
+// - Changing the prioritised contract call rules.
 type UpgradeConfig struct {
 	// Config for enabling and disabling precompiles as network upgrades.
 	PrecompileUpgrades []PrecompileUpgrade `json:"precompileUpgrades,omitempty"`
+
+	// Schedule of prioritised contract call rules, extending the built-in
+	// schedule of the chain. Configs activating at or before the last built-in
+	// config must restate it unchanged.
+	PrioritisedContractUpgrades []PrioritisedContractConfig `json:"prioritisedContractUpgrades,omitempty"`
 }
 
 // AvalancheContext provides Avalanche specific context directly into the EVM.
@@ -179,6 +185,9 @@ func (c *ChainConfig) Verify() error {
 	if err := c.verifyPrecompileUpgrades(); err != nil {
 		return fmt.Errorf("invalid precompile upgrades: %w", err)
 	}
+	if err := c.verifyPrioritisedContractUpgrades(); err != nil {
+		return fmt.Errorf("invalid prioritised contract upgrades: %w", err)
+	}
 
 	return nil
 }
@@ -114,6 +114,21 @@ func (n *NetworkUpgrades) forkOrder() []fork {
 	}
 }
 
+// UpgradeTimestamp returns the activation timestamp of the network upgrade
+// configured by the json field [name], such as "durangoBlockTimestamp", and
+// whether [name] is a known network upgrade.
+func (n *NetworkUpgrades) UpgradeTimestamp(name string) (*uint64, bool) {
+	if name == "songbirdTransitionTimestamp" {
+		return n.SongbirdTransitionTimestamp, true
+	}
+	for _, fork := range n.forkOrder() {
+		if fork.name == name {
+			return fork.timestamp, true
+		}
+	}
+	return nil, false
+}
+
 // IsApricotPhase1 returns whether [time] represents a block
 // with a timestamp after the Apricot Phase 1 upgrade time.
 func (n *NetworkUpgrades) IsApricotPhase1(time uint64) bool {
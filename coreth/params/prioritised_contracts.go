@@ -0,0 +1,212 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package params
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ava-labs/coreth/utils"
+)
+
+var (
+	//go:embed prioritised_contracts.json
+	rawPrioritisedContractSchedules []byte
+	// prioritisedContractSchedules holds the built-in prioritised contract
+	// schedule of each known chain, keyed by chain ID.
+	prioritisedContractSchedules map[string][]PrioritisedContractConfig
+
+	errPrioritisedTimestampNil     = errors.New("block timestamp cannot be nil")
+	errPrioritisedMaxGasLimit      = errors.New("max gas limit cannot be zero")
+	errPrioritisedCallDataCap      = errors.New("call data cap cannot be zero")
+	errPrioritisedPrefixInvalid    = errors.New("data prefix must be exactly 4 bytes")
+	errPrioritisedHistoricalChange = errors.New("cannot change the built-in prioritised contract schedule")
+)
+
+func init() {
+	if err := json.Unmarshal(rawPrioritisedContractSchedules, &prioritisedContractSchedules); err != nil {
+		panic(err)
+	}
+	rawPrioritisedContractSchedules = nil
+	for chainID, schedule := range prioritisedContractSchedules {
+		if err := verifyPrioritisedContractSchedule(schedule); err != nil {
+			panic(fmt.Sprintf("invalid prioritised contract schedule for chain %s: %v", chainID, err))
+		}
+	}
+}
+
+// DataPrefix is the 4-byte method selector a prioritised call must start with.
+type DataPrefix [4]byte
+
+// MarshalText implements encoding.TextMarshaler.
+func (p DataPrefix) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(p[:]).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *DataPrefix) UnmarshalText(input []byte) error {
+	var b hexutil.Bytes
+	if err := b.UnmarshalText(input); err != nil {
+		return err
+	}
+	if len(b) != len(p) {
+		return fmt.Errorf("%w: got %d", errPrioritisedPrefixInvalid, len(b))
+	}
+	copy(p[:], b)
+	return nil
+}
+
+// PrioritisedContractConfig describes which calls to the FTSO and submitter
+// contracts are charged a nominal fee instead of their full gas cost.
+// A config takes effect at [BlockTimestamp] and stays in effect until the
+// next config of the schedule activates.
+type PrioritisedContractConfig struct {
+	BlockTimestamp *uint64 `json:"blockTimestamp"`
+
+	FTSOAddress             common.Address `json:"ftsoAddress"`
+	SubmitterAddress        common.Address `json:"submitterAddress"`
+	SubmitterActivationTime uint64         `json:"submitterActivationTime"`
+
+	// MaxGasLimit is the largest gas limit a call may have to be prioritised.
+	MaxGasLimit uint64 `json:"maxGasLimit"`
+	// CallDataCap is the largest submitter call data length, in bytes, that
+	// is prioritised once data prefixes are enforced.
+	CallDataCap uint64 `json:"callDataCap"`
+
+	DataPrefixActivationTime uint64       `json:"dataPrefixActivationTime"`
+	SubmitterDataPrefixes    []DataPrefix `json:"submitterDataPrefixes"`
+	FTSODataPrefixes         []DataPrefix `json:"ftsoDataPrefixes"`
+}
+
+// Verify checks [p] is well formed.
+func (p *PrioritisedContractConfig) Verify() error {
+	switch {
+	case p.BlockTimestamp == nil:
+		return errPrioritisedTimestampNil
+	case p.MaxGasLimit == 0:
+		return errPrioritisedMaxGasLimit
+	case p.CallDataCap == 0:
+		return errPrioritisedCallDataCap
+	}
+	return nil
+}
+
+// Equal returns true if [p] and [other] are the same config. Nil and empty
+// data prefix lists are equal.
+func (p *PrioritisedContractConfig) Equal(other *PrioritisedContractConfig) bool {
+	return utils.Uint64PtrEqual(p.BlockTimestamp, other.BlockTimestamp) &&
+		p.FTSOAddress == other.FTSOAddress &&
+		p.SubmitterAddress == other.SubmitterAddress &&
+		p.SubmitterActivationTime == other.SubmitterActivationTime &&
+		p.MaxGasLimit == other.MaxGasLimit &&
+		p.CallDataCap == other.CallDataCap &&
+		p.DataPrefixActivationTime == other.DataPrefixActivationTime &&
+		slices.Equal(p.SubmitterDataPrefixes, other.SubmitterDataPrefixes) &&
+		slices.Equal(p.FTSODataPrefixes, other.FTSODataPrefixes)
+}
+
+// verifyPrioritisedContractSchedule checks every config of [schedule] is well
+// formed and that block timestamps are strictly increasing.
+func verifyPrioritisedContractSchedule(schedule []PrioritisedContractConfig) error {
+	var previousTimestamp *uint64
+	for i := range schedule {
+		config := &schedule[i]
+		if err := config.Verify(); err != nil {
+			return fmt.Errorf("PrioritisedContractUpgrade at [%d]: %w", i, err)
+		}
+		if previousTimestamp != nil && *config.BlockTimestamp <= *previousTimestamp {
+			return fmt.Errorf("PrioritisedContractUpgrade at [%d]: block timestamp (%d) <= previous timestamp (%d)", i, *config.BlockTimestamp, *previousTimestamp)
+		}
+		previousTimestamp = config.BlockTimestamp
+	}
+	return nil
+}
+
+// PrioritisedContractSchedule returns the prioritised contract configs of the
+// chain, ordered by activation time: the built-in schedule of the chain,
+// followed by the configs of the upgrade bytes activating after it.
+func (c *ChainConfig) PrioritisedContractSchedule() []PrioritisedContractConfig {
+	builtin := c.builtinPrioritisedContractSchedule()
+	upgrades := c.prioritisedContractUpgradesAfter(builtin)
+	if len(upgrades) == 0 {
+		return builtin
+	}
+	schedule := make([]PrioritisedContractConfig, 0, len(builtin)+len(upgrades))
+	schedule = append(schedule, builtin...)
+	return append(schedule, upgrades...)
+}
+
+// GetPrioritisedContractConfig returns the prioritised contract config in
+// effect at [timestamp], or nil if calls are never prioritised at that time.
+func (c *ChainConfig) GetPrioritisedContractConfig(timestamp uint64) *PrioritisedContractConfig {
+	builtin := c.builtinPrioritisedContractSchedule()
+	for _, schedule := range [][]PrioritisedContractConfig{c.prioritisedContractUpgradesAfter(builtin), builtin} {
+		for i := len(schedule) - 1; i >= 0; i-- {
+			if isTimestampForked(schedule[i].BlockTimestamp, timestamp) {
+				return &schedule[i]
+			}
+		}
+	}
+	return nil
+}
+
+// builtinPrioritisedContractSchedule returns the built-in prioritised contract
+// schedule of the chain. The returned configs must not be modified.
+func (c *ChainConfig) builtinPrioritisedContractSchedule() []PrioritisedContractConfig {
+	if c.ChainID == nil {
+		return nil
+	}
+	return prioritisedContractSchedules[c.ChainID.String()]
+}
+
+// prioritisedContractUpgradesAfter returns the configs of
+// [c.PrioritisedContractUpgrades] activating after the last config of [builtin].
+// The earlier configs restate [builtin], as checked by
+// [ChainConfig.verifyPrioritisedContractUpgrades].
+func (c *ChainConfig) prioritisedContractUpgradesAfter(builtin []PrioritisedContractConfig) []PrioritisedContractConfig {
+	if len(builtin) == 0 {
+		return c.PrioritisedContractUpgrades
+	}
+	last := *builtin[len(builtin)-1].BlockTimestamp
+	for i := range c.PrioritisedContractUpgrades {
+		if *c.PrioritisedContractUpgrades[i].BlockTimestamp > last {
+			return c.PrioritisedContractUpgrades[i:]
+		}
+	}
+	return nil
+}
+
+// verifyPrioritisedContractUpgrades checks [c.PrioritisedContractUpgrades] is
+// well formed and only extends the built-in schedule of the chain: a config
+// activating at or before the last built-in config must equal the built-in
+// config activating at the same time.
+func (c *ChainConfig) verifyPrioritisedContractUpgrades() error {
+	if err := verifyPrioritisedContractSchedule(c.PrioritisedContractUpgrades); err != nil {
+		return err
+	}
+	builtin := c.builtinPrioritisedContractSchedule()
+	if len(builtin) == 0 {
+		return nil
+	}
+	last := *builtin[len(builtin)-1].BlockTimestamp
+	for i := range c.PrioritisedContractUpgrades {
+		config := &c.PrioritisedContractUpgrades[i]
+		if *config.BlockTimestamp > last {
+			break
+		}
+		j := slices.IndexFunc(builtin, func(b PrioritisedContractConfig) bool {
+			return *b.BlockTimestamp == *config.BlockTimestamp
+		})
+		if j < 0 || !config.Equal(&builtin[j]) {
+			return fmt.Errorf("PrioritisedContractUpgrade at [%d]: %w (timestamp %d)", i, errPrioritisedHistoricalChange, *config.BlockTimestamp)
+		}
+	}
+	return nil
+}
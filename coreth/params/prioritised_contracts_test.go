@@ -0,0 +1,157 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package params
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinPrioritisedContractSchedules(t *testing.T) {
+	for _, chainID := range []string{"14", "114", "19", "16", "162", "4294967295"} {
+		require.Contains(t, prioritisedContractSchedules, chainID)
+	}
+
+	flare := &ChainConfig{ChainID: FlareChainID}
+	config := flare.GetPrioritisedContractConfig(0)
+	require.NotNil(t, config)
+	require.Equal(t, uint64(3000000), config.MaxGasLimit)
+	require.Equal(t, uint64(4500), config.CallDataCap)
+	require.Equal(t, common.HexToAddress("0x2cA6571Daa15ce734Bbd0Bf27D5C9D16787fc33f"), config.SubmitterAddress)
+	require.Equal(t, []DataPrefix{{0x8f, 0xc6, 0xf6, 0x67}, {0xe2, 0xdb, 0x5a, 0x52}}, config.FTSODataPrefixes)
+
+	songbird := &ChainConfig{ChainID: SongbirdChainID}
+	require.Equal(t, uint64(math.MaxUint64), songbird.GetPrioritisedContractConfig(0).MaxGasLimit)
+
+	unknown := &ChainConfig{ChainID: AvalancheMainnetChainID}
+	require.Nil(t, unknown.GetPrioritisedContractConfig(0))
+}
+
+func TestPrioritisedContractUpgrades(t *testing.T) {
+	upgradeBytes := []byte(`{
+		"prioritisedContractUpgrades": [
+			{
+				"blockTimestamp": 0,
+				"ftsoAddress": "0x1000000000000000000000000000000000000003",
+				"submitterAddress": "0x00000000000000000000000000000000000000aa",
+				"maxGasLimit": 1000,
+				"callDataCap": 100
+			},
+			{
+				"blockTimestamp": 200,
+				"ftsoAddress": "0x1000000000000000000000000000000000000003",
+				"submitterAddress": "0x00000000000000000000000000000000000000bb",
+				"maxGasLimit": 2000,
+				"callDataCap": 100,
+				"submitterDataPrefixes": ["0x01020304"]
+			}
+		]
+	}`)
+	var upgradeConfig UpgradeConfig
+	require.NoError(t, json.Unmarshal(upgradeBytes, &upgradeConfig))
+
+	config := &ChainConfig{ChainID: AvalancheMainnetChainID, UpgradeConfig: upgradeConfig}
+	require.NoError(t, config.Verify())
+
+	require.Equal(t, common.HexToAddress("0xaa"), config.GetPrioritisedContractConfig(199).SubmitterAddress)
+	active := config.GetPrioritisedContractConfig(200)
+	require.Equal(t, common.HexToAddress("0xbb"), active.SubmitterAddress)
+	require.Equal(t, []DataPrefix{{0x01, 0x02, 0x03, 0x04}}, active.SubmitterDataPrefixes)
+}
+
+func TestPrioritisedContractUpgradesExtendBuiltin(t *testing.T) {
+	builtin := prioritisedContractSchedules[FlareChainID.String()]
+	upgrade := builtin[len(builtin)-1]
+	upgrade.BlockTimestamp = utils.NewUint64(*upgrade.BlockTimestamp + 100)
+	upgrade.MaxGasLimit++
+
+	// Upgrades activating after the built-in schedule extend it.
+	config := &ChainConfig{ChainID: FlareChainID, UpgradeConfig: UpgradeConfig{
+		PrioritisedContractUpgrades: []PrioritisedContractConfig{upgrade},
+	}}
+	require.NoError(t, config.Verify())
+	require.Equal(t, append(slices.Clone(builtin), upgrade), config.PrioritisedContractSchedule())
+	require.Equal(t, &builtin[len(builtin)-1], config.GetPrioritisedContractConfig(*upgrade.BlockTimestamp-1))
+	require.Equal(t, upgrade, *config.GetPrioritisedContractConfig(*upgrade.BlockTimestamp))
+
+	// The built-in schedule may be restated unchanged.
+	config.PrioritisedContractUpgrades = append(slices.Clone(builtin), upgrade)
+	require.NoError(t, config.Verify())
+	require.Equal(t, append(slices.Clone(builtin), upgrade), config.PrioritisedContractSchedule())
+
+	// The built-in schedule cannot be changed.
+	changed := builtin[len(builtin)-1]
+	changed.MaxGasLimit++
+	config.PrioritisedContractUpgrades = []PrioritisedContractConfig{changed, upgrade}
+	require.ErrorIs(t, config.Verify(), errPrioritisedHistoricalChange)
+}
+
+func TestPrioritisedContractUpgradesRestateBuiltin(t *testing.T) {
+	builtin := prioritisedContractSchedules[LocalFlareChainID.String()]
+	require.Len(t, builtin, 1)
+	require.NotNil(t, builtin[0].SubmitterDataPrefixes)
+
+	// Nil data prefixes restate the empty built-in ones.
+	restated := builtin[0]
+	restated.SubmitterDataPrefixes = nil
+	restated.FTSODataPrefixes = nil
+	config := &ChainConfig{ChainID: LocalFlareChainID, UpgradeConfig: UpgradeConfig{
+		PrioritisedContractUpgrades: []PrioritisedContractConfig{restated},
+	}}
+	require.NoError(t, config.verifyPrioritisedContractUpgrades())
+
+	restated.SubmitterDataPrefixes = []DataPrefix{{0x01, 0x02, 0x03, 0x04}}
+	config.PrioritisedContractUpgrades = []PrioritisedContractConfig{restated}
+	require.ErrorIs(t, config.verifyPrioritisedContractUpgrades(), errPrioritisedHistoricalChange)
+}
+
+func TestVerifyPrioritisedContractUpgrades(t *testing.T) {
+	valid := PrioritisedContractConfig{
+		BlockTimestamp: utils.NewUint64(10),
+		MaxGasLimit:    1,
+		CallDataCap:    1,
+	}
+	tests := map[string]struct {
+		upgrades    []PrioritisedContractConfig
+		expectedErr error
+	}{
+		"valid": {
+			upgrades: []PrioritisedContractConfig{valid},
+		},
+		"nil timestamp": {
+			upgrades:    []PrioritisedContractConfig{{MaxGasLimit: 1, CallDataCap: 1}},
+			expectedErr: errPrioritisedTimestampNil,
+		},
+		"zero max gas limit": {
+			upgrades:    []PrioritisedContractConfig{{BlockTimestamp: utils.NewUint64(10), CallDataCap: 1}},
+			expectedErr: errPrioritisedMaxGasLimit,
+		},
+		"zero call data cap": {
+			upgrades:    []PrioritisedContractConfig{{BlockTimestamp: utils.NewUint64(10), MaxGasLimit: 1}},
+			expectedErr: errPrioritisedCallDataCap,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := &ChainConfig{UpgradeConfig: UpgradeConfig{PrioritisedContractUpgrades: test.upgrades}}
+			require.ErrorIs(t, config.verifyPrioritisedContractUpgrades(), test.expectedErr)
+		})
+	}
+
+	config := &ChainConfig{UpgradeConfig: UpgradeConfig{PrioritisedContractUpgrades: []PrioritisedContractConfig{valid, valid}}}
+	require.ErrorContains(t, config.verifyPrioritisedContractUpgrades(), "<= previous timestamp")
+}
+
+func TestDataPrefixUnmarshal(t *testing.T) {
+	var prefix DataPrefix
+	require.NoError(t, json.Unmarshal([]byte(`"0xe1b157e7"`), &prefix))
+	require.Equal(t, DataPrefix{0xe1, 0xb1, 0x57, 0xe7}, prefix)
+	require.ErrorIs(t, json.Unmarshal([]byte(`"0xe1b157"`), &prefix), errPrioritisedPrefixInvalid)
+}
@@ -247,7 +247,11 @@ func (n *network) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID u
 	defer cancel()
 
 	responseBytes, err := req.Handle(handleCtx, nodeID, requestID, n.appRequestHandler)
+	var throttledErr *ThrottledError
 	switch {
+	case errors.As(err, &throttledErr):
+		appErr := throttledErr.appError()
+		return n.appSender.SendAppError(ctx, nodeID, requestID, appErr.Code, appErr.Message) // Propagate fatal error
 	case err != nil && err != context.DeadlineExceeded:
 		return err // Return a fatal error
 	case responseBytes != nil:
@@ -280,6 +284,7 @@ func (n *network) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID
 // - node is benched
 // - failed to send message to [nodeID] due to a network issue
 // - request times out before a response is provided
+// - [nodeID] responded with [appErr], such as when throttling the request
 // error returned by this function is expected to be treated as fatal by the engine
 // returns error only when the response handler returns an error
 func (n *network) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32, appErr *common.AppError) error {
@@ -294,7 +299,10 @@ func (n *network) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, reque
 	// We must release the slot
 	n.activeAppRequests.Release(1)
 
-	return handler.OnFailure()
+	if appErr == nil {
+		return handler.OnFailure(nil)
+	}
+	return handler.OnFailure(appErr)
 }
 
 // calculateTimeUntilDeadline calculates the time until deadline and drops it if we missed he deadline to response.
@@ -387,7 +395,7 @@ func (n *network) Shutdown() {
 
 	// clean up any pending requests
 	for requestID, handler := range n.outstandingRequestHandlers {
-		_ = handler.OnFailure() // make sure all waiting threads are unblocked
+		_ = handler.OnFailure(nil) // make sure all waiting threads are unblocked
 		delete(n.outstandingRequestHandlers, requestID)
 	}
 
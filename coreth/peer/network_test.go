@@ -568,6 +568,54 @@ func TestNetworkPropagatesRequestHandlerError(t *testing.T) {
 	assert.Error(t, clientNetwork.AppRequest(context.Background(), nodeID, requestID, time.Now().Add(time.Second), requestMessage))
 }
 
+func TestNetworkThrottledRequest(t *testing.T) {
+	require := require.New(t)
+
+	codecManager := buildCodec(t, TestMessage{})
+	nodeID := ids.GenerateTestNodeID()
+	var net Network
+	sender := &enginetest.Sender{
+		SendAppRequestF: func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, requestBytes []byte) error {
+			go func() {
+				if err := net.AppRequest(context.Background(), nodeID, requestID, time.Now().Add(time.Second), requestBytes); err != nil {
+					panic(err)
+				}
+			}()
+			return nil
+		},
+		SendAppErrorF: func(_ context.Context, nodeID ids.NodeID, requestID uint32, errorCode int32, errorMessage string) error {
+			go func() {
+				if err := net.AppRequestFailed(context.Background(), nodeID, requestID, &common.AppError{Code: errorCode, Message: errorMessage}); err != nil {
+					panic(err)
+				}
+			}()
+			return nil
+		},
+	}
+
+	p2pNetwork, err := p2p.NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	net = NewNetwork(p2pNetwork, sender, codecManager, ids.EmptyNodeID, 1)
+	net.SetRequestHandler(&testRequestHandler{err: &ThrottledError{RetryAfter: 1500 * time.Millisecond}})
+	require.NoError(net.Connected(context.Background(), nodeID, defaultPeerVersion))
+	defer net.Shutdown()
+
+	// The throttled request fails with the backoff sent by the peer.
+	requestBytes, err := marshalStruct(codecManager, TestMessage{Message: "Hello"})
+	require.NoError(err)
+	_, err = NewNetworkClient(net).SendAppRequest(context.Background(), nodeID, requestBytes)
+	require.ErrorIs(err, ErrRequestFailed)
+	var throttledErr *ThrottledError
+	require.ErrorAs(err, &throttledErr)
+	require.Equal(1500*time.Millisecond, throttledErr.RetryAfter)
+
+	// Other app errors are not mistaken for throttling.
+	_, ok := parseThrottledError(common.ErrTimeout)
+	require.False(ok)
+	_, ok = parseThrottledError(p2p.ErrThrottled)
+	require.False(ok)
+}
+
 func TestNetworkAppRequestAfterShutdown(t *testing.T) {
 	require := require.New(t)
 
@@ -0,0 +1,50 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+const throttledMessageFormat = "throttled, retry after %dms"
+
+var _ error = (*ThrottledError)(nil)
+
+// ThrottledError is returned by request handlers to reject the request of a
+// peer that exceeded its budget. It is sent to the peer as [p2p.ErrThrottled],
+// along with the time the peer should wait for before retrying, and returned
+// to the peer by [NetworkClient] wrapped in [ErrRequestFailed].
+type ThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ThrottledError) Error() string {
+	return fmt.Sprintf("throttled, retry after %s", e.RetryAfter)
+}
+
+// appError returns the AppError sent to the throttled peer.
+func (e *ThrottledError) appError() *common.AppError {
+	return &common.AppError{
+		Code:    p2p.ErrThrottled.Code,
+		Message: fmt.Sprintf(throttledMessageFormat, e.RetryAfter.Milliseconds()),
+	}
+}
+
+// parseThrottledError returns the ThrottledError a peer sent as [err], if any.
+func parseThrottledError(err error) (*ThrottledError, bool) {
+	var appErr *common.AppError
+	if !errors.As(err, &appErr) || appErr.Code != p2p.ErrThrottled.Code {
+		return nil, false
+	}
+	var retryAfterMs int64
+	if _, err := fmt.Sscanf(appErr.Message, throttledMessageFormat, &retryAfterMs); err != nil || retryAfterMs < 0 {
+		return nil, false
+	}
+	return &ThrottledError{RetryAfter: time.Duration(retryAfterMs) * time.Millisecond}, true
+}
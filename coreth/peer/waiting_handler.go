@@ -5,6 +5,7 @@ package peer
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ava-labs/coreth/plugin/evm/message"
 )
@@ -18,6 +19,7 @@ var _ message.ResponseHandler = &waitingResponseHandler{}
 type waitingResponseHandler struct {
 	responseChan chan []byte // blocking channel with response bytes
 	failed       bool        // whether the original request is failed
+	err          error       // the error the peer responded with, if any
 }
 
 // newWaitingResponseHandler returns new instance of the waitingResponseHandler
@@ -38,8 +40,9 @@ func (w *waitingResponseHandler) OnResponse(response []byte) error {
 }
 
 // OnFailure sets the failed flag to true and closes the channel
-func (w *waitingResponseHandler) OnFailure() error {
+func (w *waitingResponseHandler) OnFailure(err error) error {
 	w.failed = true
+	w.err = err
 	close(w.responseChan)
 	return nil
 }
@@ -50,6 +53,9 @@ func (waitingHandler *waitingResponseHandler) WaitForResult(ctx context.Context)
 		return nil, ctx.Err()
 	case response := <-waitingHandler.responseChan:
 		if waitingHandler.failed {
+			if throttledErr, ok := parseThrottledError(waitingHandler.err); ok {
+				return nil, fmt.Errorf("%w: %w", ErrRequestFailed, throttledErr)
+			}
 			return nil, ErrRequestFailed
 		}
 		return response, nil
@@ -4,15 +4,19 @@
 package evm
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/coreth/plugin/evm/client"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+var errNoStateSyncFilePath = errors.New("path must be specified")
+
 // Admin is the API service for admin API calls
 type Admin struct {
 	vm       *VM
@@ -82,3 +86,24 @@ func (p *Admin) GetVMConfig(_ *http.Request, _ *struct{}, reply *client.ConfigRe
 	reply.Config = &p.vm.config
 	return nil
 }
+
+// ExportStateSyncFile writes the state sync file of a state summary, which
+// other nodes can state sync from with the state-sync-file and
+// state-sync-file-summary-id config options
+func (p *Admin) ExportStateSyncFile(r *http.Request, args *client.ExportStateSyncFileArgs, reply *client.ExportStateSyncFileReply) error {
+	log.Info("Admin: ExportStateSyncFile called", "path", args.Path, "height", args.Height)
+
+	if len(args.Path) == 0 {
+		return errNoStateSyncFilePath
+	}
+	summary, err := p.vm.exportStateSyncFile(r.Context(), args.Path, (*uint64)(args.Height))
+	if err != nil {
+		return err
+	}
+	reply.SummaryID = summary.ID()
+	reply.Height = json.Uint64(summary.BlockNumber)
+	reply.BlockHash = summary.BlockHash
+	reply.BlockRoot = summary.BlockRoot
+	reply.AtomicRoot = summary.AtomicRoot
+	return nil
+}
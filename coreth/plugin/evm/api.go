@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"slices"
 
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/ids"
@@ -29,12 +30,16 @@ const (
 
 	// Max number of addresses that can be passed in as argument to GetUTXOs
 	maxGetUTXOsAddrs = 1024
+
+	// Max number of txs returned by GetAtomicTxsByAddress
+	maxAtomicTxsByAddressToFetch = 1024
 )
 
 var (
-	errNoAddresses   = errors.New("no addresses provided")
-	errNoSourceChain = errors.New("no source chain provided")
-	errNilTxID       = errors.New("nil transaction ID")
+	errNoAddresses         = errors.New("no addresses provided")
+	errNoSourceChain       = errors.New("no source chain provided")
+	errNilTxID             = errors.New("nil transaction ID")
+	errNoAtomicProofTarget = errors.New("either a transaction ID or a height must be provided")
 
 	initialBaseFee = big.NewInt(ap3.InitialBaseFee)
 )
@@ -219,6 +224,153 @@ func (service *AvaxAPI) GetAtomicTxStatus(r *http.Request, args *api.JSONTxID, r
 	return nil
 }
 
+// GetAtomicTxsByAddress returns the accepted atomic txs the address took part
+// in, in ascending order of block height and txID, with their statuses. The
+// address is either a bech32 address of any chain, matching the owners of
+// exported UTXOs and the signers of imported UTXOs, or a hex EVM address,
+// matching the inputs of exports and the outputs of imports.
+func (service *AvaxAPI) GetAtomicTxsByAddress(r *http.Request, args *client.GetAtomicTxsByAddressArgs, reply *client.GetAtomicTxsByAddressReply) error {
+	log.Info("EVM: GetAtomicTxsByAddress called", "address", args.Address)
+
+	if args.Address == "" {
+		return errNoAddresses
+	}
+	var addr ids.ShortID
+	if common.IsHexAddress(args.Address) {
+		addr = ids.ShortID(common.HexToAddress(args.Address))
+	} else {
+		var err error
+		addr, err = ids.ShortFromString(args.Address)
+		if err != nil {
+			_, addr, err = service.vm.ParseAddress(args.Address)
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+		}
+	}
+	limit := int(args.Limit)
+	if limit <= 0 || limit > maxAtomicTxsByAddressToFetch {
+		limit = maxAtomicTxsByAddressToFetch
+	}
+	var (
+		startHeight uint64
+		startTxID   ids.ID
+	)
+	if args.StartIndex != nil {
+		startHeight, startTxID = uint64(args.StartIndex.BlockHeight), args.StartIndex.TxID
+	}
+
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	txs, err := service.vm.atomicTxRepository.GetByAddress(addr, startHeight, startTxID, limit+1)
+	if err != nil {
+		return err
+	}
+	if len(txs) > limit {
+		reply.EndIndex = &client.AtomicTxIndex{
+			BlockHeight: json.Uint64(txs[limit].Height),
+			TxID:        txs[limit].TxID,
+		}
+		txs = txs[:limit]
+	}
+
+	// Since chain state updates run asynchronously with VM block acceptance,
+	// txs above the last accepted block of the chain state are reported as
+	// [Processing].
+	lastAccepted := service.vm.blockChain.LastAcceptedBlock().NumberU64()
+	reply.Txs = make([]client.AddressAtomicTx, len(txs))
+	for i, tx := range txs {
+		status := atomic.Accepted
+		if tx.Height > lastAccepted {
+			status = atomic.Processing
+		}
+		reply.Txs[i] = client.AddressAtomicTx{
+			TxID:        tx.TxID,
+			BlockHeight: json.Uint64(tx.Height),
+			Status:      status,
+		}
+	}
+	reply.NumFetched = json.Uint64(len(txs))
+	return nil
+}
+
+// GetAtomicProof returns a Merkle proof of the atomic operations accepted at
+// the height of the given tx, or at the given height, against the atomic trie
+// root committed at the commit height. The commit height defaults to the first
+// commit of the atomic trie including the operations.
+func (service *AvaxAPI) GetAtomicProof(r *http.Request, args *client.GetAtomicProofArgs, reply *client.GetAtomicProofReply) error {
+	log.Info("EVM: GetAtomicProof called", "txID", args.TxID, "height", args.Height)
+
+	if (args.TxID == ids.Empty) == (args.Height == nil) {
+		return errNoAtomicProofTarget
+	}
+
+	service.vm.ctx.Lock.Lock()
+	defer service.vm.ctx.Lock.Unlock()
+
+	var height uint64
+	if args.TxID != ids.Empty {
+		_, status, txHeight, err := service.vm.getAtomicTx(args.TxID)
+		if err != nil {
+			return err
+		}
+		if status != atomic.Accepted {
+			return fmt.Errorf("tx %s is not accepted, status %s", args.TxID, status)
+		}
+		height = txHeight
+	} else {
+		height = uint64(*args.Height)
+	}
+
+	commitHeight := atomicCommitHeight(height, service.vm.config.CommitInterval)
+	if args.CommitHeight != nil {
+		commitHeight = uint64(*args.CommitHeight)
+	}
+	if commitHeight < height {
+		return fmt.Errorf("atomic operations at height %d can't be committed at height %d", height, commitHeight)
+	}
+	root, err := service.vm.atomicTrie.Root(commitHeight)
+	if err != nil {
+		return err
+	}
+	if root == (common.Hash{}) {
+		_, lastCommittedHeight := service.vm.atomicTrie.LastCommitted()
+		return fmt.Errorf("atomic trie is not committed at height %d, last committed height %d", commitHeight, lastCommittedHeight)
+	}
+
+	ops, proof, err := proveAtomicOps(service.vm.atomicTrie, root, height)
+	if err != nil {
+		return err
+	}
+	reply.Ops = make([]client.AtomicOps, 0, len(ops))
+	for blockchainID, requests := range ops {
+		requestsStr, err := formatting.Encode(args.Encoding, requests)
+		if err != nil {
+			return err
+		}
+		reply.Ops = append(reply.Ops, client.AtomicOps{
+			BlockchainID: blockchainID,
+			Requests:     requestsStr,
+		})
+	}
+	slices.SortFunc(reply.Ops, func(a, b client.AtomicOps) int {
+		return a.BlockchainID.Compare(b.BlockchainID)
+	})
+	reply.Proof = make([]string, len(proof))
+	for i, node := range proof {
+		reply.Proof[i], err = formatting.Encode(args.Encoding, node)
+		if err != nil {
+			return err
+		}
+	}
+	reply.Height = json.Uint64(height)
+	reply.CommitHeight = json.Uint64(commitHeight)
+	reply.Root = root
+	reply.Encoding = args.Encoding
+	return nil
+}
+
 type FormattedTx struct {
 	api.FormattedTx
 	BlockHeight *json.Uint64 `json:"blockHeight,omitempty"`
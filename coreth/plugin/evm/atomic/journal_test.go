@@ -0,0 +1,108 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package atomic
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// newJournalTestTx returns an import tx of the UTXO [utxoID] on [networkID],
+// which burns [amount].
+func newJournalTestTx(t *testing.T, networkID uint32, utxoID ids.ID, amount uint64) *Tx {
+	tx := &Tx{UnsignedAtomicTx: &UnsignedImportTx{
+		NetworkID: networkID,
+		ImportedInputs: []*avax.TransferableInput{{
+			UTXOID: avax.UTXOID{TxID: utxoID},
+			In: &secp256k1fx.TransferInput{
+				Amt:   amount,
+				Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+		}},
+	}}
+	require.NoError(t, tx.Sign(Codec, nil))
+	return tx
+}
+
+func TestMempoolJournal(t *testing.T) {
+	require := require.New(t)
+	path := filepath.Join(t.TempDir(), "atomic_transactions.rlp")
+
+	var (
+		utxoID    = ids.GenerateTestID()
+		accepted  = newJournalTestTx(t, 1, ids.GenerateTestID(), 1_000_000)
+		pending   = newJournalTestTx(t, 1, utxoID, 1_000_000)
+		discarded = newJournalTestTx(t, 1, ids.GenerateTestID(), 1_000_000)
+		remote    = newJournalTestTx(t, 1, ids.GenerateTestID(), 1_000_000)
+	)
+	m, err := NewMempool(&snow.Context{}, prometheus.NewRegistry(), 10, nil)
+	require.NoError(err)
+	require.Empty(m.LoadJournal(path))
+
+	require.NoError(m.AddLocalTx(accepted))
+	require.NoError(m.AddLocalTx(pending))
+	require.NoError(m.AddLocalTx(discarded))
+	require.NoError(m.AddRemoteTx(remote))
+
+	// Locally issued txs are journaled as they are added, and re-verified when
+	// loaded after a restart.
+	errAccepted := errors.New("utxo already consumed")
+	verify := func(tx *Tx) error {
+		if tx.ID() == accepted.ID() {
+			return errAccepted
+		}
+		return nil
+	}
+	restarted, err := NewMempool(&snow.Context{}, prometheus.NewRegistry(), 10, verify)
+	require.NoError(err)
+	require.ElementsMatch([]ids.ID{pending.ID(), discarded.ID()}, txIDs(restarted.LoadJournal(path)))
+	require.True(restarted.Has(pending.ID()))
+	require.False(restarted.Has(accepted.ID()))
+	require.False(restarted.Has(remote.ID()))
+	require.NoError(restarted.CloseJournal())
+
+	// Rejournaling drops the locally issued txs no longer in the mempool.
+	for {
+		tx, ok := m.NextTx()
+		if !ok {
+			break
+		}
+		if tx.ID() == discarded.ID() {
+			m.DiscardCurrentTx(tx.ID())
+		}
+	}
+	m.IssueCurrentTxs()
+	m.RemoveTx(accepted)
+	require.NoError(m.Rejournal())
+	require.NoError(m.CloseJournal())
+
+	restarted, err = NewMempool(&snow.Context{}, prometheus.NewRegistry(), 10, nil)
+	require.NoError(err)
+	require.Equal([]ids.ID{pending.ID()}, txIDs(restarted.LoadJournal(path)))
+	require.NoError(restarted.CloseJournal())
+
+	// Loaded txs are subject to the conflict checks of the mempool.
+	conflict := newJournalTestTx(t, 2, utxoID, 1_000_000)
+	restarted, err = NewMempool(&snow.Context{}, prometheus.NewRegistry(), 10, nil)
+	require.NoError(err)
+	require.NoError(restarted.AddRemoteTx(conflict))
+	require.Empty(restarted.LoadJournal(path))
+	require.NoError(restarted.CloseJournal())
+}
+
+func txIDs(txs []*Tx) []ids.ID {
+	txIDs := make([]ids.ID, len(txs))
+	for i, tx := range txs {
+		txIDs[i] = tx.ID()
+	}
+	return txIDs
+}
@@ -82,6 +82,12 @@ type Mempool struct {
 	metrics *mempoolMetrics
 
 	verify func(tx *Tx) error
+
+	// journal persists the transactions in [locals] so they survive node
+	// restarts. It is nil unless enabled with LoadJournal.
+	journal *journal
+	// locals is the set of locally issued transactions to be journaled
+	locals map[ids.ID]*Tx
 }
 
 // NewMempool returns a Mempool with [maxSize]
@@ -180,8 +186,12 @@ func (m *Mempool) AddLocalTx(tx *Tx) error {
 	if errors.Is(err, errTxAlreadyKnown) {
 		return nil
 	}
+	if err != nil {
+		return err
+	}
 
-	return err
+	m.journalTx(tx)
+	return nil
 }
 
 // ForceAddTx forcibly adds a *Tx to the mempool and bypasses all verification.
@@ -572,6 +582,100 @@ func (m *Mempool) RemoveTx(tx *Tx) {
 	m.removeTx(tx, false)
 }
 
+// LoadJournal loads the locally issued transactions journaled at [path] into
+// the mempool, subjecting them to the same verification and conflict checks
+// as newly issued ones, and journals locally issued transactions to [path]
+// from then on. It returns the transactions that were added to the mempool,
+// which need to be gossiped again.
+func (m *Mempool) LoadJournal(path string) []*Tx {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.journal = newJournal(path)
+	m.locals = make(map[ids.ID]*Tx)
+
+	var loaded []*Tx
+	err := m.journal.load(func(tx *Tx) error {
+		if err := m.addTx(tx, true, false); err != nil {
+			return err
+		}
+		m.locals[tx.ID()] = tx
+		loaded = append(loaded, tx)
+		return nil
+	})
+	if err != nil {
+		log.Warn("Failed to load atomic transaction journal", "err", err)
+	}
+	if err := m.rejournal(); err != nil {
+		log.Warn("Failed to rotate atomic transaction journal", "err", err)
+	}
+	return loaded
+}
+
+// Rejournal regenerates the journal with the locally issued transactions that
+// are still in the mempool, dropping the accepted and discarded ones.
+func (m *Mempool) Rejournal() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.rejournal()
+}
+
+// CloseJournal closes the journal, if any.
+func (m *Mempool) CloseJournal() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.journal == nil {
+		return nil
+	}
+	return m.journal.close()
+}
+
+// journalTx records [tx] as a locally issued transaction and appends it to
+// the journal, if any.
+// Assumes the lock is held.
+func (m *Mempool) journalTx(tx *Tx) {
+	if m.journal == nil {
+		return
+	}
+	m.locals[tx.ID()] = tx
+	if err := m.journal.insert(tx); err != nil {
+		log.Warn("Failed to journal local atomic transaction", "txID", tx.ID(), "err", err)
+	}
+}
+
+// rejournal regenerates the journal, if any.
+// Assumes the lock is held.
+func (m *Mempool) rejournal() error {
+	if m.journal == nil {
+		return nil
+	}
+	txs := make([]*Tx, 0, len(m.locals))
+	for txID, tx := range m.locals {
+		if !m.contains(txID) {
+			delete(m.locals, txID)
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	return m.journal.rotate(txs)
+}
+
+// contains returns whether [txID] is pending, being built into a block or
+// issued.
+// Assumes the lock is held.
+func (m *Mempool) contains(txID ids.ID) bool {
+	if _, ok := m.txHeap.Get(txID); ok {
+		return true
+	}
+	if _, ok := m.currentTxs[txID]; ok {
+		return true
+	}
+	_, ok := m.issuedTxs[txID]
+	return ok
+}
+
 // addPending makes sure that an item is in the Pending channel.
 func (m *Mempool) addPending() {
 	select {
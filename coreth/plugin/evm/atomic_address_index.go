@@ -0,0 +1,236 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/coreth/plugin/evm/atomic"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const atomicAddressIndexKeyLen = ids.ShortIDLen + wrappers.LongLen + ids.IDLen
+
+var (
+	atomicAddressIndexDBPrefix  = []byte("atomicAddressIndexDB")
+	atomicAddressIndexHeightKey = []byte("atomicAddressIndexHeight")
+
+	errAtomicAddressIndexDisabled = errors.New("atomic tx address indexing is disabled, enable it with atomic-tx-address-indexing")
+)
+
+// AddressAtomicTx is an accepted atomic tx an address took part in.
+type AddressAtomicTx struct {
+	Height uint64
+	TxID   ids.ID
+}
+
+// InitializeAddressIndex enables the index of [address]+[height]+[txID] for
+// the addresses taking part in accepted atomic txs, indexing the txs accepted
+// since the index was last enabled. Addresses are either the short IDs of
+// addresses on other chains or EVM addresses. [secpCache] is used to recover
+// the signers of imported UTXOs.
+func (a *atomicTxRepository) InitializeAddressIndex(secpCache *secp256k1.RecoverCache) error {
+	a.addressIndexDB = prefixdb.New(atomicAddressIndexDBPrefix, a.db)
+	a.secpCache = secpCache
+
+	indexHeight, err := a.GetIndexHeight()
+	if err != nil {
+		return err
+	}
+	var start uint64
+	switch heightBytes, err := a.atomicRepoMetadataDB.Get(atomicAddressIndexHeightKey); err {
+	case nil:
+		if len(heightBytes) != wrappers.LongLen {
+			return fmt.Errorf("unexpected length for atomic address index height %d", len(heightBytes))
+		}
+		start = binary.BigEndian.Uint64(heightBytes) + 1
+	case database.ErrNotFound:
+		// There are no atomic txs in genesis.
+		start = 1
+	default:
+		return err
+	}
+	if start > indexHeight {
+		return nil
+	}
+
+	startTime := time.Now()
+	lastLogTime := startTime
+	log.Info("Initializing atomic tx address index", "start", start, "indexHeight", indexHeight)
+
+	iter := a.IterateByHeight(start)
+	defer iter.Release()
+
+	indexedTxs := 0
+	pendingBytesApproximation := 0
+	for iter.Next() {
+		heightBytes := iter.Key()
+		if len(heightBytes) != wrappers.LongLen {
+			return fmt.Errorf("atomic tx height index key had invalid length (%d) != (%d)", len(heightBytes), wrappers.LongLen)
+		}
+		height := binary.BigEndian.Uint64(heightBytes)
+		if height > indexHeight {
+			break
+		}
+		txs, err := atomic.ExtractAtomicTxsBatch(iter.Value(), a.codec)
+		if err != nil {
+			return err
+		}
+		for _, tx := range txs {
+			// Txs of bonus blocks are indexed at the height they were first
+			// accepted at, as in [acceptedAtomicTxDB].
+			_, txHeight, err := a.GetByTxID(tx.ID())
+			if err != nil {
+				return err
+			}
+			if txHeight != height {
+				continue
+			}
+			if err := a.indexTxByAddress(heightBytes, tx); err != nil {
+				return err
+			}
+			indexedTxs++
+		}
+		pendingBytesApproximation += len(iter.Value())
+
+		if pendingBytesApproximation > repoCommitSizeCap {
+			if err := a.atomicRepoMetadataDB.Put(atomicAddressIndexHeightKey, heightBytes); err != nil {
+				return err
+			}
+			if err := a.db.Commit(); err != nil {
+				return err
+			}
+			pendingBytesApproximation = 0
+		}
+		if time.Since(lastLogTime) > 15*time.Second {
+			lastLogTime = time.Now()
+			log.Info("Atomic tx address index initialization", "height", height, "indexedTxs", indexedTxs)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("atomic tx height index iterator errored while initializing atomic address index: %w", err)
+	}
+
+	indexHeightBytes := make([]byte, wrappers.LongLen)
+	binary.BigEndian.PutUint64(indexHeightBytes, indexHeight)
+	if err := a.atomicRepoMetadataDB.Put(atomicAddressIndexHeightKey, indexHeightBytes); err != nil {
+		return err
+	}
+	log.Info("Completed atomic tx address index initialization", "indexedTxs", indexedTxs, "duration", time.Since(startTime))
+	return a.db.Commit()
+}
+
+// GetByAddress returns up to [limit] accepted atomic txs [addr] took part in,
+// in ascending order of height and txID, starting at [startHeight] and
+// [startTxID].
+func (a *atomicTxRepository) GetByAddress(addr ids.ShortID, startHeight uint64, startTxID ids.ID, limit int) ([]AddressAtomicTx, error) {
+	if a.addressIndexDB == nil {
+		return nil, errAtomicAddressIndexDisabled
+	}
+	start := make([]byte, 0, atomicAddressIndexKeyLen)
+	start = append(start, addr[:]...)
+	start = binary.BigEndian.AppendUint64(start, startHeight)
+	start = append(start, startTxID[:]...)
+
+	iter := a.addressIndexDB.NewIteratorWithStartAndPrefix(start, addr[:])
+	defer iter.Release()
+
+	var txs []AddressAtomicTx
+	for len(txs) < limit && iter.Next() {
+		key := iter.Key()
+		if len(key) != atomicAddressIndexKeyLen {
+			return nil, fmt.Errorf("atomic address index key had invalid length (%d) != (%d)", len(key), atomicAddressIndexKeyLen)
+		}
+		txID, err := ids.ToID(key[ids.ShortIDLen+wrappers.LongLen:])
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, AddressAtomicTx{
+			Height: binary.BigEndian.Uint64(key[ids.ShortIDLen:]),
+			TxID:   txID,
+		})
+	}
+	return txs, iter.Error()
+}
+
+// indexTxByAddress adds [tx] accepted at [heightBytes] to the address index,
+// if enabled.
+func (a *atomicTxRepository) indexTxByAddress(heightBytes []byte, tx *atomic.Tx) error {
+	if a.addressIndexDB == nil {
+		return nil
+	}
+	addrs, err := atomicTxAddresses(tx, a.secpCache)
+	if err != nil {
+		return fmt.Errorf("failed to get addresses of atomic tx %s: %w", tx.ID(), err)
+	}
+	txID := tx.ID()
+	for addr := range addrs {
+		key := make([]byte, 0, atomicAddressIndexKeyLen)
+		key = append(key, addr[:]...)
+		key = append(key, heightBytes...)
+		key = append(key, txID[:]...)
+		if err := a.addressIndexDB.Put(key, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// atomicTxAddresses returns the addresses taking part in [tx]: the EVM
+// addresses debited by an export or credited by an import, the owners of the
+// exported outputs and the signers of the imported UTXOs.
+func atomicTxAddresses(tx *atomic.Tx, secpCache *secp256k1.RecoverCache) (set.Set[ids.ShortID], error) {
+	addrs := set.NewSet[ids.ShortID](2)
+	switch utx := tx.UnsignedAtomicTx.(type) {
+	case *atomic.UnsignedImportTx:
+		for _, out := range utx.Outs {
+			addrs.Add(ids.ShortID(out.Address))
+		}
+		unsignedBytes := utx.Bytes()
+		for _, verifiable := range tx.Creds {
+			cred, ok := verifiable.(*secp256k1fx.Credential)
+			if !ok {
+				return nil, fmt.Errorf("expected *secp256k1fx.Credential but got %T", verifiable)
+			}
+			for _, sig := range cred.Sigs {
+				pubKey, err := secpCache.RecoverPublicKey(unsignedBytes, sig[:])
+				if err != nil {
+					return nil, err
+				}
+				addrs.Add(pubKey.Address())
+			}
+		}
+	case *atomic.UnsignedExportTx:
+		for _, in := range utx.Ins {
+			addrs.Add(ids.ShortID(in.Address))
+		}
+		for _, out := range utx.ExportedOutputs {
+			addressable, ok := out.Out.(avax.Addressable)
+			if !ok {
+				continue
+			}
+			for _, addrBytes := range addressable.Addresses() {
+				addr, err := ids.ToShortID(addrBytes)
+				if err != nil {
+					return nil, err
+				}
+				addrs.Add(addr)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unexpected atomic tx type %T", utx)
+	}
+	return addrs, nil
+}
@@ -0,0 +1,139 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/coreth/plugin/evm/atomic"
+	"github.com/ava-labs/coreth/plugin/evm/client"
+	"github.com/ava-labs/coreth/plugin/evm/upgrade/ap0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAtomicTxsByAddress(t *testing.T) {
+	require := require.New(t)
+
+	importAmount := uint64(50000000)
+	issuer, vm, dbManager, _, appSender := GenesisVMWithUTXOs(t, true, genesisJSONApricotPhase2, "", "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+		testShortIDAddrs[1]: importAmount,
+	})
+	acceptTx := func(vm *VM, tx *atomic.Tx) {
+		require.NoError(vm.mempool.AddLocalTx(tx))
+		<-issuer
+
+		blk, err := vm.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+		require.NoError(blk.Accept(context.Background()))
+	}
+
+	importTx, err := vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	acceptTx(vm, importTx)
+	exportTx, err := vm.newExportTx(vm.ctx.AVAXAssetID, importAmount-(2*ap0.AtomicTxFee), vm.ctx.XChainID, testShortIDAddrs[2], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	acceptTx(vm, exportTx)
+
+	// The index is opt-in.
+	vm.ctx.Lock.Unlock()
+	reply := &client.GetAtomicTxsByAddressReply{}
+	err = (&AvaxAPI{vm}).GetAtomicTxsByAddress(nil, &client.GetAtomicTxsByAddressArgs{Address: testEthAddrs[0].Hex()}, reply)
+	require.ErrorIs(err, errAtomicAddressIndexDisabled)
+	vm.ctx.Lock.Lock()
+	require.NoError(vm.Shutdown(context.Background()))
+
+	// Enabling the index indexes the previously accepted txs.
+	resetMetrics(vm)
+	restartedVM := &VM{}
+	require.NoError(restartedVM.Initialize(
+		context.Background(),
+		vm.ctx,
+		dbManager,
+		[]byte(genesisJSONApricotPhase2),
+		[]byte(""),
+		[]byte(`{"atomic-tx-address-indexing":true}`),
+		issuer,
+		[]*commonEng.Fx{},
+		appSender,
+	))
+	defer func() {
+		require.NoError(restartedVM.Shutdown(context.Background()))
+	}()
+	require.NoError(restartedVM.SetState(context.Background(), snow.Bootstrapping))
+	require.NoError(restartedVM.SetState(context.Background(), snow.NormalOp))
+
+	// Newly accepted txs are indexed.
+	importTx2, err := restartedVM.newImportTx(restartedVM.ctx.XChainID, testEthAddrs[1], initialBaseFee, []*secp256k1.PrivateKey{testKeys[1]})
+	require.NoError(err)
+	acceptTx(restartedVM, importTx2)
+
+	service := &AvaxAPI{restartedVM}
+	getTxs := func(addr string, limit uint32, startIndex *client.AtomicTxIndex) *client.GetAtomicTxsByAddressReply {
+		restartedVM.ctx.Lock.Unlock()
+		defer restartedVM.ctx.Lock.Lock()
+
+		reply := &client.GetAtomicTxsByAddressReply{}
+		require.NoError(service.GetAtomicTxsByAddress(nil, &client.GetAtomicTxsByAddressArgs{
+			Address:    addr,
+			StartIndex: startIndex,
+			Limit:      json.Uint32(limit),
+		}, reply))
+		return reply
+	}
+	accepted := func(tx *atomic.Tx, height uint64) client.AddressAtomicTx {
+		return client.AddressAtomicTx{TxID: tx.ID(), BlockHeight: json.Uint64(height), Status: atomic.Accepted}
+	}
+
+	// The signer of the imported UTXOs
+	signer, err := restartedVM.FormatAddress(restartedVM.ctx.XChainID, testShortIDAddrs[0])
+	require.NoError(err)
+	require.Equal(&client.GetAtomicTxsByAddressReply{
+		Txs:        []client.AddressAtomicTx{accepted(importTx, 1)},
+		NumFetched: 1,
+	}, getTxs(signer, 0, nil))
+
+	// The recipient of the import and sender of the export, paginated
+	reply = getTxs(testEthAddrs[0].Hex(), 1, nil)
+	require.Equal(&client.GetAtomicTxsByAddressReply{
+		Txs:        []client.AddressAtomicTx{accepted(importTx, 1)},
+		NumFetched: 1,
+		EndIndex:   &client.AtomicTxIndex{BlockHeight: 2, TxID: exportTx.ID()},
+	}, reply)
+	require.Equal(&client.GetAtomicTxsByAddressReply{
+		Txs:        []client.AddressAtomicTx{accepted(exportTx, 2)},
+		NumFetched: 1,
+	}, getTxs(testEthAddrs[0].Hex(), 1, reply.EndIndex))
+
+	// The owner of the exported UTXOs
+	require.Equal(&client.GetAtomicTxsByAddressReply{
+		Txs:        []client.AddressAtomicTx{accepted(exportTx, 2)},
+		NumFetched: 1,
+	}, getTxs(testShortIDAddrs[2].String(), 0, nil))
+
+	// Txs are processing until the chain state reaches their block.
+	processing := accepted(importTx2, 3)
+	processing.Status = atomic.Processing
+	require.Equal(&client.GetAtomicTxsByAddressReply{
+		Txs:        []client.AddressAtomicTx{processing},
+		NumFetched: 1,
+	}, getTxs(testEthAddrs[1].Hex(), 0, nil))
+	restartedVM.blockChain.DrainAcceptorQueue()
+	require.Equal(&client.GetAtomicTxsByAddressReply{
+		Txs:        []client.AddressAtomicTx{accepted(importTx2, 3)},
+		NumFetched: 1,
+	}, getTxs(testEthAddrs[1].Hex(), 0, nil))
+
+	require.Equal(&client.GetAtomicTxsByAddressReply{
+		Txs: []client.AddressAtomicTx{},
+	}, getTxs(ids.GenerateTestShortID().String(), 0, nil))
+}
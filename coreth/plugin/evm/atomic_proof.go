@@ -0,0 +1,66 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"fmt"
+
+	avalanchedatabase "github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// atomicCommitHeight returns the first height at or above [height] the atomic
+// trie is committed at with [commitInterval].
+func atomicCommitHeight(height uint64, commitInterval uint64) uint64 {
+	commitHeight := nearestCommitHeight(height, commitInterval)
+	if commitHeight < height {
+		commitHeight += commitInterval
+	}
+	return commitHeight
+}
+
+// proveAtomicOps returns the codec bytes of the atomic operations accepted at
+// [height] by blockchain ID, and the trie nodes proving them against the
+// atomic trie [root], ordered by hash.
+func proveAtomicOps(atomicTrie AtomicTrie, root common.Hash, height uint64) (map[ids.ID][]byte, [][]byte, error) {
+	iter, err := atomicTrie.Iterator(root, avalanchedatabase.PackUInt64(height))
+	if err != nil {
+		return nil, nil, err
+	}
+	var (
+		keys [][]byte
+		ops  = make(map[ids.ID][]byte)
+	)
+	for iter.Next() && iter.BlockNumber() == height {
+		keys = append(keys, iter.Key())
+		ops[iter.BlockchainID()] = common.CopyBytes(iter.Value())
+	}
+	if err := iter.Error(); err != nil {
+		return nil, nil, err
+	}
+	if len(ops) == 0 {
+		return nil, nil, fmt.Errorf("no atomic operations at height %d", height)
+	}
+
+	t, err := atomicTrie.OpenTrie(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	proofDB := memorydb.New()
+	for _, key := range keys {
+		if err := t.Prove(key, proofDB); err != nil {
+			return nil, nil, err
+		}
+	}
+	proofIter := proofDB.NewIterator(nil, nil)
+	defer proofIter.Release()
+
+	proof := make([][]byte, 0, proofDB.Len())
+	for proofIter.Next() {
+		proof = append(proof, common.CopyBytes(proofIter.Value()))
+	}
+	return ops, proof, proofIter.Error()
+}
@@ -0,0 +1,99 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/coreth/plugin/evm/atomic"
+	"github.com/ava-labs/coreth/plugin/evm/client"
+	"github.com/ava-labs/coreth/plugin/evm/upgrade/ap0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAtomicProof(t *testing.T) {
+	require := require.New(t)
+
+	importAmount := uint64(50000000)
+	issuer, vm, _, _, _ := GenesisVMWithUTXOs(t, true, genesisJSONApricotPhase2, `{"commit-interval":2}`, "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+	acceptTx := func(tx *atomic.Tx) {
+		require.NoError(vm.mempool.AddLocalTx(tx))
+		<-issuer
+
+		blk, err := vm.BuildBlock(context.Background())
+		require.NoError(err)
+		require.NoError(blk.Verify(context.Background()))
+		require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+		require.NoError(blk.Accept(context.Background()))
+	}
+
+	importTx, err := vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	acceptTx(importTx)
+	exportTx, err := vm.newExportTx(vm.ctx.AVAXAssetID, importAmount-(2*ap0.AtomicTxFee), vm.ctx.XChainID, testShortIDAddrs[1], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+	require.NoError(err)
+	acceptTx(exportTx)
+
+	// The atomic trie is committed at height 2.
+	root, err := vm.atomicTrie.Root(2)
+	require.NoError(err)
+	require.NotEqual(common.Hash{}, root)
+
+	service := &AvaxAPI{vm}
+	getProof := func(args *client.GetAtomicProofArgs) (*client.AtomicProof, error) {
+		vm.ctx.Lock.Unlock()
+		defer vm.ctx.Lock.Lock()
+
+		reply := &client.GetAtomicProofReply{}
+		if err := service.GetAtomicProof(nil, args, reply); err != nil {
+			return nil, err
+		}
+		return client.NewAtomicProof(reply)
+	}
+
+	exportProof, err := getProof(&client.GetAtomicProofArgs{TxID: exportTx.ID()})
+	require.NoError(err)
+	require.Equal(uint64(2), exportProof.Height)
+	require.Equal(uint64(2), exportProof.CommitHeight)
+	require.Equal(root, exportProof.Root)
+	require.NoError(client.VerifyAtomicTxProof(root, exportProof, exportTx))
+	require.Error(client.VerifyAtomicTxProof(root, exportProof, importTx))
+
+	height := json.Uint64(1)
+	importProof, err := getProof(&client.GetAtomicProofArgs{Height: &height})
+	require.NoError(err)
+	require.Equal(uint64(1), importProof.Height)
+	require.Equal(uint64(2), importProof.CommitHeight)
+	require.NoError(client.VerifyAtomicTxProof(root, importProof, importTx))
+
+	// The proof only verifies against its root and its atomic operations.
+	_, err = client.VerifyAtomicProof(common.Hash{1}, importProof)
+	require.Error(err)
+	importProof.Ops[vm.ctx.XChainID] = exportProof.Ops[vm.ctx.XChainID]
+	_, err = client.VerifyAtomicProof(root, importProof)
+	require.Error(err)
+
+	// Atomic operations are only proven once the atomic trie is committed.
+	vm.ctx.Lock.Unlock()
+	err = service.GetAtomicProof(nil, &client.GetAtomicProofArgs{}, &client.GetAtomicProofReply{})
+	vm.ctx.Lock.Lock()
+	require.ErrorIs(err, errNoAtomicProofTarget)
+	height = 3
+	_, err = getProof(&client.GetAtomicProofArgs{Height: &height})
+	require.ErrorContains(err, "not committed")
+	height = 2
+	commitHeight := json.Uint64(1)
+	_, err = getProof(&client.GetAtomicProofArgs{Height: &height, CommitHeight: &commitHeight})
+	require.Error(err)
+}
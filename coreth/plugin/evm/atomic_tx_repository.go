@@ -17,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/coreth/plugin/evm/atomic"
@@ -47,6 +48,9 @@ type AtomicTxRepository interface {
 
 	IterateByHeight(start uint64) database.Iterator
 	Codec() codec.Manager
+
+	InitializeAddressIndex(secpCache *secp256k1.RecoverCache) error
+	GetByAddress(addr ids.ShortID, startHeight uint64, startTxID ids.ID, limit int) ([]AddressAtomicTx, error)
 }
 
 // atomicTxRepository is a prefixdb implementation of the AtomicTxRepository interface
@@ -61,6 +65,12 @@ type atomicTxRepository struct {
 	// has indexed.
 	atomicRepoMetadataDB database.Database
 
+	// [addressIndexDB] maintains an index of [address]+[height]+[txID] for the
+	// addresses taking part in accepted atomic txs, if enabled with
+	// InitializeAddressIndex.
+	addressIndexDB database.Database
+	secpCache      *secp256k1.RecoverCache
+
 	// [db] is used to commit to the underlying versiondb.
 	db *versiondb.Database
 
@@ -288,6 +298,9 @@ func (a *atomicTxRepository) write(height uint64, txs []*atomic.Tx, bonus bool)
 			if err := a.indexTxByID(heightBytes, tx); err != nil {
 				return err
 			}
+			if err := a.indexTxByAddress(heightBytes, tx); err != nil {
+				return err
+			}
 		}
 		if err := a.indexTxsAtHeight(heightBytes, txs); err != nil {
 			return err
@@ -296,6 +309,11 @@ func (a *atomicTxRepository) write(height uint64, txs []*atomic.Tx, bonus bool)
 
 	// Update the index height regardless of if any atomic transactions
 	// were present at [height].
+	if a.addressIndexDB != nil {
+		if err := a.atomicRepoMetadataDB.Put(atomicAddressIndexHeightKey, heightBytes); err != nil {
+			return err
+		}
+	}
 	return a.atomicRepoMetadataDB.Put(maxIndexedHeightKey, heightBytes)
 }
 
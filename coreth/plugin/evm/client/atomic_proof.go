@@ -0,0 +1,195 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	avalancheatomic "github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/coreth/plugin/evm/atomic"
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+var (
+	errUnexpectedAtomicRoot = errors.New("atomic proof is not against the expected root")
+	errNoAtomicOps          = errors.New("atomic proof has no atomic operations")
+)
+
+// GetAtomicProofArgs are the arguments for GetAtomicProof. Either TxID or
+// Height must be set. CommitHeight defaults to the first height the atomic
+// trie was committed at including the atomic operations.
+type GetAtomicProofArgs struct {
+	TxID         ids.ID              `json:"txID"`
+	Height       *json.Uint64        `json:"height,omitempty"`
+	CommitHeight *json.Uint64        `json:"commitHeight,omitempty"`
+	Encoding     formatting.Encoding `json:"encoding"`
+}
+
+// AtomicOps are the encoded atomic requests accepted at a height for a
+// blockchain
+type AtomicOps struct {
+	BlockchainID ids.ID `json:"blockchainID"`
+	Requests     string `json:"requests"`
+}
+
+// GetAtomicProofReply defines the GetAtomicProof replies returned from the
+// API. Proof holds the encoded trie nodes proving Ops against Root, the atomic
+// trie root committed at CommitHeight.
+type GetAtomicProofReply struct {
+	Height       json.Uint64         `json:"height"`
+	CommitHeight json.Uint64         `json:"commitHeight"`
+	Root         common.Hash         `json:"root"`
+	Ops          []AtomicOps         `json:"ops"`
+	Proof        []string            `json:"proof"`
+	Encoding     formatting.Encoding `json:"encoding"`
+}
+
+// AtomicProof is a Merkle proof of the atomic operations accepted at Height
+// against the atomic trie root committed at CommitHeight
+type AtomicProof struct {
+	Height       uint64
+	CommitHeight uint64
+	Root         common.Hash
+	// Ops are the codec bytes of the atomic requests by blockchain ID
+	Ops   map[ids.ID][]byte
+	Proof [][]byte
+}
+
+// NewAtomicProof decodes [reply] into an AtomicProof
+func NewAtomicProof(reply *GetAtomicProofReply) (*AtomicProof, error) {
+	proof := &AtomicProof{
+		Height:       uint64(reply.Height),
+		CommitHeight: uint64(reply.CommitHeight),
+		Root:         reply.Root,
+		Ops:          make(map[ids.ID][]byte, len(reply.Ops)),
+		Proof:        make([][]byte, len(reply.Proof)),
+	}
+	for _, ops := range reply.Ops {
+		requests, err := formatting.Decode(reply.Encoding, ops.Requests)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode atomic requests for %s: %w", ops.BlockchainID, err)
+		}
+		proof.Ops[ops.BlockchainID] = requests
+	}
+	for i, node := range reply.Proof {
+		nodeBytes, err := formatting.Decode(reply.Encoding, node)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't decode proof node: %w", err)
+		}
+		proof.Proof[i] = nodeBytes
+	}
+	return proof, nil
+}
+
+// AtomicTrieKey returns the key of the atomic operations accepted at [height]
+// for [blockchainID] in the atomic trie
+func AtomicTrieKey(height uint64, blockchainID ids.ID) []byte {
+	keyPacker := wrappers.Packer{Bytes: make([]byte, wrappers.LongLen+ids.IDLen)}
+	keyPacker.PackLong(height)
+	keyPacker.PackFixedBytes(blockchainID[:])
+	return keyPacker.Bytes
+}
+
+// VerifyAtomicProof verifies [proof] against the trusted atomic trie [root],
+// such as the atomic root of the state summary at the commit height of the
+// proof, and returns the proven atomic requests by blockchain ID.
+func VerifyAtomicProof(root common.Hash, proof *AtomicProof) (map[ids.ID]*avalancheatomic.Requests, error) {
+	if proof.Root != root {
+		return nil, fmt.Errorf("%w: expected %s but got %s", errUnexpectedAtomicRoot, root, proof.Root)
+	}
+	if proof.Height > proof.CommitHeight {
+		return nil, fmt.Errorf("atomic operations at height %d can't be committed at height %d", proof.Height, proof.CommitHeight)
+	}
+	if len(proof.Ops) == 0 {
+		return nil, errNoAtomicOps
+	}
+	proofDB := memorydb.New()
+	for _, node := range proof.Proof {
+		if err := proofDB.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	atomicOps := make(map[ids.ID]*avalancheatomic.Requests, len(proof.Ops))
+	for blockchainID, requestsBytes := range proof.Ops {
+		value, err := trie.VerifyProof(root, AtomicTrieKey(proof.Height, blockchainID), proofDB)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proof of atomic operations for %s: %w", blockchainID, err)
+		}
+		if !bytes.Equal(value, requestsBytes) {
+			return nil, fmt.Errorf("proven atomic operations for %s don't match the proof", blockchainID)
+		}
+		requests := new(avalancheatomic.Requests)
+		if _, err := atomic.Codec.Unmarshal(requestsBytes, requests); err != nil {
+			return nil, fmt.Errorf("couldn't parse atomic operations for %s: %w", blockchainID, err)
+		}
+		atomicOps[blockchainID] = requests
+	}
+	return atomicOps, nil
+}
+
+// VerifyAtomicTxProof verifies [proof] against the trusted atomic trie [root]
+// and that the atomic operations of [tx] were accepted at the height of the
+// proof.
+func VerifyAtomicTxProof(root common.Hash, proof *AtomicProof, tx *atomic.Tx) error {
+	atomicOps, err := VerifyAtomicProof(root, proof)
+	if err != nil {
+		return err
+	}
+	blockchainID, txRequests, err := tx.AtomicOps()
+	if err != nil {
+		return err
+	}
+	requests, ok := atomicOps[blockchainID]
+	if !ok {
+		return fmt.Errorf("atomic proof has no atomic operations for %s", blockchainID)
+	}
+	for _, key := range txRequests.RemoveRequests {
+		if !containsRemoveRequest(requests, key) {
+			return fmt.Errorf("atomic proof doesn't include the removal of %x by tx %s", key, tx.ID())
+		}
+	}
+	for _, elem := range txRequests.PutRequests {
+		if !containsPutRequest(requests, elem) {
+			return fmt.Errorf("atomic proof doesn't include the put of %x by tx %s", elem.Key, tx.ID())
+		}
+	}
+	return nil
+}
+
+func containsRemoveRequest(requests *avalancheatomic.Requests, key []byte) bool {
+	for _, removed := range requests.RemoveRequests {
+		if bytes.Equal(removed, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsPutRequest(requests *avalancheatomic.Requests, elem *avalancheatomic.Element) bool {
+	for _, put := range requests.PutRequests {
+		if !bytes.Equal(put.Key, elem.Key) || !bytes.Equal(put.Value, elem.Value) || len(put.Traits) != len(elem.Traits) {
+			continue
+		}
+		equal := true
+		for i, trait := range put.Traits {
+			if !bytes.Equal(trait, elem.Traits[i]) {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return true
+		}
+	}
+	return false
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/coreth/plugin/evm/atomic"
 	"github.com/ava-labs/coreth/plugin/evm/config"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // Interface compliance
@@ -31,12 +32,16 @@ type Client interface {
 	GetAtomicTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (atomic.Status, error)
 	GetAtomicTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	GetAtomicUTXOs(ctx context.Context, addrs []ids.ShortID, sourceChain string, limit uint32, startAddress ids.ShortID, startUTXOID ids.ID, options ...rpc.Option) ([][]byte, ids.ShortID, ids.ID, error)
+	GetAtomicTxsByAddress(ctx context.Context, addr string, limit uint32, startIndex *AtomicTxIndex, options ...rpc.Option) ([]AddressAtomicTx, *AtomicTxIndex, error)
+	GetAtomicTxProof(ctx context.Context, txID ids.ID, options ...rpc.Option) (*AtomicProof, error)
+	GetAtomicProof(ctx context.Context, height uint64, options ...rpc.Option) (*AtomicProof, error)
 	StartCPUProfiler(ctx context.Context, options ...rpc.Option) error
 	StopCPUProfiler(ctx context.Context, options ...rpc.Option) error
 	MemoryProfile(ctx context.Context, options ...rpc.Option) error
 	LockProfile(ctx context.Context, options ...rpc.Option) error
 	SetLogLevel(ctx context.Context, level slog.Level, options ...rpc.Option) error
 	GetVMConfig(ctx context.Context, options ...rpc.Option) (*config.Config, error)
+	ExportStateSyncFile(ctx context.Context, path string, height *uint64, options ...rpc.Option) (*ExportStateSyncFileReply, error)
 }
 
 // Client implementation for interacting with EVM [chain]
@@ -135,6 +140,78 @@ func (c *client) GetAtomicUTXOs(ctx context.Context, addrs []ids.ShortID, source
 	return utxos, endAddr, endUTXOID, err
 }
 
+// AtomicTxIndex identifies an accepted atomic tx in the history of an address
+type AtomicTxIndex struct {
+	BlockHeight json.Uint64 `json:"blockHeight"`
+	TxID        ids.ID      `json:"txID"`
+}
+
+// GetAtomicTxsByAddressArgs are the arguments for GetAtomicTxsByAddress
+type GetAtomicTxsByAddressArgs struct {
+	// Address is either a bech32 address of any chain or a hex EVM address
+	Address    string         `json:"address"`
+	StartIndex *AtomicTxIndex `json:"startIndex,omitempty"`
+	Limit      json.Uint32    `json:"limit"`
+}
+
+// AddressAtomicTx is an atomic tx an address took part in
+type AddressAtomicTx struct {
+	TxID        ids.ID        `json:"txID"`
+	BlockHeight json.Uint64   `json:"blockHeight"`
+	Status      atomic.Status `json:"status"`
+}
+
+// GetAtomicTxsByAddressReply defines the GetAtomicTxsByAddress replies
+// returned from the API. EndIndex is set if there are more txs, and must be
+// passed as StartIndex to fetch them.
+type GetAtomicTxsByAddressReply struct {
+	Txs        []AddressAtomicTx `json:"txs"`
+	NumFetched json.Uint64       `json:"numFetched"`
+	EndIndex   *AtomicTxIndex    `json:"endIndex,omitempty"`
+}
+
+// GetAtomicTxsByAddress returns up to [limit] accepted atomic txs [addr] took
+// part in starting at [startIndex], and the index to continue from if there
+// are more
+func (c *client) GetAtomicTxsByAddress(ctx context.Context, addr string, limit uint32, startIndex *AtomicTxIndex, options ...rpc.Option) ([]AddressAtomicTx, *AtomicTxIndex, error) {
+	res := &GetAtomicTxsByAddressReply{}
+	err := c.requester.SendRequest(ctx, "avax.getAtomicTxsByAddress", &GetAtomicTxsByAddressArgs{
+		Address:    addr,
+		StartIndex: startIndex,
+		Limit:      json.Uint32(limit),
+	}, res, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Txs, res.EndIndex, nil
+}
+
+// GetAtomicTxProof returns a proof of the atomic operations accepted at the
+// height of [txID], including the ones of [txID]
+func (c *client) GetAtomicTxProof(ctx context.Context, txID ids.ID, options ...rpc.Option) (*AtomicProof, error) {
+	return c.getAtomicProof(ctx, &GetAtomicProofArgs{
+		TxID:     txID,
+		Encoding: formatting.Hex,
+	}, options...)
+}
+
+// GetAtomicProof returns a proof of the atomic operations accepted at [height]
+func (c *client) GetAtomicProof(ctx context.Context, height uint64, options ...rpc.Option) (*AtomicProof, error) {
+	jsonHeight := json.Uint64(height)
+	return c.getAtomicProof(ctx, &GetAtomicProofArgs{
+		Height:   &jsonHeight,
+		Encoding: formatting.Hex,
+	}, options...)
+}
+
+func (c *client) getAtomicProof(ctx context.Context, args *GetAtomicProofArgs, options ...rpc.Option) (*AtomicProof, error) {
+	res := &GetAtomicProofReply{}
+	if err := c.requester.SendRequest(ctx, "avax.getAtomicProof", args, res, options...); err != nil {
+		return nil, err
+	}
+	return NewAtomicProof(res)
+}
+
 func (c *client) StartCPUProfiler(ctx context.Context, options ...rpc.Option) error {
 	return c.adminRequester.SendRequest(ctx, "admin.startCPUProfiler", struct{}{}, &api.EmptyReply{}, options...)
 }
@@ -172,3 +249,28 @@ func (c *client) GetVMConfig(ctx context.Context, options ...rpc.Option) (*confi
 	err := c.adminRequester.SendRequest(ctx, "admin.getVMConfig", struct{}{}, res, options...)
 	return res.Config, err
 }
+
+type ExportStateSyncFileArgs struct {
+	Path   string       `json:"path"`
+	Height *json.Uint64 `json:"height,omitempty"`
+}
+
+type ExportStateSyncFileReply struct {
+	SummaryID  ids.ID      `json:"summaryID"`
+	Height     json.Uint64 `json:"height"`
+	BlockHash  common.Hash `json:"blockHash"`
+	BlockRoot  common.Hash `json:"blockRoot"`
+	AtomicRoot common.Hash `json:"atomicRoot"`
+}
+
+// ExportStateSyncFile writes the state sync file of the state summary at [height],
+// or of the last state summary if nil, to [path] on the node
+func (c *client) ExportStateSyncFile(ctx context.Context, path string, height *uint64, options ...rpc.Option) (*ExportStateSyncFileReply, error) {
+	args := &ExportStateSyncFileArgs{Path: path}
+	if height != nil {
+		args.Height = (*json.Uint64)(height)
+	}
+	res := &ExportStateSyncFileReply{}
+	err := c.adminRequester.SendRequest(ctx, "admin.exportStateSyncFile", args, res, options...)
+	return res, err
+}
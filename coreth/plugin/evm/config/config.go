@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/vms/components/gas"
 	"github.com/ava-labs/coreth/plugin/evm/upgrade/etna"
@@ -35,6 +36,7 @@ const (
 	defaultWsCpuRefillRate                        = 0 // Default to no maximum WS CPU usage
 	defaultWsCpuMaxStored                         = 0 // Default to no maximum WS CPU usage
 	defaultMaxBlocksPerRequest                    = 0 // Default to no maximum on the number of blocks per getLogs request
+	defaultLogsReplayLimit                        = 10_000
 	defaultContinuousProfilerFrequency            = 15 * time.Minute
 	defaultContinuousProfilerMaxFiles             = 5
 	defaultPushGossipPercentStake                 = .9
@@ -51,7 +53,13 @@ const (
 	defaultMaxOutboundActiveRequests              = 16
 	defaultPopulateMissingTriesParallelism        = 1024
 	defaultStateSyncServerTrieCache               = 64 // MB
+	defaultStateSyncServerPeerPeriod              = time.Minute
+	defaultStateSyncValidatorMultiplier           = 4
 	defaultAcceptedCacheSize                      = 32 // blocks
+	defaultHistoricalStateReexec                  = defaultCommitInterval
+	defaultHistoricalStateCache                   = 256    // MB
+	defaultAncientFreezerThreshold                = 90_000 // blocks
+	defaultAtomicMempoolRejournal                 = time.Hour
 
 	// defaultStateSyncMinBlocks is the minimum number of blocks the blockchain
 	// should be ahead of local last accepted to perform state sync.
@@ -105,6 +113,7 @@ type Config struct {
 	CorethAdminAPIEnabled bool   `json:"coreth-admin-api-enabled"` // Deprecated: use AdminAPIEnabled instead
 	CorethAdminAPIDir     string `json:"coreth-admin-api-dir"`     // Deprecated: use AdminAPIDir instead
 	WarpAPIEnabled        bool   `json:"warp-api-enabled"`
+	GraphQLEnabled        bool   `json:"graphql-enabled"`
 
 	// EnabledEthAPIs is a list of Ethereum services that should be enabled
 	// If none is specified, then we use the default list [defaultEnabledAPIs]
@@ -144,6 +153,14 @@ type Config struct {
 	// last accepted block to be accepted for proof state queries.
 	HistoricalProofQueryWindow uint64 `json:"historical-proof-query-window,omitempty"`
 
+	// HistoricalStateRegeneration allows, when pruning is enabled, state queries
+	// on blocks whose state is no longer available by re-executing up to
+	// HistoricalStateReexec blocks on top of the nearest committed state. The
+	// regenerated states are kept in up to HistoricalStateCache MB of memory.
+	HistoricalStateRegeneration bool   `json:"historical-state-regeneration-enabled"`
+	HistoricalStateReexec       uint64 `json:"historical-state-reexec"`
+	HistoricalStateCache        int    `json:"historical-state-cache"`
+
 	// Metric Settings
 	MetricsExpensiveEnabled bool `json:"metrics-expensive-enabled"` // Debug-level metrics that might impact runtime performance
 
@@ -163,10 +180,21 @@ type Config struct {
 	TxPoolGlobalQueue  uint64   `json:"tx-pool-global-queue"`
 	TxPoolLifetime     Duration `json:"tx-pool-lifetime"`
 
+	// AtomicMempoolJournal persists the atomic transactions issued through
+	// the avax API to AtomicMempoolJournalPath, which defaults to the
+	// "atomic_transactions.rlp" file in the chain data directory, so the ones
+	// not yet accepted are verified again, added back to the mempool and
+	// gossiped after a restart. The journal is regenerated every
+	// AtomicMempoolRejournal.
+	AtomicMempoolJournal     bool     `json:"atomic-mempool-journal-enabled"`
+	AtomicMempoolJournalPath string   `json:"atomic-mempool-journal-path"`
+	AtomicMempoolRejournal   Duration `json:"atomic-mempool-rejournal"`
+
 	APIMaxDuration           Duration      `json:"api-max-duration"`
 	WSCPURefillRate          Duration      `json:"ws-cpu-refill-rate"`
 	WSCPUMaxStored           Duration      `json:"ws-cpu-max-stored"`
 	MaxBlocksPerRequest      int64         `json:"api-max-blocks-per-request"`
+	LogsReplayLimit          uint64        `json:"api-logs-replay-limit"`
 	AllowUnfinalizedQueries  bool          `json:"allow-unfinalized-queries"`
 	AllowUnprotectedTxs      bool          `json:"allow-unprotected-txs"`
 	AllowUnprotectedTxHashes []common.Hash `json:"allow-unprotected-tx-hashes"`
@@ -208,8 +236,34 @@ type Config struct {
 	StateSyncMinBlocks       uint64 `json:"state-sync-min-blocks"`
 	StateSyncRequestSize     uint16 `json:"state-sync-request-size"`
 
+	// StateSyncServerPeerBytes and StateSyncServerPeerTime are the response
+	// bytes and handling time the state sync server spends on the requests of
+	// a peer per StateSyncServerPeerPeriod, after which the requests of the
+	// peer are rejected with the time to back off for. Zero disables the
+	// respective budget. The budgets of validators are multiplied by
+	// StateSyncServerValidatorMultiplier.
+	StateSyncServerPeerBytes           uint64   `json:"state-sync-server-peer-bytes"`
+	StateSyncServerPeerTime            Duration `json:"state-sync-server-peer-time"`
+	StateSyncServerPeerPeriod          Duration `json:"state-sync-server-peer-period"`
+	StateSyncServerValidatorMultiplier uint64   `json:"state-sync-server-validator-multiplier"`
+
+	// StateSyncFile is the path of a state sync file exported with
+	// admin.exportStateSyncFile. If set, the node state syncs from the file
+	// on startup, unless it already accepted the block of its summary.
+	// The summary of the file is not voted on by validators, so it must have
+	// the ID StateSyncFileSummaryID, as returned by admin.exportStateSyncFile.
+	StateSyncFile          string `json:"state-sync-file"`
+	StateSyncFileSummaryID ids.ID `json:"state-sync-file-summary-id"`
+
 	// Database Settings
 	InspectDatabase bool `json:"inspect-database"` // Inspects the database on startup if enabled.
+	// AncientFreezer moves accepted blocks that are AncientFreezerThreshold
+	// blocks below the last accepted block, along with their receipts, out of
+	// the database into append-only freezer files in AncientFreezerDirectory,
+	// which defaults to the "ancient" directory in the chain data directory.
+	AncientFreezer          bool   `json:"ancient-freezer-enabled"`
+	AncientFreezerDirectory string `json:"ancient-freezer-directory"`
+	AncientFreezerThreshold uint64 `json:"ancient-freezer-threshold"`
 
 	// SkipUpgradeCheck disables checking that upgrades must take place before the last
 	// accepted block. Skipping this check is useful when a node operator does not update
@@ -237,6 +291,34 @@ type Config struct {
 	// TxLookupLimit can be still used to control unindexing old transactions.
 	SkipTxIndexing bool `json:"skip-tx-indexing"`
 
+	// DaemonMintIndexing persists the daemon invocations of accepted blocks
+	// so they can be served by flare_getDaemonMints.
+	DaemonMintIndexing bool `json:"daemon-mint-indexing"`
+
+	// StateConnectorVoteIndexing persists the default attestor votes of
+	// finalised state connector rounds so they can be served by
+	// stateConnector_getRoundVotes.
+	StateConnectorVoteIndexing bool `json:"state-connector-vote-indexing"`
+
+	// FlatTraceIndexing traces accepted blocks with the flatCallTracer and
+	// persists the results so they can be served by trace_filter,
+	// trace_block and trace_transaction. Indexing starts from the last
+	// accepted block when first enabled, earlier blocks are not traced.
+	FlatTraceIndexing bool `json:"flat-trace-indexing"`
+
+	// AddressIndexing maintains an index of the transactions sent or received
+	// by each address, bounded by TransactionHistory (unbounded if 0), so it
+	// can be served by eth_getTransactionsByAddress.
+	AddressIndexing bool `json:"address-indexing"`
+	// AddressIndexInternalCalls extends the address index with the addresses
+	// taking part in internal calls.
+	AddressIndexInternalCalls bool `json:"address-index-internal-calls"`
+
+	// AtomicTxAddressIndexing maintains an index of the accepted atomic txs
+	// each address took part in, so it can be served by
+	// avax.getAtomicTxsByAddress.
+	AtomicTxAddressIndexing bool `json:"atomic-tx-address-indexing"`
+
 	// WarpOffChainMessages encodes off-chain messages (unrelated to any on-chain event ie. block or AddressedCall)
 	// that the node should be willing to sign.
 	// Note: only supports AddressedCall payloads as defined here:
@@ -278,11 +360,13 @@ func (c *Config) SetDefaults(txPoolConfig TxPoolConfig) {
 	c.TxPoolAccountQueue = txPoolConfig.AccountQueue
 	c.TxPoolGlobalQueue = txPoolConfig.GlobalQueue
 	c.TxPoolLifetime.Duration = txPoolConfig.Lifetime
+	c.AtomicMempoolRejournal.Duration = defaultAtomicMempoolRejournal
 
 	c.APIMaxDuration.Duration = defaultApiMaxDuration
 	c.WSCPURefillRate.Duration = defaultWsCpuRefillRate
 	c.WSCPUMaxStored.Duration = defaultWsCpuMaxStored
 	c.MaxBlocksPerRequest = defaultMaxBlocksPerRequest
+	c.LogsReplayLimit = defaultLogsReplayLimit
 	c.ContinuousProfilerFrequency.Duration = defaultContinuousProfilerFrequency
 	c.ContinuousProfilerMaxFiles = defaultContinuousProfilerMaxFiles
 	c.Pruning = defaultPruningEnabled
@@ -308,12 +392,17 @@ func (c *Config) SetDefaults(txPoolConfig TxPoolConfig) {
 	c.MaxOutboundActiveRequests = defaultMaxOutboundActiveRequests
 	c.PopulateMissingTriesParallelism = defaultPopulateMissingTriesParallelism
 	c.StateSyncServerTrieCache = defaultStateSyncServerTrieCache
+	c.StateSyncServerPeerPeriod.Duration = defaultStateSyncServerPeerPeriod
+	c.StateSyncServerValidatorMultiplier = defaultStateSyncValidatorMultiplier
 	c.StateSyncCommitInterval = defaultSyncableCommitInterval
 	c.StateSyncMinBlocks = defaultStateSyncMinBlocks
 	c.StateSyncRequestSize = DefaultStateSyncRequestSize
 	c.AllowUnprotectedTxHashes = defaultAllowUnprotectedTxHashes
 	c.AcceptedCacheSize = defaultAcceptedCacheSize
 	c.HistoricalProofQueryWindow = defaultHistoricalProofQueryWindow
+	c.HistoricalStateReexec = defaultHistoricalStateReexec
+	c.HistoricalStateCache = defaultHistoricalStateCache
+	c.AncientFreezerThreshold = defaultAncientFreezerThreshold
 
 	// Price Option Settings
 	c.PriceOptionSlowFeePercentage = defaultPriceOptionSlowFeePercentage
@@ -368,6 +457,33 @@ func (c *Config) Validate(networkID uint32) error {
 		return fmt.Errorf("cannot use commit interval of 0 with pruning enabled")
 	}
 
+	if c.AddressIndexInternalCalls && !c.AddressIndexing {
+		return fmt.Errorf("cannot enable address-index-internal-calls while address-indexing is disabled")
+	}
+
+	if c.HistoricalStateRegeneration && c.HistoricalStateCache <= 0 {
+		return fmt.Errorf("historical-state-cache must be positive with historical state regeneration enabled, got %d", c.HistoricalStateCache)
+	}
+
+	if (c.StateSyncServerPeerBytes > 0 || c.StateSyncServerPeerTime.Duration > 0) && c.StateSyncServerPeerPeriod.Duration <= 0 {
+		return fmt.Errorf("state-sync-server-peer-period must be positive with state sync server peer budgets, got %s", c.StateSyncServerPeerPeriod)
+	}
+	if c.StateSyncServerPeerTime.Duration < 0 {
+		return fmt.Errorf("state-sync-server-peer-time must not be negative, got %s", c.StateSyncServerPeerTime)
+	}
+
+	if len(c.StateSyncFile) > 0 && c.StateSyncFileSummaryID == ids.Empty {
+		return fmt.Errorf("state-sync-file-summary-id must be set with a state sync file")
+	}
+
+	if c.AncientFreezer && c.AncientFreezerThreshold == 0 {
+		return fmt.Errorf("ancient-freezer-threshold must be positive with the ancient freezer enabled")
+	}
+
+	if c.AtomicMempoolJournal && c.AtomicMempoolRejournal.Duration <= 0 {
+		return fmt.Errorf("atomic-mempool-rejournal must be positive with the atomic mempool journal enabled, got %s", c.AtomicMempoolRejournal)
+	}
+
 	if c.PushGossipPercentStake < 0 || c.PushGossipPercentStake > 1 {
 		return fmt.Errorf("push-gossip-percent-stake is %f but must be in the range [0, 1]", c.PushGossipPercentStake)
 	}
@@ -5,17 +5,21 @@ package evm
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"path/filepath"
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/vms/components/chain"
 	"github.com/ava-labs/coreth/plugin/evm/atomic"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // shows that a locally generated AtomicTx can be added to mempool and then
@@ -153,3 +157,61 @@ func TestMempoolPriorityDrop(t *testing.T) {
 	assert.False(mempool.Has(tx2.ID()))
 	assert.True(mempool.Has(tx3.ID()))
 }
+
+// shows that locally issued atomic txs that are not yet accepted are restored
+// from the atomic mempool journal after a restart
+func TestAtomicMempoolJournal(t *testing.T) {
+	require := require.New(t)
+
+	importAmount := uint64(50000000)
+	configJSON := fmt.Sprintf(`{"atomic-mempool-journal-enabled":true,"atomic-mempool-journal-path":%q}`, filepath.Join(t.TempDir(), "atomic_transactions.rlp"))
+	issuer, vm, dbManager, _, appSender := GenesisVMWithUTXOs(t, true, genesisJSONApricotPhase2, configJSON, "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+		testShortIDAddrs[1]: importAmount,
+	})
+
+	var txs []*atomic.Tx
+	for _, key := range testKeys[:2] {
+		tx, err := vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{key})
+		require.NoError(err)
+		require.NoError(vm.mempool.AddLocalTx(tx))
+		txs = append(txs, tx)
+	}
+	<-issuer
+
+	// Accept a block with one of the txs
+	blk, err := vm.BuildBlock(context.Background())
+	require.NoError(err)
+	require.NoError(blk.Verify(context.Background()))
+	require.NoError(vm.SetPreference(context.Background(), blk.ID()))
+	require.NoError(blk.Accept(context.Background()))
+	accepted := blk.(*chain.BlockWrapper).Block.(*Block).atomicTxs
+	require.Len(accepted, 1)
+	pending := txs[0]
+	if pending.ID() == accepted[0].ID() {
+		pending = txs[1]
+	}
+	require.NoError(vm.Shutdown(context.Background()))
+
+	resetMetrics(vm)
+	restartedVM := &VM{}
+	require.NoError(restartedVM.Initialize(
+		context.Background(),
+		vm.ctx,
+		dbManager,
+		[]byte(genesisJSONApricotPhase2),
+		[]byte(""),
+		[]byte(configJSON),
+		issuer,
+		[]*commonEng.Fx{},
+		appSender,
+	))
+	defer func() {
+		require.NoError(restartedVM.Shutdown(context.Background()))
+	}()
+	require.NoError(restartedVM.SetState(context.Background(), snow.Bootstrapping))
+	require.NoError(restartedVM.SetState(context.Background(), snow.NormalOp))
+
+	require.True(restartedVM.mempool.Has(pending.ID()))
+	require.False(restartedVM.mempool.Has(accepted[0].ID()))
+}
@@ -30,8 +30,9 @@ type RequestHandler interface {
 type ResponseHandler interface {
 	// OnResponse is invoked when the peer responded to a request
 	OnResponse(response []byte) error
-	// OnFailure is invoked when there was a failure in processing a request
-	OnFailure() error
+	// OnFailure is invoked when there was a failure in processing a request,
+	// with the error the peer responded with, if any
+	OnFailure(err error) error
 }
 
 type NoopRequestHandler struct{}
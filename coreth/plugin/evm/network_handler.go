@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
 	"github.com/ava-labs/coreth/plugin/evm/message"
 	syncHandlers "github.com/ava-labs/coreth/sync/handlers"
 	syncStats "github.com/ava-labs/coreth/sync/handlers/stats"
@@ -26,6 +27,7 @@ type networkHandler struct {
 	blockRequestHandler           *syncHandlers.BlockRequestHandler
 	codeRequestHandler            *syncHandlers.CodeRequestHandler
 	signatureRequestHandler       *warpHandlers.SignatureRequestHandler
+	syncThrottler                 *syncHandlers.Throttler
 }
 
 // newNetworkHandler constructs the handler for serving network requests.
@@ -36,6 +38,8 @@ func newNetworkHandler(
 	atomicTrieDB *triedb.Database,
 	warpBackend warp.Backend,
 	networkCodec codec.Manager,
+	syncThrottlerConfig syncHandlers.ThrottlerConfig,
+	validators p2p.ValidatorSet,
 ) message.RequestHandler {
 	syncStats := syncStats.NewHandlerStats(metrics.Enabled)
 	return &networkHandler{
@@ -44,23 +48,32 @@ func newNetworkHandler(
 		blockRequestHandler:           syncHandlers.NewBlockRequestHandler(provider, networkCodec, syncStats),
 		codeRequestHandler:            syncHandlers.NewCodeRequestHandler(diskDB, networkCodec, syncStats),
 		signatureRequestHandler:       warpHandlers.NewSignatureRequestHandler(warpBackend, networkCodec),
+		syncThrottler:                 syncHandlers.NewThrottler(syncThrottlerConfig, validators, syncStats),
 	}
 }
 
 func (n networkHandler) HandleStateTrieLeafsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, leafsRequest message.LeafsRequest) ([]byte, error) {
-	return n.stateTrieLeafsRequestHandler.OnLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+	return n.syncThrottler.Throttle(ctx, nodeID, func() ([]byte, error) {
+		return n.stateTrieLeafsRequestHandler.OnLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+	})
 }
 
 func (n networkHandler) HandleAtomicTrieLeafsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, leafsRequest message.LeafsRequest) ([]byte, error) {
-	return n.atomicTrieLeafsRequestHandler.OnLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+	return n.syncThrottler.Throttle(ctx, nodeID, func() ([]byte, error) {
+		return n.atomicTrieLeafsRequestHandler.OnLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+	})
 }
 
 func (n networkHandler) HandleBlockRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockRequest message.BlockRequest) ([]byte, error) {
-	return n.blockRequestHandler.OnBlockRequest(ctx, nodeID, requestID, blockRequest)
+	return n.syncThrottler.Throttle(ctx, nodeID, func() ([]byte, error) {
+		return n.blockRequestHandler.OnBlockRequest(ctx, nodeID, requestID, blockRequest)
+	})
 }
 
 func (n networkHandler) HandleCodeRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, codeRequest message.CodeRequest) ([]byte, error) {
-	return n.codeRequestHandler.OnCodeRequest(ctx, nodeID, requestID, codeRequest)
+	return n.syncThrottler.Throttle(ctx, nodeID, func() ([]byte, error) {
+		return n.codeRequestHandler.OnCodeRequest(ctx, nodeID, requestID, codeRequest)
+	})
 }
 
 func (n networkHandler) HandleMessageSignatureRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, messageSignatureRequest message.MessageSignatureRequest) ([]byte, error) {
@@ -0,0 +1,653 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	avalancheatomic "github.com/ava-labs/avalanchego/chains/atomic"
+	avalanchedatabase "github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+
+	"github.com/ava-labs/coreth/consensus/dummy"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/core/vm"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm/atomic"
+	"github.com/ava-labs/coreth/plugin/evm/database"
+	"github.com/ava-labs/coreth/trie"
+)
+
+const (
+	segmentManifestFile    = "manifest.json"
+	segmentManifestVersion = 1
+)
+
+var (
+	errNoSegmentManifest      = errors.New("segment manifest not found")
+	errSegmentChecksum        = errors.New("segment checksum mismatch")
+	errSegmentManifestChanged = errors.New("segment manifest does not match the requested export")
+)
+
+// SegmentManifest describes a range of accepted blocks exported into chunks
+// of consecutive blocks. Chunks are listed in order, each with the SHA-256
+// checksum of its file, and only once the file is completely written.
+type SegmentManifest struct {
+	Version        int            `json:"version"`
+	GenesisHash    common.Hash    `json:"genesisHash"`
+	From           uint64         `json:"from"`
+	To             uint64         `json:"to"`
+	ChunkSize      uint64         `json:"chunkSize"`
+	CommitInterval uint64         `json:"commitInterval"`
+	Chunks         []SegmentChunk `json:"chunks"`
+}
+
+// SegmentChunk is a file of the blocks [First, Last] of a segment.
+type SegmentChunk struct {
+	First    uint64      `json:"first"`
+	Last     uint64      `json:"last"`
+	File     string      `json:"file"`
+	Checksum common.Hash `json:"sha256"`
+}
+
+// segmentEntry is the RLP encoded record of a block in a chunk file.
+type segmentEntry struct {
+	Block    rlp.RawValue
+	Receipts rlp.RawValue // receipts in their storage encoding
+	// AtomicTxs holds the atomic txs indexed at the height of the block in
+	// the atomic tx repository, empty if there are none.
+	AtomicTxs []byte
+	// AtomicRoot is the root of the atomic trie committed at the height of
+	// the block, empty if it is not a commit height.
+	AtomicRoot common.Hash
+}
+
+// SegmentExportConfig is the range of accepted blocks to export and how to
+// split it into chunks.
+type SegmentExportConfig struct {
+	From      uint64
+	To        uint64
+	ChunkSize uint64
+	// CommitInterval is the atomic trie commit interval of the exported
+	// chain, whose committed roots are exported for verification.
+	CommitInterval uint64
+	// AncientDirectory is the root directory of the ancient freezer of the
+	// VM, if it is enabled.
+	AncientDirectory string
+}
+
+// SegmentImportConfig identifies the chain segments are imported into.
+type SegmentImportConfig struct {
+	// SnowCtx provides the network, chain and AVAX asset IDs of the chain.
+	SnowCtx *snow.Context
+	Genesis []byte
+	Upgrade []byte
+	// AncientDirectory is the root directory of the ancient freezer of the
+	// VM, if it is enabled.
+	AncientDirectory string
+}
+
+// segmentDatabases are the parts of the VM database that segments are read
+// from and written to, laid out as by [VM.initializeDBs].
+type segmentDatabases struct {
+	chaindb          ethdb.Database
+	versiondb        *versiondb.Database
+	acceptedBlockDB  avalanchedatabase.Database
+	atomicHeightTxDB avalanchedatabase.Database
+	atomicTrieMetaDB avalanchedatabase.Database
+}
+
+// newSegmentDatabases opens the VM database [db], with the ancient freezer in
+// [ancient] if it is set. The freezer does not freeze blocks, which the VM does
+// once it restarts.
+func newSegmentDatabases(db avalanchedatabase.Database, ancient string) (*segmentDatabases, error) {
+	kvdb := database.WrapDatabase(prefixdb.NewNested(ethDBPrefix, db))
+	chaindb := rawdb.NewDatabase(kvdb)
+	if ancient != "" {
+		var err error
+		chaindb, err = rawdb.NewDatabaseWithFreezer(kvdb, ancient, ancientFreezerNamespace, false, math.MaxUint64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open ancient freezer at %s: %w", ancient, err)
+		}
+	}
+	vdb := versiondb.New(db)
+	return &segmentDatabases{
+		chaindb:          chaindb,
+		versiondb:        vdb,
+		acceptedBlockDB:  prefixdb.New(acceptedPrefix, vdb),
+		atomicHeightTxDB: prefixdb.New(atomicHeightTxDBPrefix, vdb),
+		atomicTrieMetaDB: prefixdb.New(atomicTrieMetaDBPrefix, vdb),
+	}, nil
+}
+
+// close closes the chain database, including its ancient freezer.
+func (dbs *segmentDatabases) close() error {
+	return dbs.chaindb.Close()
+}
+
+// lastAccepted returns the last accepted block, which is the genesis block if
+// the VM never accepted a block.
+func (dbs *segmentDatabases) lastAccepted() (common.Hash, uint64, error) {
+	lastAcceptedBytes, err := dbs.acceptedBlockDB.Get(lastAcceptedKey)
+	switch {
+	case err == avalanchedatabase.ErrNotFound:
+		return rawdb.ReadCanonicalHash(dbs.chaindb, 0), 0, nil
+	case err != nil:
+		return common.Hash{}, 0, fmt.Errorf("failed to get last accepted block ID due to: %w", err)
+	case len(lastAcceptedBytes) != common.HashLength:
+		return common.Hash{}, 0, fmt.Errorf("last accepted bytes should have been length %d, but found %d", common.HashLength, len(lastAcceptedBytes))
+	}
+	hash := common.BytesToHash(lastAcceptedBytes)
+	height := rawdb.ReadHeaderNumber(dbs.chaindb, hash)
+	if height == nil {
+		return common.Hash{}, 0, fmt.Errorf("failed to retrieve header number of last accepted block: %s", hash)
+	}
+	return hash, *height, nil
+}
+
+// ExportSegments exports the accepted blocks in the range of [config] from
+// the VM database [db] into checksummed chunk files in [dir], along with their
+// receipts, the atomic txs indexed at their heights and the atomic trie roots
+// committed at them. It resumes an interrupted export into [dir], re-writing
+// any chunk whose file does not match its checksum.
+func ExportSegments(db avalanchedatabase.Database, dir string, config SegmentExportConfig) error {
+	if config.From == 0 || config.From > config.To {
+		return fmt.Errorf("invalid block range [%d, %d]", config.From, config.To)
+	}
+	if config.ChunkSize == 0 {
+		return errors.New("chunk size must be positive")
+	}
+	dbs, err := newSegmentDatabases(db, config.AncientDirectory)
+	if err != nil {
+		return err
+	}
+	defer dbs.close()
+
+	_, lastAcceptedHeight, err := dbs.lastAccepted()
+	if err != nil {
+		return err
+	}
+	if config.To > lastAcceptedHeight {
+		return fmt.Errorf("block %d is after the last accepted block %d", config.To, lastAcceptedHeight)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	manifest := &SegmentManifest{
+		Version:        segmentManifestVersion,
+		GenesisHash:    rawdb.ReadCanonicalHash(dbs.chaindb, 0),
+		From:           config.From,
+		To:             config.To,
+		ChunkSize:      config.ChunkSize,
+		CommitInterval: config.CommitInterval,
+	}
+	switch existing, err := ReadSegmentManifest(dir); {
+	case errors.Is(err, errNoSegmentManifest):
+	case err != nil:
+		return err
+	default:
+		if existing.GenesisHash != manifest.GenesisHash || existing.From != manifest.From || existing.To != manifest.To ||
+			existing.ChunkSize != manifest.ChunkSize || existing.CommitInterval != manifest.CommitInterval {
+			return fmt.Errorf("%w in %s", errSegmentManifestChanged, dir)
+		}
+		// Keep the chunks written by the interrupted export up to the
+		// first one that is missing or corrupted.
+		for _, chunk := range existing.Chunks {
+			if err := verifySegmentChunk(dir, chunk); err != nil {
+				log.Warn("Re-exporting chunk", "first", chunk.First, "last", chunk.Last, "err", err)
+				break
+			}
+			manifest.Chunks = append(manifest.Chunks, chunk)
+		}
+		log.Info("Resuming segment export", "chunks", len(manifest.Chunks))
+	}
+
+	start := config.From
+	if n := len(manifest.Chunks); n > 0 {
+		start = manifest.Chunks[n-1].Last + 1
+	}
+	for first := start; first <= config.To; first += config.ChunkSize {
+		last := min(first+config.ChunkSize-1, config.To)
+		chunk, err := exportSegmentChunk(dbs, dir, first, last, config.CommitInterval)
+		if err != nil {
+			return fmt.Errorf("failed to export blocks [%d, %d]: %w", first, last, err)
+		}
+		manifest.Chunks = append(manifest.Chunks, chunk)
+		if err := writeSegmentManifest(dir, manifest); err != nil {
+			return err
+		}
+		log.Info("Exported chunk", "first", first, "last", last, "file", chunk.File)
+	}
+	return nil
+}
+
+// exportSegmentChunk writes the blocks [first, last] into a new chunk file.
+// The file is only moved into place once it is completely written.
+func exportSegmentChunk(dbs *segmentDatabases, dir string, first, last uint64, commitInterval uint64) (SegmentChunk, error) {
+	chunk := SegmentChunk{
+		First: first,
+		Last:  last,
+		File:  fmt.Sprintf("%012d-%012d.rlp.gz", first, last),
+	}
+	f, err := os.CreateTemp(dir, chunk.File+".*.tmp")
+	if err != nil {
+		return chunk, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	var (
+		hasher = sha256.New()
+		gz     = gzip.NewWriter(io.MultiWriter(f, hasher))
+		parent = rawdb.ReadCanonicalHash(dbs.chaindb, first-1)
+	)
+	for number := first; number <= last; number++ {
+		hash := rawdb.ReadCanonicalHash(dbs.chaindb, number)
+		block := rawdb.ReadBlock(dbs.chaindb, hash, number)
+		if block == nil {
+			return chunk, fmt.Errorf("block %d not found", number)
+		}
+		if block.ParentHash() != parent {
+			return chunk, fmt.Errorf("block %d is not a child of the canonical block %d", number, number-1)
+		}
+		parent = hash
+
+		entry := segmentEntry{Receipts: rawdb.ReadReceiptsRLP(dbs.chaindb, hash, number)}
+		if entry.Block, err = rlp.EncodeToBytes(block); err != nil {
+			return chunk, err
+		}
+		if len(entry.Receipts) == 0 {
+			if block.ReceiptHash() != types.EmptyReceiptsHash {
+				return chunk, fmt.Errorf("receipts of block %d not found", number)
+			}
+			entry.Receipts = rlp.EmptyList
+		}
+		entry.AtomicTxs, err = dbs.atomicHeightTxDB.Get(avalanchedatabase.PackUInt64(number))
+		if err != nil && err != avalanchedatabase.ErrNotFound {
+			return chunk, err
+		}
+		if commitInterval > 0 && number%commitInterval == 0 {
+			if entry.AtomicRoot, err = getRoot(dbs.atomicTrieMetaDB, number); err != nil {
+				return chunk, err
+			}
+		}
+		if err := rlp.Encode(gz, &entry); err != nil {
+			return chunk, err
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return chunk, err
+	}
+	if err := f.Sync(); err != nil {
+		return chunk, err
+	}
+	if err := f.Close(); err != nil {
+		return chunk, err
+	}
+	if err := os.Rename(f.Name(), filepath.Join(dir, chunk.File)); err != nil {
+		return chunk, err
+	}
+	chunk.Checksum = common.BytesToHash(hasher.Sum(nil))
+	return chunk, nil
+}
+
+// ReadSegmentManifest reads the manifest of the segment exported into [dir].
+func ReadSegmentManifest(dir string) (*SegmentManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, segmentManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w in %s", errNoSegmentManifest, dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	manifest := new(SegmentManifest)
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse segment manifest: %w", err)
+	}
+	if manifest.Version != segmentManifestVersion {
+		return nil, fmt.Errorf("unsupported segment manifest version %d", manifest.Version)
+	}
+	return manifest, nil
+}
+
+// writeSegmentManifest replaces the manifest in [dir] with [manifest].
+func writeSegmentManifest(dir string, manifest *SegmentManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(dir, segmentManifestFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(dir, segmentManifestFile))
+}
+
+// verifySegmentChunk checks the file of [chunk] matches its checksum.
+func verifySegmentChunk(dir string, chunk SegmentChunk) error {
+	f, err := os.Open(filepath.Join(dir, chunk.File))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return err
+	}
+	if checksum := common.BytesToHash(hasher.Sum(nil)); checksum != chunk.Checksum {
+		return fmt.Errorf("%w: %s has checksum %s, expected %s", errSegmentChecksum, chunk.File, checksum, chunk.Checksum)
+	}
+	return nil
+}
+
+// segmentImporter executes and accepts the blocks of a segment on top of the
+// last accepted block of a VM database.
+type segmentImporter struct {
+	ctx           *snow.Context
+	chainConfig   *params.ChainConfig
+	dbs           *segmentDatabases
+	chain         *core.BlockChain
+	atomicBackend AtomicBackend
+}
+
+// ImportSegments imports the segment exported into [dir] into the VM database
+// [db], continuing from its last accepted block so that an interrupted import
+// resumes where it stopped. The checksum of every chunk is checked before its
+// blocks are read. Every block is verified, executed and accepted as the VM
+// would, except that atomic txs are checked against the exported atomic tx
+// repository and atomic trie roots rather than the UTXOs in shared memory.
+// Their atomic operations are applied to [sharedMemory].
+func ImportSegments(db avalanchedatabase.Database, sharedMemory avalancheatomic.SharedMemory, dir string, config SegmentImportConfig) error {
+	manifest, err := ReadSegmentManifest(dir)
+	if err != nil {
+		return err
+	}
+	if n := len(manifest.Chunks); n == 0 || manifest.Chunks[0].First != manifest.From || manifest.Chunks[n-1].Last != manifest.To {
+		return fmt.Errorf("segment in %s is incomplete", dir)
+	}
+	for i := 1; i < len(manifest.Chunks); i++ {
+		if manifest.Chunks[i].First != manifest.Chunks[i-1].Last+1 {
+			return fmt.Errorf("segment in %s is missing blocks after %d", dir, manifest.Chunks[i-1].Last)
+		}
+	}
+	g, err := parseGenesis(config.SnowCtx, config.Genesis, config.Upgrade)
+	if err != nil {
+		return fmt.Errorf("failed to parse genesis: %w", err)
+	}
+	if genesisHash := g.ToBlock().Hash(); genesisHash != manifest.GenesisHash {
+		return fmt.Errorf("segment genesis %s does not match genesis %s", manifest.GenesisHash, genesisHash)
+	}
+
+	dbs, err := newSegmentDatabases(db, config.AncientDirectory)
+	if err != nil {
+		return err
+	}
+	defer dbs.close()
+
+	lastAcceptedHash, lastAcceptedHeight, err := dbs.lastAccepted()
+	if err != nil {
+		return err
+	}
+	if lastAcceptedHash == (common.Hash{}) {
+		lastAcceptedHash = manifest.GenesisHash
+	}
+	if lastAcceptedHeight+1 < manifest.From {
+		return fmt.Errorf("segment starts at block %d, after the last accepted block %d", manifest.From, lastAcceptedHeight)
+	}
+	if lastAcceptedHeight >= manifest.To {
+		if hash := rawdb.ReadCanonicalHash(dbs.chaindb, manifest.To); hash == (common.Hash{}) {
+			return fmt.Errorf("block %d not found", manifest.To)
+		}
+		log.Info("Segment already imported", "lastAccepted", lastAcceptedHeight)
+		return nil
+	}
+
+	importer := &segmentImporter{
+		ctx: config.SnowCtx,
+		dbs: dbs,
+	}
+	cacheConfig := *core.DefaultCacheConfig
+	cacheConfig.CommitInterval = manifest.CommitInterval
+	cacheConfig.SnapshotLimit = 0
+	engine := dummy.NewDummyEngine(
+		dummy.ConsensusCallbacks{OnExtraStateChange: importer.onExtraStateChange},
+		dummy.Mode{},
+		&mockable.Clock{},
+		nil,
+	)
+	importer.chain, err = core.NewBlockChain(dbs.chaindb, &cacheConfig, g, engine, vm.Config{}, lastAcceptedHash, false)
+	if err != nil {
+		return err
+	}
+	defer importer.chain.Stop()
+	importer.chainConfig = importer.chain.Config()
+
+	var bonusBlocks map[uint64]ids.ID
+	if config.SnowCtx.NetworkID == constants.MainnetID {
+		if bonusBlocks, err = readMainnetBonusBlocks(); err != nil {
+			return fmt.Errorf("failed to read mainnet bonus blocks: %w", err)
+		}
+	}
+	repo, err := NewAtomicTxRepository(dbs.versiondb, atomic.Codec, lastAcceptedHeight)
+	if err != nil {
+		return fmt.Errorf("failed to create atomic repository: %w", err)
+	}
+	importer.atomicBackend, err = NewAtomicBackend(
+		dbs.versiondb, sharedMemory, bonusBlocks,
+		repo, lastAcceptedHeight, lastAcceptedHash,
+		manifest.CommitInterval,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create atomic backend: %w", err)
+	}
+
+	log.Info("Importing segment", "from", lastAcceptedHeight+1, "to", manifest.To)
+	var (
+		start  = time.Now()
+		logged = time.Now()
+	)
+	for _, chunk := range manifest.Chunks {
+		if chunk.Last <= lastAcceptedHeight {
+			continue
+		}
+		if err := importer.importChunk(dir, chunk, lastAcceptedHash, lastAcceptedHeight); err != nil {
+			return fmt.Errorf("failed to import %s: %w", chunk.File, err)
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Importing segment", "number", chunk.Last, "remaining", manifest.To-chunk.Last, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	importer.chain.DrainAcceptorQueue()
+	log.Info("Imported segment", "to", manifest.To, "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// importChunk imports the blocks of [chunk] after the last accepted block.
+func (i *segmentImporter) importChunk(dir string, chunk SegmentChunk, lastAcceptedHash common.Hash, lastAcceptedHeight uint64) error {
+	if err := verifySegmentChunk(dir, chunk); err != nil {
+		return err
+	}
+	f, err := os.Open(filepath.Join(dir, chunk.File))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	stream := rlp.NewStream(gz, 0)
+	for number := chunk.First; number <= chunk.Last; number++ {
+		var entry segmentEntry
+		if err := stream.Decode(&entry); err != nil {
+			return fmt.Errorf("failed to decode block %d: %w", number, err)
+		}
+		block := new(types.Block)
+		if err := rlp.DecodeBytes(entry.Block, block); err != nil {
+			return fmt.Errorf("failed to decode block %d: %w", number, err)
+		}
+		if block.NumberU64() != number {
+			return fmt.Errorf("found block %d instead of block %d", block.NumberU64(), number)
+		}
+		switch {
+		case number < lastAcceptedHeight:
+		case number == lastAcceptedHeight:
+			if block.Hash() != lastAcceptedHash {
+				return fmt.Errorf("block %d (%s) does not match the last accepted block %s", number, block.Hash(), lastAcceptedHash)
+			}
+		default:
+			if err := i.importBlock(block, &entry); err != nil {
+				return fmt.Errorf("block %d (%s): %w", number, block.Hash(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// importBlock verifies, executes and accepts [block].
+func (i *segmentImporter) importBlock(block *types.Block, entry *segmentEntry) error {
+	if parent := i.chain.LastConsensusAcceptedBlock(); block.ParentHash() != parent.Hash() {
+		return fmt.Errorf("parent %s is not the last accepted block %s", block.ParentHash(), parent.Hash())
+	}
+	if err := i.verifyBlock(block, entry.Receipts); err != nil {
+		return err
+	}
+
+	rules := i.chainConfig.Rules(block.Number(), block.Time())
+	txs, err := atomic.ExtractAtomicTxs(block.ExtData(), rules.IsApricotPhase5, atomic.Codec)
+	if err != nil {
+		return err
+	}
+	if err := verifySegmentAtomicTxs(txs, entry.AtomicTxs); err != nil {
+		return err
+	}
+	atomicRoot, err := i.atomicBackend.InsertTxs(block.Hash(), block.NumberU64(), block.ParentHash(), txs)
+	if err != nil {
+		return err
+	}
+	if entry.AtomicRoot != (common.Hash{}) && atomicRoot != entry.AtomicRoot {
+		return fmt.Errorf("atomic trie root %s does not match the exported root %s", atomicRoot, entry.AtomicRoot)
+	}
+	if err := i.chain.InsertBlock(block); err != nil {
+		return err
+	}
+	return i.accept(block)
+}
+
+// accept accepts [block] as [Block.Accept] does.
+func (i *segmentImporter) accept(block *types.Block) error {
+	defer i.dbs.versiondb.Abort()
+
+	if err := i.chain.Accept(block); err != nil {
+		return fmt.Errorf("chain could not accept %s: %w", block.Hash(), err)
+	}
+	hash := block.Hash()
+	if err := i.dbs.acceptedBlockDB.Put(lastAcceptedKey, hash[:]); err != nil {
+		return fmt.Errorf("failed to put %s as the last accepted block: %w", hash, err)
+	}
+	atomicState, err := i.atomicBackend.GetVerifiedAtomicState(hash)
+	if err != nil {
+		return err
+	}
+	vdbBatch, err := i.dbs.versiondb.CommitBatch()
+	if err != nil {
+		return fmt.Errorf("could not create commit batch processing block[%s]: %w", hash, err)
+	}
+	return atomicState.Accept(vdbBatch, nil)
+}
+
+// verifyBlock checks the body of [block] and its exported [receipts] match
+// its header. The rest of the block is verified by executing it.
+func (i *segmentImporter) verifyBlock(block *types.Block, receipts rlp.RawValue) error {
+	header := block.Header()
+	if i.chainConfig.IsApricotPhase1(header.Time) {
+		if hash := types.CalcExtDataHash(block.ExtData()); header.ExtDataHash != hash {
+			return fmt.Errorf("extra data hash mismatch: have %x, want %x", header.ExtDataHash, hash)
+		}
+	} else if header.ExtDataHash != (common.Hash{}) {
+		return fmt.Errorf("expected ExtDataHash to be empty but got %x", header.ExtDataHash)
+	}
+	if hash := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); hash != header.TxHash {
+		return fmt.Errorf("invalid txs hash %v does not match calculated txs hash %v", header.TxHash, hash)
+	}
+	if hash := types.CalcUncleHash(block.Uncles()); hash != header.UncleHash {
+		return fmt.Errorf("invalid uncle hash %v does not match calculated uncle hash %v", header.UncleHash, hash)
+	}
+
+	var stored []*types.ReceiptForStorage
+	if err := rlp.DecodeBytes(receipts, &stored); err != nil {
+		return fmt.Errorf("failed to decode receipts: %w", err)
+	}
+	decoded := make(types.Receipts, len(stored))
+	for i, receipt := range stored {
+		decoded[i] = (*types.Receipt)(receipt)
+	}
+	if err := decoded.DeriveFields(i.chainConfig, block.Hash(), block.NumberU64(), block.Time(), block.BaseFee(), nil, block.Transactions()); err != nil {
+		return fmt.Errorf("failed to derive receipt fields: %w", err)
+	}
+	if hash := types.DeriveSha(decoded, trie.NewStackTrie(nil)); hash != header.ReceiptHash {
+		return fmt.Errorf("exported receipts hash %v does not match receipt hash %v", hash, header.ReceiptHash)
+	}
+	return nil
+}
+
+// verifySegmentAtomicTxs checks the atomic [txs] of a block are the ones
+// indexed at its height in the exported atomic tx repository.
+func verifySegmentAtomicTxs(txs []*atomic.Tx, indexed []byte) error {
+	var indexedTxs []*atomic.Tx
+	if len(indexed) > 0 {
+		var err error
+		if indexedTxs, err = atomic.ExtractAtomicTxsBatch(indexed, atomic.Codec); err != nil {
+			return fmt.Errorf("failed to parse indexed atomic txs: %w", err)
+		}
+	}
+	sortedIDs := func(txs []*atomic.Tx) []ids.ID {
+		txIDs := make([]ids.ID, len(txs))
+		for i, tx := range txs {
+			txIDs[i] = tx.ID()
+		}
+		slices.SortFunc(txIDs, ids.ID.Compare)
+		return txIDs
+	}
+	if have, want := sortedIDs(txs), sortedIDs(indexedTxs); !slices.Equal(have, want) {
+		return fmt.Errorf("atomic txs %v do not match the indexed atomic txs %v", have, want)
+	}
+	return nil
+}
+
+// onExtraStateChange applies the atomic txs of [block] to [state]. They are
+// checked against the exported atomic tx repository before [block] is
+// executed, and are not verified against shared memory.
+func (i *segmentImporter) onExtraStateChange(block *types.Block, parent *types.Header, state *state.StateDB) (*big.Int, *big.Int, error) {
+	return ApplyAtomicTxs(i.ctx, i.chainConfig, block, parent, state)
+}
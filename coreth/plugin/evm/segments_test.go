@@ -0,0 +1,245 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/plugin/evm/atomic"
+	"github.com/ava-labs/coreth/predicate"
+)
+
+const segmentsTestConfig = `{"commit-interval": 4}`
+
+// segmentsTestSource is a stopped VM whose accepted blocks include atomic txs.
+type segmentsTestSource struct {
+	db         database.Database
+	lastHash   common.Hash
+	lastHeight uint64
+	balance    *uint256.Int // balance of testEthAddrs[1]
+	importTx   *atomic.Tx
+	exportTx   *atomic.Tx
+}
+
+func newSegmentsTestSource(t *testing.T, importAmount uint64, numBlocks int, configJSON string) *segmentsTestSource {
+	require := require.New(t)
+	alloc := map[ids.ShortID]uint64{testShortIDAddrs[0]: importAmount}
+	_, vm, db, _, _ := GenesisVMWithUTXOs(t, true, "", configJSON, "", alloc)
+
+	source := &segmentsTestSource{db: db}
+	generateAndAcceptBlocks(t, vm, numBlocks, func(i int, gen *core.BlockGen) {
+		b, err := predicate.NewResults().Bytes()
+		require.NoError(err)
+		gen.AppendExtra(b)
+		switch i {
+		case 0:
+			source.importTx, err = vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, []*secp256k1.PrivateKey{testKeys[0]})
+			require.NoError(err)
+			require.NoError(vm.mempool.AddLocalTx(source.importTx))
+		case 1:
+			source.exportTx, err = vm.newExportTx(
+				vm.ctx.AVAXAssetID,
+				importAmount/2,
+				vm.ctx.XChainID,
+				testShortIDAddrs[0],
+				initialBaseFee,
+				[]*secp256k1.PrivateKey{testKeys[0]},
+			)
+			require.NoError(err)
+			require.NoError(vm.mempool.AddLocalTx(source.exportTx))
+		default:
+			tx := types.NewTransaction(gen.TxNonce(testEthAddrs[0]), testEthAddrs[1], common.Big1, params.TxGas, initialBaseFee, nil)
+			signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainID), testKeys[0].ToECDSA())
+			require.NoError(err)
+			gen.AddTx(signedTx)
+		}
+	}, nil)
+
+	if vm.config.AncientFreezer {
+		vm.blockChain.DrainAcceptorQueue()
+		freezer, ok := vm.chaindb.(interface{ Freeze(threshold uint64) error })
+		require.True(ok)
+		require.NoError(freezer.Freeze(vm.config.AncientFreezerThreshold))
+	}
+
+	last := vm.blockChain.LastAcceptedBlock()
+	source.lastHash, source.lastHeight = last.Hash(), last.NumberU64()
+	statedb, err := vm.blockChain.State()
+	require.NoError(err)
+	source.balance = statedb.GetBalance(testEthAddrs[1])
+	require.NoError(vm.Shutdown(context.Background()))
+	return source
+}
+
+func TestSegmentsExportImport(t *testing.T) {
+	var (
+		require      = require.New(t)
+		importAmount = 2000000 * units.Avax
+		source       = newSegmentsTestSource(t, importAmount, 20, segmentsTestConfig)
+		dir          = t.TempDir()
+	)
+	require.NoError(ExportSegments(source.db, dir, SegmentExportConfig{
+		From:           1,
+		To:             source.lastHeight,
+		ChunkSize:      6,
+		CommitInterval: 4,
+	}))
+	manifest, err := ReadSegmentManifest(dir)
+	require.NoError(err)
+	require.Len(manifest.Chunks, 4)
+
+	// Import into a fresh node with the same UTXOs in shared memory.
+	alloc := map[ids.ShortID]uint64{testShortIDAddrs[0]: importAmount}
+	issuer, targetVM, targetDB, targetMemory, _ := GenesisVMWithUTXOs(t, true, "", segmentsTestConfig, "", alloc)
+	ctx := targetVM.ctx
+	require.NoError(targetVM.Shutdown(context.Background()))
+
+	importConfig := SegmentImportConfig{
+		SnowCtx: ctx,
+		Genesis: BuildGenesisTest(t, genesisJSONLatest),
+	}
+	require.NoError(ImportSegments(targetDB, targetMemory.NewSharedMemory(ctx.ChainID), dir, importConfig))
+	// Importing again is a no-op.
+	require.NoError(ImportSegments(targetDB, targetMemory.NewSharedMemory(ctx.ChainID), dir, importConfig))
+
+	restartedCtx := NewContext()
+	restartedCtx.SharedMemory = targetMemory.NewSharedMemory(restartedCtx.ChainID)
+	restartedVM := &VM{}
+	require.NoError(restartedVM.Initialize(
+		context.Background(),
+		restartedCtx,
+		targetDB,
+		importConfig.Genesis,
+		nil,
+		[]byte(segmentsTestConfig),
+		issuer,
+		[]*commonEng.Fx{},
+		nil,
+	))
+	defer func() {
+		require.NoError(restartedVM.Shutdown(context.Background()))
+	}()
+
+	last := restartedVM.blockChain.LastAcceptedBlock()
+	require.Equal(source.lastHash, last.Hash())
+	statedb, err := restartedVM.blockChain.State()
+	require.NoError(err)
+	require.Equal(source.balance, statedb.GetBalance(testEthAddrs[1]))
+
+	sharedMemories := newSharedMemories(targetMemory, ctx.ChainID, ctx.XChainID)
+	sharedMemories.assertOpsApplied(t, mustAtomicOps(source.importTx))
+	sharedMemories.assertOpsApplied(t, mustAtomicOps(source.exportTx))
+}
+
+func TestSegmentsResume(t *testing.T) {
+	var (
+		require      = require.New(t)
+		importAmount = 2000000 * units.Avax
+		source       = newSegmentsTestSource(t, importAmount, 20, segmentsTestConfig)
+		dir          = t.TempDir()
+		exportConfig = SegmentExportConfig{
+			From:           1,
+			To:             source.lastHeight,
+			ChunkSize:      6,
+			CommitInterval: 4,
+		}
+	)
+	require.NoError(ExportSegments(source.db, dir, exportConfig))
+	manifest, err := ReadSegmentManifest(dir)
+	require.NoError(err)
+
+	// A different range cannot be resumed into the same directory.
+	changed := exportConfig
+	changed.ChunkSize = 5
+	require.ErrorIs(ExportSegments(source.db, dir, changed), errSegmentManifestChanged)
+
+	// Corrupt the second chunk so the import stops after the first one.
+	corrupted := filepath.Join(dir, manifest.Chunks[1].File)
+	require.NoError(os.WriteFile(corrupted, []byte("corrupted"), 0o644))
+
+	alloc := map[ids.ShortID]uint64{testShortIDAddrs[0]: importAmount}
+	_, targetVM, targetDB, targetMemory, _ := GenesisVMWithUTXOs(t, true, "", segmentsTestConfig, "", alloc)
+	ctx := targetVM.ctx
+	require.NoError(targetVM.Shutdown(context.Background()))
+
+	importConfig := SegmentImportConfig{
+		SnowCtx: ctx,
+		Genesis: BuildGenesisTest(t, genesisJSONLatest),
+	}
+	sharedMemory := targetMemory.NewSharedMemory(ctx.ChainID)
+	require.ErrorIs(ImportSegments(targetDB, sharedMemory, dir, importConfig), errSegmentChecksum)
+	dbs, err := newSegmentDatabases(targetDB, "")
+	require.NoError(err)
+	_, height, err := dbs.lastAccepted()
+	require.NoError(err)
+	require.Equal(manifest.Chunks[0].Last, height)
+
+	// Resuming the export re-writes the corrupted chunk, after which the
+	// import resumes from the last accepted block.
+	require.NoError(ExportSegments(source.db, dir, exportConfig))
+	resumed, err := ReadSegmentManifest(dir)
+	require.NoError(err)
+	require.Equal(manifest, resumed)
+	require.NoError(ImportSegments(targetDB, sharedMemory, dir, importConfig))
+
+	hash, height, err := dbs.lastAccepted()
+	require.NoError(err)
+	require.Equal(source.lastHeight, height)
+	require.Equal(source.lastHash, hash)
+}
+
+func TestSegmentsExportAncients(t *testing.T) {
+	var (
+		require      = require.New(t)
+		importAmount = 2000000 * units.Avax
+		ancient      = t.TempDir()
+		configJSON   = fmt.Sprintf(`{"commit-interval": 4, "ancient-freezer-enabled": true, "ancient-freezer-directory": %q, "ancient-freezer-threshold": 5}`, ancient)
+		source       = newSegmentsTestSource(t, importAmount, 20, configJSON)
+		exportConfig = SegmentExportConfig{
+			From:           1,
+			To:             source.lastHeight,
+			ChunkSize:      6,
+			CommitInterval: 4,
+		}
+	)
+
+	// The frozen blocks are only found in the ancient freezer.
+	require.ErrorContains(ExportSegments(source.db, t.TempDir(), exportConfig), "block 1 not found")
+
+	dir := t.TempDir()
+	exportConfig.AncientDirectory = ancient
+	require.NoError(ExportSegments(source.db, dir, exportConfig))
+
+	alloc := map[ids.ShortID]uint64{testShortIDAddrs[0]: importAmount}
+	_, targetVM, targetDB, targetMemory, _ := GenesisVMWithUTXOs(t, true, "", segmentsTestConfig, "", alloc)
+	ctx := targetVM.ctx
+	require.NoError(targetVM.Shutdown(context.Background()))
+	require.NoError(ImportSegments(targetDB, targetMemory.NewSharedMemory(ctx.ChainID), dir, SegmentImportConfig{
+		SnowCtx: ctx,
+		Genesis: BuildGenesisTest(t, genesisJSONLatest),
+	}))
+
+	dbs, err := newSegmentDatabases(targetDB, "")
+	require.NoError(err)
+	hash, height, err := dbs.lastAccepted()
+	require.NoError(err)
+	require.Equal(source.lastHeight, height)
+	require.Equal(source.lastHash, hash)
+}
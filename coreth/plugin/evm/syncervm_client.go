@@ -5,6 +5,7 @@ package evm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -174,29 +175,9 @@ func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncS
 			)
 			return block.StateSyncSkipped, nil
 		}
-
-		// Wipe the snapshot completely if we are not resuming from an existing sync, so that we do not
-		// use a corrupted snapshot.
-		// Note: this assumes that when the node is started with state sync disabled, the in-progress state
-		// sync marker will be wiped, so we do not accidentally resume progress from an incorrect version
-		// of the snapshot. (if switching between versions that come before this change and back this could
-		// lead to the snapshot not being cleaned up correctly)
-		<-snapshot.WipeSnapshot(client.chaindb, true)
-		// Reset the snapshot generator here so that when state sync completes, snapshots will not attempt to read an
-		// invalid generator.
-		// Note: this must be called after WipeSnapshot is called so that we do not invalidate a partially generated snapshot.
-		snapshot.ResetSnapshotGeneration(client.chaindb)
 	}
-	client.syncSummary = proposedSummary
-
-	// Update the current state sync summary key in the database
-	// Note: this must be performed after WipeSnapshot finishes so that we do not start a state sync
-	// session from a partially wiped snapshot.
-	if err := client.metadataDB.Put(stateSyncSummaryKey, proposedSummary.Bytes()); err != nil {
-		return block.StateSyncSkipped, fmt.Errorf("failed to write state sync summary key to disk: %w", err)
-	}
-	if err := client.db.Commit(); err != nil {
-		return block.StateSyncSkipped, fmt.Errorf("failed to commit db: %w", err)
+	if err := client.prepareSync(proposedSummary, isResume); err != nil {
+		return block.StateSyncSkipped, err
 	}
 
 	log.Info("Starting state sync", "summary", proposedSummary)
@@ -223,6 +204,55 @@ func (client *stateSyncerClient) acceptSyncSummary(proposedSummary message.SyncS
 	return block.StateSyncStatic, nil
 }
 
+// prepareSync sets [proposedSummary] as the summary to sync to, and marks it
+// as the ongoing summary once the snapshot is wiped unless [isResume].
+func (client *stateSyncerClient) prepareSync(proposedSummary message.SyncSummary, isResume bool) error {
+	if !isResume {
+		// Wipe the snapshot completely if we are not resuming from an existing sync, so that we do not
+		// use a corrupted snapshot.
+		// Note: this assumes that when the node is started with state sync disabled, the in-progress state
+		// sync marker will be wiped, so we do not accidentally resume progress from an incorrect version
+		// of the snapshot. (if switching between versions that come before this change and back this could
+		// lead to the snapshot not being cleaned up correctly)
+		<-snapshot.WipeSnapshot(client.chaindb, true)
+		// Reset the snapshot generator here so that when state sync completes, snapshots will not attempt to read an
+		// invalid generator.
+		// Note: this must be called after WipeSnapshot is called so that we do not invalidate a partially generated snapshot.
+		snapshot.ResetSnapshotGeneration(client.chaindb)
+	}
+	client.syncSummary = proposedSummary
+
+	// Update the current state sync summary key in the database
+	// Note: this must be performed after WipeSnapshot finishes so that we do not start a state sync
+	// session from a partially wiped snapshot.
+	if err := client.metadataDB.Put(stateSyncSummaryKey, proposedSummary.Bytes()); err != nil {
+		return fmt.Errorf("failed to write state sync summary key to disk: %w", err)
+	}
+	if err := client.db.Commit(); err != nil {
+		return fmt.Errorf("failed to commit db: %w", err)
+	}
+	return nil
+}
+
+// syncToSummary blockingly performs the state sync to [summary] and prepares the VM
+// for bootstrapping from it, resuming the ongoing state sync to [summary] if any.
+// Unlike [acceptSyncSummary], the engine is not notified.
+func (client *stateSyncerClient) syncToSummary(ctx context.Context, summary message.SyncSummary) error {
+	if _, err := client.GetOngoingSyncStateSummary(ctx); err != nil && !errors.Is(err, database.ErrNotFound) {
+		return err
+	}
+	isResume := summary.BlockHash == client.resumableSummary.BlockHash
+	if err := client.prepareSync(summary, isResume); err != nil {
+		return err
+	}
+
+	log.Info("Starting state sync", "summary", summary, "resume", isResume)
+	if err := client.stateSync(ctx); err != nil {
+		return err
+	}
+	return client.finishSync()
+}
+
 // syncBlocks fetches (up to) [parentsToGet] blocks from peers
 // using [client] and writes them to disk.
 // the process begins with [fromHash] and it fetches parents recursively.
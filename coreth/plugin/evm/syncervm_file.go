@@ -0,0 +1,160 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	avalanchedatabase "github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/plugin/evm/database"
+	"github.com/ava-labs/coreth/plugin/evm/message"
+	statesyncclient "github.com/ava-labs/coreth/sync/client"
+	"github.com/ava-labs/coreth/sync/client/stats"
+	"github.com/ava-labs/coreth/sync/syncfile"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// stateSyncFilePrefix is the prefix of the database a state sync file is
+// loaded into while the VM state syncs from it.
+var stateSyncFilePrefix = []byte("stateSyncFile")
+
+var (
+	errNoStateSummary         = errors.New("no state summary available")
+	errStateSyncFileSummaryID = errors.New("unexpected state sync file summary")
+)
+
+// stateSyncFromFile state syncs to the summary of the state sync file
+// [vm.config.StateSyncFile], unless [lastAcceptedHeight] is at or above its
+// height, and returns the resulting last accepted height.
+// The summary is not voted on by validators, so its ID must be
+// [vm.config.StateSyncFileSummaryID]. The file is then loaded into a temporary
+// database and served to a state sync client, which verifies the state and
+// blocks of the file against the summary as it does for data served by peers.
+func (vm *VM) stateSyncFromFile(lastAcceptedHeight uint64) (uint64, error) {
+	file, err := os.Open(vm.config.StateSyncFile)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open state sync file: %w", err)
+	}
+	defer file.Close()
+
+	reader, err := syncfile.NewReader(file)
+	if err != nil {
+		return 0, err
+	}
+	summary := reader.Summary()
+	if summary.ID() != vm.config.StateSyncFileSummaryID {
+		return 0, fmt.Errorf("%w: got %s, expected %s", errStateSyncFileSummaryID, summary.ID(), vm.config.StateSyncFileSummaryID)
+	}
+	if lastAcceptedHeight >= summary.BlockNumber {
+		log.Info("last accepted at or above state sync file summary, skipping state sync from file",
+			"lastAccepted", lastAcceptedHeight,
+			"summaryHeight", summary.BlockNumber,
+		)
+		return lastAcceptedHeight, nil
+	}
+
+	// Clear the data of any previous attempt before loading the file, and
+	// once the sync is over.
+	fileDB := prefixdb.NewNested(stateSyncFilePrefix, vm.db)
+	if err := avalanchedatabase.Clear(fileDB, ethdb.IdealBatchSize); err != nil {
+		return 0, fmt.Errorf("failed to clear state sync file database: %w", err)
+	}
+	defer func() {
+		if err := avalanchedatabase.Clear(fileDB, ethdb.IdealBatchSize); err != nil {
+			log.Error("failed to clear state sync file database", "err", err)
+		}
+	}()
+	fileChainDB := rawdb.NewDatabase(database.WrapDatabase(fileDB))
+	if err := reader.Load(fileChainDB); err != nil {
+		return 0, fmt.Errorf("failed to load state sync file: %w", err)
+	}
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	client := &stateSyncerClient{stateSyncClientConfig: &stateSyncClientConfig{
+		chain: vm.eth,
+		state: vm.State,
+		client: statesyncclient.NewClient(
+			&statesyncclient.ClientConfig{
+				NetworkClient: syncfile.NewNetworkClient(fileChainDB, vm.networkCodec, cancel),
+				Codec:         vm.networkCodec,
+				Stats:         stats.NewNoOpStats(),
+				BlockParser:   vm,
+			},
+		),
+		enabled:              true,
+		stateSyncRequestSize: vm.config.StateSyncRequestSize,
+		lastAcceptedHeight:   lastAcceptedHeight,
+		chaindb:              vm.chaindb,
+		metadataDB:           vm.metadataDB,
+		acceptedBlockDB:      vm.acceptedBlockDB,
+		db:                   vm.versiondb,
+		atomicBackend:        vm.atomicBackend,
+	}}
+	if err := client.syncToSummary(ctx, summary); err != nil {
+		if cause := context.Cause(ctx); cause != nil {
+			err = cause
+		}
+		return 0, fmt.Errorf("failed to state sync from file: %w", err)
+	}
+	log.Info("state synced from file", "summary", summary)
+	return summary.BlockNumber, nil
+}
+
+// exportStateSyncFile writes the state sync file of the state summary at
+// [height], or of the last state summary if nil, to [path].
+// Assumes [vm.ctx.Lock] is not held, as exporting the state may take long.
+func (vm *VM) exportStateSyncFile(ctx context.Context, path string, height *uint64) (message.SyncSummary, error) {
+	summary, err := vm.stateSummary(ctx, height)
+	if err != nil {
+		return message.SyncSummary{}, err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return message.SyncSummary{}, fmt.Errorf("failed to create state sync file: %w", err)
+	}
+	defer file.Close()
+
+	err = syncfile.Export(ctx, file, &syncfile.ExportConfig{
+		Summary:      summary,
+		StateTrieDB:  vm.blockChain.StateCache().TrieDB(),
+		CodeReader:   vm.chaindb,
+		AtomicTrieDB: vm.atomicTrie.TrieDB(),
+		Blocks:       vm.blockChain,
+		NumBlocks:    parentsToGet,
+	})
+	if err != nil {
+		return message.SyncSummary{}, fmt.Errorf("failed to export state sync file: %w", err)
+	}
+	return summary, file.Sync()
+}
+
+// stateSummary returns the state summary at [height], or the last state
+// summary if nil.
+func (vm *VM) stateSummary(ctx context.Context, height *uint64) (message.SyncSummary, error) {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	var (
+		summary block.StateSummary
+		err     error
+	)
+	if height == nil {
+		summary, err = vm.StateSyncServer.GetLastStateSummary(ctx)
+	} else {
+		summary, err = vm.StateSyncServer.GetStateSummary(ctx, *height)
+	}
+	if err != nil {
+		return message.SyncSummary{}, fmt.Errorf("%w: %w", errNoStateSummary, err)
+	}
+	return summary.(message.SyncSummary), nil
+}
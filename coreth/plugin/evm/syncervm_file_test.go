@@ -0,0 +1,104 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/plugin/evm/client"
+	"github.com/ava-labs/coreth/plugin/evm/message"
+	"github.com/ava-labs/coreth/predicate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateSyncFromFile(t *testing.T) {
+	rand.Seed(1)
+	require := require.New(t)
+
+	test := syncTest{
+		syncableInterval:   256,
+		stateSyncMinBlocks: 50,
+		syncMode:           block.StateSyncStatic,
+	}
+	vmSetup := createSyncServerAndClientVMs(t, test, parentsToGet)
+	serverVM := vmSetup.serverVM
+
+	path := filepath.Join(t.TempDir(), "state.sync")
+	admin := NewAdminService(serverVM, t.TempDir())
+	reply := &client.ExportStateSyncFileReply{}
+	serverVM.ctx.Lock.Unlock()
+	err := admin.ExportStateSyncFile(httptest.NewRequest(http.MethodPost, "/", nil), &client.ExportStateSyncFileArgs{Path: path}, reply)
+	serverVM.ctx.Lock.Lock()
+	require.NoError(err)
+	stateSummary, err := serverVM.GetLastStateSummary(context.Background())
+	require.NoError(err)
+	summary := stateSummary.(message.SyncSummary)
+	require.Equal(summary.ID(), reply.SummaryID)
+	require.Equal(json.Uint64(summary.BlockNumber), reply.Height)
+	require.Equal(summary.BlockHash, reply.BlockHash)
+	require.Equal(summary.BlockRoot, reply.BlockRoot)
+	require.Equal(summary.AtomicRoot, reply.AtomicRoot)
+
+	// A new node state syncs from the file on startup, without its peers.
+	configJSON := fmt.Sprintf(`{"state-sync-file": %q, "state-sync-file-summary-id": %q, "commit-interval": %d}`, path, reply.SummaryID, test.syncableInterval)
+	_, syncerVM, _, syncerAtomicMemory, _ := GenesisVMWithUTXOs(t, false, "", configJSON, "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: 2000000 * units.Avax,
+	})
+	defer func() {
+		require.NoError(syncerVM.Shutdown(context.Background()))
+	}()
+
+	require.Equal(serverVM.LastAcceptedBlock().ID(), syncerVM.LastAcceptedBlock().ID())
+	require.True(syncerVM.blockChain.HasState(syncerVM.blockChain.LastAcceptedBlock().Root()))
+	assertSyncPerformedHeights(t, syncerVM.chaindb, map[uint64]struct{}{summary.BlockNumber: {}})
+	enabled, err := syncerVM.StateSyncEnabled(context.Background())
+	require.NoError(err)
+	require.False(enabled)
+
+	// A file with a summary other than the configured one is rejected.
+	syncerVM.config.StateSyncFileSummaryID = ids.GenerateTestID()
+	_, err = syncerVM.stateSyncFromFile(0)
+	require.ErrorIs(err, errStateSyncFileSummaryID)
+
+	// The loaded file is cleared once synced.
+	iter := prefixdb.NewNested(stateSyncFilePrefix, syncerVM.db).NewIterator()
+	require.False(iter.Next())
+	iter.Release()
+
+	require.NoError(syncerVM.SetState(context.Background(), snow.Bootstrapping))
+	require.NoError(syncerVM.SetState(context.Background(), snow.NormalOp))
+	syncerSharedMemories := newSharedMemories(syncerAtomicMemory, syncerVM.ctx.ChainID, syncerVM.ctx.XChainID)
+	for _, tx := range vmSetup.includedAtomicTxs {
+		syncerSharedMemories.assertOpsApplied(t, mustAtomicOps(tx))
+	}
+
+	// The synced node processes blocks on top of the synced state.
+	generateAndAcceptBlocks(t, syncerVM, 5, func(_ int, gen *core.BlockGen) {
+		b, err := predicate.NewResults().Bytes()
+		require.NoError(err)
+		gen.AppendExtra(b)
+		for k := range vmSetup.fundedAccounts {
+			tx := types.NewTransaction(gen.TxNonce(k.Address), testEthAddrs[1], big.NewInt(1), 21000, initialBaseFee, nil)
+			signedTx, err := types.SignTx(tx, types.NewEIP155Signer(serverVM.chainConfig.ChainID), k.PrivateKey)
+			require.NoError(err)
+			gen.AddTx(signedTx)
+			break
+		}
+	}, nil)
+}
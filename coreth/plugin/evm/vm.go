@@ -4,6 +4,7 @@
 package evm
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
@@ -13,6 +14,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -34,6 +36,8 @@ import (
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/eth"
 	"github.com/ava-labs/coreth/eth/ethconfig"
+	"github.com/ava-labs/coreth/eth/filters"
+	"github.com/ava-labs/coreth/graphql"
 	corethprometheus "github.com/ava-labs/coreth/metrics/prometheus"
 	"github.com/ava-labs/coreth/miner"
 	"github.com/ava-labs/coreth/node"
@@ -54,6 +58,7 @@ import (
 	"github.com/ava-labs/coreth/rpc"
 	statesyncclient "github.com/ava-labs/coreth/sync/client"
 	"github.com/ava-labs/coreth/sync/client/stats"
+	syncHandlers "github.com/ava-labs/coreth/sync/handlers"
 	"github.com/ava-labs/coreth/warp"
 
 	// Force-load tracer engine to trigger registration
@@ -132,6 +137,15 @@ const (
 
 	targetAtomicTxsSize = 40 * units.KiB
 
+	// ancientFreezerDirectory is the default directory of the ancient freezer
+	// in the chain data directory.
+	ancientFreezerDirectory = "ancient"
+	ancientFreezerNamespace = "eth/db/chaindata/"
+
+	// atomicMempoolJournalFile is the default file of the atomic mempool
+	// journal in the chain data directory.
+	atomicMempoolJournalFile = "atomic_transactions.rlp"
+
 	// maxAtomicTxMempoolGas is the maximum amount of gas that is allowed to be
 	// used by an atomic transaction in the mempool. It is allowed to build
 	// blocks with larger atomic transactions, but they will not be accepted
@@ -153,6 +167,7 @@ const (
 	adminEndpoint           = "/admin"
 	ethRPCEndpoint          = "/rpc"
 	ethWSEndpoint           = "/ws"
+	graphQLEndpoint         = "/graphql"
 	ethTxGossipNamespace    = "eth_tx_gossip"
 	atomicTxGossipNamespace = "atomic_tx_gossip"
 )
@@ -164,6 +179,7 @@ var (
 	metadataPrefix  = []byte("metadata")
 	warpPrefix      = []byte("warp")
 	ethDBPrefix     = []byte("ethdb")
+	flatTracePrefix = []byte("flattrace")
 
 	// Prefixes for atomic trie
 	atomicTrieDBPrefix     = []byte("atomicTrieDB")
@@ -255,6 +271,10 @@ type VM struct {
 	// set to a prefixDB with the prefix [warpPrefix]
 	warpDB database.Database
 
+	// [flatTraceDB] is used to store the flat call traces of accepted
+	// blocks if flat trace indexing is enabled
+	flatTraceDB ethdb.KeyValueStore
+
 	toEngine chan<- commonEng.Message
 
 	syntacticBlockValidator BlockValidator
@@ -415,34 +435,14 @@ func (vm *VM) Initialize(
 		}
 	}
 
-	g := new(core.Genesis)
-	if err := json.Unmarshal(genesisBytes, g); err != nil {
+	g, err := parseGenesis(chainCtx, genesisBytes, upgradeBytes)
+	if err != nil {
 		return err
 	}
-
-	// if the chainCtx.NetworkUpgrades is not empty, set the chain config
-	// normally it should not be empty, but some tests may not set it
-	if chainCtx.NetworkUpgrades != (upgrade.Config{}) {
-		g.Config.NetworkUpgrades = params.GetNetworkUpgrades(chainCtx.NetworkID)
-	}
-
-	// If the Durango is activated, activate the Warp Precompile at the same time
-	if g.Config.DurangoBlockTimestamp != nil {
-		g.Config.PrecompileUpgrades = append(g.Config.PrecompileUpgrades, params.PrecompileUpgrade{
-			Config: warpcontract.NewDefaultConfig(g.Config.DurangoBlockTimestamp),
-		})
-	}
-
-	// Set the Avalanche Context on the ChainConfig
-	g.Config.AvalancheContext = params.AvalancheContext{
-		SnowCtx: chainCtx,
-	}
 	vm.syntacticBlockValidator = NewBlockValidator(nil)
 
 	vm.chainID = g.Config.ChainID
 
-	g.Config.SetEthUpgrades()
-
 	if g.Config.IsSongbirdCode() {
 		vm.ethConfig = ethconfig.NewDefaultSgbConfig()
 	} else {
@@ -502,6 +502,14 @@ func (vm *VM) Initialize(
 	vm.ethConfig.AcceptedCacheSize = vm.config.AcceptedCacheSize
 	vm.ethConfig.TransactionHistory = vm.config.TransactionHistory
 	vm.ethConfig.SkipTxIndexing = vm.config.SkipTxIndexing
+	vm.ethConfig.DaemonMintIndexing = vm.config.DaemonMintIndexing
+	vm.ethConfig.StateConnectorVoteIndexing = vm.config.StateConnectorVoteIndexing
+	vm.ethConfig.FlatTraceIndexing = vm.config.FlatTraceIndexing
+	vm.ethConfig.AddressIndexing = vm.config.AddressIndexing
+	vm.ethConfig.AddressIndexInternalCalls = vm.config.AddressIndexInternalCalls
+	vm.ethConfig.HistoricalStateRegeneration = vm.config.HistoricalStateRegeneration
+	vm.ethConfig.HistoricalStateReexec = vm.config.HistoricalStateReexec
+	vm.ethConfig.HistoricalStateCache = vm.config.HistoricalStateCache
 
 	// Create directory for offline pruning
 	if len(vm.ethConfig.OfflinePruningDataDirectory) != 0 {
@@ -593,6 +601,11 @@ func (vm *VM) Initialize(
 	if err != nil {
 		return fmt.Errorf("failed to create atomic repository: %w", err)
 	}
+	if vm.config.AtomicTxAddressIndexing {
+		if err := vm.atomicTxRepository.InitializeAddressIndex(&vm.secpCache); err != nil {
+			return fmt.Errorf("failed to initialize atomic tx address index: %w", err)
+		}
+	}
 	vm.atomicBackend, err = NewAtomicBackend(
 		vm.versiondb, vm.ctx.SharedMemory, bonusBlockHeights,
 		vm.atomicTxRepository, lastAcceptedHeight, lastAcceptedHash,
@@ -625,6 +638,15 @@ func (vm *VM) Initialize(
 		AtomicTrie:       vm.atomicTrie,
 		SyncableInterval: vm.config.StateSyncCommitInterval,
 	})
+
+	// State sync from the configured file before the engine starts, so that
+	// the engine only state syncs from the network if explicitly enabled.
+	if len(vm.config.StateSyncFile) > 0 {
+		lastAcceptedHeight, err = vm.stateSyncFromFile(lastAcceptedHeight)
+		if err != nil {
+			return err
+		}
+	}
 	return vm.initializeStateSyncClient(lastAcceptedHeight)
 }
 
@@ -638,6 +660,55 @@ func (vm *VM) initializeMetrics() error {
 	return vm.ctx.Metrics.Register(sdkMetricsPrefix, vm.sdkMetrics)
 }
 
+// parseGenesis parses the genesis of the chain from [genesisBytes], and
+// completes its chain config with the network upgrades of [chainCtx] and the
+// precompile upgrades and prioritised contract schedule in [upgradeBytes], if any.
+func parseGenesis(chainCtx *snow.Context, genesisBytes []byte, upgradeBytes []byte) (*core.Genesis, error) {
+	g := new(core.Genesis)
+	if err := json.Unmarshal(genesisBytes, g); err != nil {
+		return nil, err
+	}
+
+	// if the chainCtx.NetworkUpgrades is not empty, set the chain config
+	// normally it should not be empty, but some tests may not set it
+	if chainCtx.NetworkUpgrades != (upgrade.Config{}) {
+		g.Config.NetworkUpgrades = params.GetNetworkUpgrades(chainCtx.NetworkID)
+	}
+
+	// Load the precompile upgrades and the prioritised contract schedule from
+	// the upgrade bytes, if any.
+	if len(upgradeBytes) > 0 {
+		var upgradeConfig params.UpgradeConfig
+		if err := json.Unmarshal(upgradeBytes, &upgradeConfig); err != nil {
+			return nil, fmt.Errorf("failed to parse upgrade bytes: %w", err)
+		}
+		g.Config.UpgradeConfig = upgradeConfig
+	}
+
+	// If the Durango is activated, activate the Warp Precompile at the same time
+	if g.Config.DurangoBlockTimestamp != nil {
+		g.Config.PrecompileUpgrades = append(g.Config.PrecompileUpgrades, params.PrecompileUpgrade{
+			Config: warpcontract.NewDefaultConfig(g.Config.DurangoBlockTimestamp),
+		})
+		// Keep the precompile upgrades ordered by timestamp, as the upgrade
+		// bytes may schedule upgrades after Durango. Upgrades without a
+		// timestamp are left in place to be rejected by the config checks.
+		slices.SortStableFunc(g.Config.PrecompileUpgrades, func(a, b params.PrecompileUpgrade) int {
+			if a.Timestamp() == nil || b.Timestamp() == nil {
+				return 0
+			}
+			return cmp.Compare(*a.Timestamp(), *b.Timestamp())
+		})
+	}
+
+	// Set the Avalanche Context on the ChainConfig
+	g.Config.AvalancheContext = params.AvalancheContext{
+		SnowCtx: chainCtx,
+	}
+	g.Config.SetEthUpgrades()
+	return g, nil
+}
+
 func (vm *VM) initializeChain(lastAcceptedHash common.Hash) error {
 	nodecfg := &node.Config{
 		CorethVersion:         Version,
@@ -664,7 +735,11 @@ func (vm *VM) initializeChain(lastAcceptedHash common.Hash) error {
 		&vm.ethConfig,
 		&EthPushGossiper{vm: vm},
 		vm.chaindb,
-		eth.Settings{MaxBlocksPerRequest: vm.config.MaxBlocksPerRequest},
+		eth.Settings{
+			MaxBlocksPerRequest: vm.config.MaxBlocksPerRequest,
+			LogsReplayLimit:     vm.config.LogsReplayLimit,
+			FlatTraceDB:         vm.flatTraceDB,
+		},
 		lastAcceptedHash,
 		dummy.NewDummyEngine(
 			callbacks,
@@ -955,12 +1030,7 @@ func (vm *VM) onFinalizeAndAssemble(
 }
 
 func (vm *VM) onExtraStateChange(block *types.Block, parent *types.Header, state *state.StateDB) (*big.Int, *big.Int, error) {
-	var (
-		batchContribution *big.Int = big.NewInt(0)
-		batchGasUsed      *big.Int = big.NewInt(0)
-		header                     = block.Header()
-		rules                      = vm.chainConfig.Rules(header.Number, header.Time)
-	)
+	rules := vm.chainConfig.Rules(block.Number(), block.Time())
 
 	txs, err := atomic.ExtractAtomicTxs(block.ExtData(), rules.IsApricotPhase5, atomic.Codec)
 	if err != nil {
@@ -987,18 +1057,30 @@ func (vm *VM) onExtraStateChange(block *types.Block, parent *types.Header, state
 		}
 	}
 
+	return atomicStateTransfer(vm.ctx, vm.chainConfig, block, parent, state, txs)
+}
+
+// atomicStateTransfer applies the EVM state changes of the atomic [txs] of
+// [block] to [state], returning their block fee contribution and gas used.
+func atomicStateTransfer(ctx *snow.Context, chainConfig *params.ChainConfig, block *types.Block, parent *types.Header, state *state.StateDB, txs []*atomic.Tx) (*big.Int, *big.Int, error) {
 	// If there are no transactions, we can return early.
 	if len(txs) == 0 {
 		return nil, nil, nil
 	}
 
+	var (
+		batchContribution *big.Int = big.NewInt(0)
+		batchGasUsed      *big.Int = big.NewInt(0)
+		header                     = block.Header()
+		rules                      = chainConfig.Rules(header.Number, header.Time)
+	)
 	for _, tx := range txs {
-		if err := tx.UnsignedAtomicTx.EVMStateTransfer(vm.ctx, state); err != nil {
+		if err := tx.UnsignedAtomicTx.EVMStateTransfer(ctx, state); err != nil {
 			return nil, nil, err
 		}
 		// If ApricotPhase4 is enabled, calculate the block fee contribution
 		if rules.IsApricotPhase4 {
-			contribution, gasUsed, err := tx.BlockFeeContribution(rules.IsApricotPhase5, vm.ctx.AVAXAssetID, block.BaseFee())
+			contribution, gasUsed, err := tx.BlockFeeContribution(rules.IsApricotPhase5, ctx.AVAXAssetID, block.BaseFee())
 			if err != nil {
 				return nil, nil, err
 			}
@@ -1011,7 +1093,7 @@ func (vm *VM) onExtraStateChange(block *types.Block, parent *types.Header, state
 	// If ApricotPhase5 is enabled, enforce that the atomic gas used does not exceed the
 	// atomic gas limit.
 	if rules.IsApricotPhase5 {
-		atomicGasLimit, err := customheader.RemainingAtomicGasCapacity(vm.chainConfig, parent, header)
+		atomicGasLimit, err := customheader.RemainingAtomicGasCapacity(chainConfig, parent, header)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1023,6 +1105,18 @@ func (vm *VM) onExtraStateChange(block *types.Block, parent *types.Header, state
 	return batchContribution, batchGasUsed, nil
 }
 
+// ApplyAtomicTxs applies the EVM state changes of the atomic txs in the extra
+// data of [block] to [state], returning their block fee contribution and gas
+// used. The txs are not verified against shared memory.
+func ApplyAtomicTxs(ctx *snow.Context, chainConfig *params.ChainConfig, block *types.Block, parent *types.Header, state *state.StateDB) (*big.Int, *big.Int, error) {
+	rules := chainConfig.Rules(block.Number(), block.Time())
+	txs, err := atomic.ExtractAtomicTxs(block.ExtData(), rules.IsApricotPhase5, atomic.Codec)
+	if err != nil {
+		return nil, nil, err
+	}
+	return atomicStateTransfer(ctx, chainConfig, block, parent, state, txs)
+}
+
 func (vm *VM) SetState(_ context.Context, state snow.State) error {
 	switch state {
 	case snow.StateSyncing:
@@ -1144,6 +1238,12 @@ func (vm *VM) initBlockBuilding() error {
 		}
 	}
 
+	if vm.config.AtomicMempoolJournal {
+		if err := vm.initAtomicMempoolJournal(ctx); err != nil {
+			return fmt.Errorf("failed to initialize atomic mempool journal: %w", err)
+		}
+	}
+
 	// NOTE: gossip network must be initialized first otherwise ETH tx gossip will not work.
 	vm.builder = vm.NewBlockBuilder(vm.toEngine)
 	vm.builder.awaitSubmittedTxs()
@@ -1275,6 +1375,13 @@ func (vm *VM) setAppRequestHandlers() {
 		vm.atomicTrie.TrieDB(),
 		vm.warpBackend,
 		vm.networkCodec,
+		syncHandlers.ThrottlerConfig{
+			Bytes:               vm.config.StateSyncServerPeerBytes,
+			Time:                vm.config.StateSyncServerPeerTime.Duration,
+			Period:              vm.config.StateSyncServerPeerPeriod.Duration,
+			ValidatorMultiplier: vm.config.StateSyncServerValidatorMultiplier,
+		},
+		vm.p2pValidators,
 	)
 	vm.Network.SetRequestHandler(networkHandler)
 }
@@ -1301,6 +1408,47 @@ func (vm *VM) Shutdown(context.Context) error {
 	return nil
 }
 
+// initAtomicMempoolJournal loads the locally issued atomic txs journaled
+// before the last shutdown into the mempool, gossips them again and keeps
+// regenerating the journal until [ctx] is cancelled.
+func (vm *VM) initAtomicMempoolJournal(ctx context.Context) error {
+	path := vm.config.AtomicMempoolJournalPath
+	if path == "" {
+		if vm.ctx.ChainDataDir == "" {
+			return errors.New("atomic-mempool-journal-path must be set when there is no chain data directory")
+		}
+		path = filepath.Join(vm.ctx.ChainDataDir, atomicMempoolJournalFile)
+	}
+	for _, tx := range vm.mempool.LoadJournal(path) {
+		vm.atomicTxPushGossiper.Add(&atomic.GossipAtomicTx{Tx: tx})
+	}
+
+	vm.shutdownWg.Add(1)
+	go func() {
+		defer vm.shutdownWg.Done()
+
+		ticker := time.NewTicker(vm.config.AtomicMempoolRejournal.Duration)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := vm.mempool.Rejournal(); err != nil {
+					log.Warn("Failed to rotate atomic mempool journal", "err", err)
+				}
+			case <-ctx.Done():
+				if err := vm.mempool.Rejournal(); err != nil {
+					log.Warn("Failed to rotate atomic mempool journal", "err", err)
+				}
+				if err := vm.mempool.CloseJournal(); err != nil {
+					log.Warn("Failed to close atomic mempool journal", "err", err)
+				}
+				return
+			}
+		}
+	}()
+	return nil
+}
+
 // buildBlock builds a block to be wrapped by ChainState
 func (vm *VM) buildBlock(ctx context.Context) (snowman.Block, error) {
 	return vm.buildBlockWithContext(ctx, nil)
@@ -1514,6 +1662,18 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]http.Handler, error) {
 		enabledAPIs = append(enabledAPIs, "warp")
 	}
 
+	if vm.config.GraphQLEnabled {
+		filterSystem := filters.NewFilterSystem(vm.eth.APIBackend, filters.Config{
+			Timeout: 5 * time.Minute,
+		})
+		graphQLHandler, err := graphql.NewHandler(vm.eth.APIBackend, filterSystem, vm.config.APIMaxDuration.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GraphQL handler due to %w", err)
+		}
+		apis[graphQLEndpoint] = graphQLHandler
+		enabledAPIs = append(enabledAPIs, "graphql")
+	}
+
 	log.Info(fmt.Sprintf("Enabled APIs: %s", strings.Join(enabledAPIs, ", ")))
 	apis[ethRPCEndpoint] = handler
 	apis[ethWSEndpoint] = handler.WebsocketHandlerWithDuration(
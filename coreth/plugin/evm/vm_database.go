@@ -4,6 +4,9 @@
 package evm
 
 import (
+	"errors"
+	"fmt"
+	"path/filepath"
 	"time"
 
 	avalanchedatabase "github.com/ava-labs/avalanchego/database"
@@ -20,7 +23,20 @@ import (
 func (vm *VM) initializeDBs(db avalanchedatabase.Database) error {
 	// Use NewNested rather than New so that the structure of the database
 	// remains the same regardless of the provided baseDB type.
-	vm.chaindb = rawdb.NewDatabase(database.WrapDatabase(prefixdb.NewNested(ethDBPrefix, db)))
+	kvdb := database.WrapDatabase(prefixdb.NewNested(ethDBPrefix, db))
+	if vm.config.AncientFreezer {
+		ancient, err := vm.ancientDirectory()
+		if err != nil {
+			return err
+		}
+		vm.chaindb, err = rawdb.NewDatabaseWithFreezer(kvdb, ancient, ancientFreezerNamespace, false, vm.config.AncientFreezerThreshold)
+		if err != nil {
+			return fmt.Errorf("failed to open ancient freezer at %s: %w", ancient, err)
+		}
+		log.Info("Opened ancient freezer", "directory", ancient, "threshold", vm.config.AncientFreezerThreshold)
+	} else {
+		vm.chaindb = rawdb.NewDatabase(kvdb)
+	}
 	vm.versiondb = versiondb.New(db)
 	vm.acceptedBlockDB = prefixdb.New(acceptedPrefix, vm.versiondb)
 	vm.metadataDB = prefixdb.New(metadataPrefix, vm.versiondb)
@@ -29,9 +45,25 @@ func (vm *VM) initializeDBs(db avalanchedatabase.Database) error {
 	// that warp signatures are committed to the database atomically with
 	// the last accepted block.
 	vm.warpDB = prefixdb.New(warpPrefix, db)
+	// Flat traces are derived data that can be rebuilt by re-executing the
+	// accepted blocks, so they are not committed atomically either.
+	if vm.config.FlatTraceIndexing {
+		vm.flatTraceDB = database.WrapDatabase(prefixdb.NewNested(flatTracePrefix, db))
+	}
 	return nil
 }
 
+// ancientDirectory returns the root directory of the ancient freezer.
+func (vm *VM) ancientDirectory() (string, error) {
+	if vm.config.AncientFreezerDirectory != "" {
+		return vm.config.AncientFreezerDirectory, nil
+	}
+	if vm.ctx.ChainDataDir == "" {
+		return "", errors.New("ancient-freezer-directory must be set when there is no chain data directory")
+	}
+	return filepath.Join(vm.ctx.ChainDataDir, ancientFreezerDirectory), nil
+}
+
 func (vm *VM) inspectDatabases() error {
 	start := time.Now()
 	log.Info("Starting database inspection")
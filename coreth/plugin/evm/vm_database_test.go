@@ -0,0 +1,80 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	commonEng "github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/predicate"
+)
+
+func TestAncientFreezer(t *testing.T) {
+	require := require.New(t)
+
+	configJSON := fmt.Sprintf(`{"ancient-freezer-enabled": true, "ancient-freezer-directory": %q, "ancient-freezer-threshold": 4}`, t.TempDir())
+	issuer, vm, db, _, _ := GenesisVM(t, true, "", configJSON, "")
+
+	var accepted []*types.Block
+	generateAndAcceptBlocks(t, vm, 10, func(_ int, gen *core.BlockGen) {
+		b, err := predicate.NewResults().Bytes()
+		require.NoError(err)
+		gen.AppendExtra(b)
+		tx := types.NewTransaction(gen.TxNonce(testEthAddrs[0]), testEthAddrs[1], common.Big1, params.TxGas, initialBaseFee, nil)
+		signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainID), testKeys[0].ToECDSA())
+		require.NoError(err)
+		gen.AddTx(signedTx)
+	}, func(block *types.Block) {
+		accepted = append(accepted, block)
+	})
+
+	// Blocks at least 4 blocks below the last accepted block are frozen.
+	freezer, ok := vm.chaindb.(interface{ Freeze(uint64) error })
+	require.True(ok)
+	require.NoError(freezer.Freeze(4))
+	frozen, err := vm.chaindb.Ancients()
+	require.NoError(err)
+	require.Equal(uint64(7), frozen)
+	require.NoError(vm.Shutdown(context.Background()))
+
+	// Frozen blocks are served transparently after a restart.
+	restartedVM := &VM{}
+	require.NoError(restartedVM.Initialize(
+		context.Background(),
+		NewContext(),
+		db,
+		BuildGenesisTest(t, genesisJSONLatest),
+		nil,
+		[]byte(configJSON),
+		issuer,
+		[]*commonEng.Fx{},
+		nil,
+	))
+	defer func() {
+		require.NoError(restartedVM.Shutdown(context.Background()))
+	}()
+
+	for _, block := range accepted {
+		number := block.NumberU64()
+		require.Equal(block.Hash(), rawdb.ReadCanonicalHash(restartedVM.chaindb, number))
+		stored := restartedVM.blockChain.GetBlockByNumber(number)
+		require.NotNil(stored, "block %d", number)
+		require.Equal(block.Hash(), stored.Hash())
+		receipts := restartedVM.blockChain.GetReceiptsByHash(block.Hash())
+		require.Len(receipts, 1)
+		require.Equal(types.ReceiptStatusSuccessful, receipts[0].Status)
+		tx, _, blockNumber, _ := rawdb.ReadTransaction(restartedVM.chaindb, block.Transactions()[0].Hash())
+		require.NotNil(tx)
+		require.Equal(number, blockNumber)
+	}
+}
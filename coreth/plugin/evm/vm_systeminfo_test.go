@@ -0,0 +1,63 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/upgrade"
+
+	"github.com/ava-labs/coreth/internal/ethapi"
+	"github.com/ava-labs/coreth/precompile/contracts/systeminfo"
+	"github.com/ava-labs/coreth/rpc"
+)
+
+// TestSystemInfoUpgradeBytes checks that the system info precompile can be
+// enabled at a timestamp through the upgrade bytes of the chain.
+func TestSystemInfoUpgradeBytes(t *testing.T) {
+	require := require.New(t)
+
+	// Activate the precompile after the genesis block.
+	activationTime := uint64(upgrade.InitiallyActiveTime.Unix()) + 10
+	upgradeJSON := fmt.Sprintf(`{"precompileUpgrades":[{"%s":{"blockTimestamp":%d}}]}`, systeminfo.ConfigKey, activationTime)
+	_, vm, _, _, _ := GenesisVM(t, true, genesisJSONLatest, "", upgradeJSON)
+	defer func() {
+		require.NoError(vm.Shutdown(context.Background()))
+	}()
+
+	require.False(vm.chainConfig.IsPrecompileEnabled(systeminfo.ContractAddress, activationTime-1))
+	require.True(vm.chainConfig.IsPrecompileEnabled(systeminfo.ContractAddress, activationTime))
+
+	input, err := systeminfo.PackGetUpgradeActivationTime("durangoBlockTimestamp")
+	require.NoError(err)
+	call := func(timestamp uint64) []byte {
+		data := hexutil.Bytes(input)
+		res, err := ethapi.DoCall(
+			context.Background(),
+			vm.eth.APIBackend,
+			ethapi.TransactionArgs{To: &systeminfo.ContractAddress, Data: &data},
+			rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber),
+			nil,
+			&ethapi.BlockOverrides{Time: (*hexutil.Uint64)(&timestamp)},
+			0,
+			vm.config.RPCGasCap,
+		)
+		require.NoError(err)
+		require.NoError(res.Err)
+		return res.Return()
+	}
+
+	// Before the activation, the address holds no code.
+	require.Empty(call(activationTime - 1))
+
+	output, err := systeminfo.UnpackGetUpgradeActivationTimeOutput(call(activationTime))
+	require.NoError(err)
+	require.True(output.Activated)
+	require.Equal(*vm.chainConfig.DurangoBlockTimestamp, output.Timestamp)
+}
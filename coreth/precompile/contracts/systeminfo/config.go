@@ -0,0 +1,133 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package systeminfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	warpValidators "github.com/ava-labs/coreth/warp/validators"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	_ precompileconfig.Config     = &Config{}
+	_ precompileconfig.Predicater = &Config{}
+)
+
+var (
+	errSystemInfoCannotBeActivated = errors.New("system info cannot be activated before Durango")
+	errFuturePChainHeight          = errors.New("validator weight P-chain height is after the block P-chain height")
+	errCannotRetrieveValidatorSet  = errors.New("cannot retrieve validator set")
+	errOverflowTotalWeight         = errors.New("overflow calculating total validator weight")
+	errValidatorWeightMismatch     = errors.New("validator weight does not match the validator set")
+)
+
+// Config implements the precompileconfig.Config interface and
+// adds specific configuration for the system info precompile.
+type Config struct {
+	precompileconfig.Upgrade
+}
+
+// NewConfig returns a config for a network upgrade at [blockTimestamp] that
+// enables the system info precompile.
+func NewConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{BlockTimestamp: blockTimestamp},
+	}
+}
+
+// NewDisableConfig returns config for a network upgrade at [blockTimestamp]
+// that disables the system info precompile.
+func NewDisableConfig(blockTimestamp *uint64) *Config {
+	return &Config{
+		Upgrade: precompileconfig.Upgrade{
+			BlockTimestamp: blockTimestamp,
+			Disable:        true,
+		},
+	}
+}
+
+// Key returns the key for the system info precompileconfig.
+// This should be the same key as used in the precompile module.
+func (*Config) Key() string { return ConfigKey }
+
+// Verify tries to verify Config and returns an error accordingly.
+func (c *Config) Verify(chainConfig precompileconfig.ChainConfig) error {
+	// Validator weight predicates are verified within the ProposerVM block
+	// context, which is only required after Durango.
+	if c.Timestamp() != nil && !chainConfig.IsDurango(*c.Timestamp()) {
+		return errSystemInfoCannotBeActivated
+	}
+	return nil
+}
+
+// Equal returns true if [s] is a [*Config] and it has been configured identical to [c].
+func (c *Config) Equal(s precompileconfig.Config) bool {
+	// typecast before comparison
+	other, ok := (s).(*Config)
+	if !ok {
+		return false
+	}
+	return c.Upgrade.Equal(&other.Upgrade)
+}
+
+// PredicateGas returns the amount of gas necessary to verify the validator
+// weight predicate, which is dominated by the lookup of the validator set.
+//
+// If the predicate fails parsing, return a non-nil error invalidating the transaction.
+func (c *Config) PredicateGas(predicateBytes []byte) (uint64, error) {
+	if _, err := parseValidatorWeightPredicate(predicateBytes); err != nil {
+		return 0, err
+	}
+	return ValidatorWeightPredicateGasCost, nil
+}
+
+// VerifyPredicate returns whether the validator weight described by
+// [predicateBytes] matches the validator set at its P-chain height.
+func (c *Config) VerifyPredicate(predicateContext *precompileconfig.PredicateContext, predicateBytes []byte) error {
+	// Note: PredicateGas should be called before VerifyPredicate, so we should never reach an error case here.
+	claim, err := parseValidatorWeightPredicate(predicateBytes)
+	if err != nil {
+		return err
+	}
+	// The P-chain height of the block is known to be available on every
+	// node verifying it, unlike any later height.
+	if blockHeight := predicateContext.ProposerVMBlockCtx.PChainHeight; claim.PChainHeight > blockHeight {
+		return fmt.Errorf("%w: %d > %d", errFuturePChainHeight, claim.PChainHeight, blockHeight)
+	}
+
+	snowCtx := predicateContext.SnowCtx
+	state := warpValidators.NewState(snowCtx.ValidatorState, snowCtx.SubnetID, snowCtx.ChainID, false)
+	validatorSet, err := state.GetValidatorSet(context.Background(), claim.PChainHeight, constants.PrimaryNetworkID)
+	if err != nil {
+		log.Debug("failed to retrieve validator set", "pChainHeight", claim.PChainHeight, "err", err)
+		return fmt.Errorf("%w: %w", errCannotRetrieveValidatorSet, err)
+	}
+
+	var (
+		nodeID      = ids.NodeID(claim.NodeID)
+		weight      uint64
+		totalWeight uint64
+		overflow    bool
+	)
+	for id, vdr := range validatorSet {
+		totalWeight, overflow = math.SafeAdd(totalWeight, vdr.Weight)
+		if overflow {
+			return errOverflowTotalWeight
+		}
+		if id == nodeID {
+			weight = vdr.Weight
+		}
+	}
+	if weight != claim.Weight || totalWeight != claim.TotalWeight {
+		return fmt.Errorf("%w: node %s has weight %d of %d at P-chain height %d", errValidatorWeightMismatch, nodeID, weight, totalWeight, claim.PChainHeight)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package systeminfo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/snow/validators/validatorstest"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerify(t *testing.T) {
+	tests := map[string]testutils.ConfigVerifyTest{
+		"valid config": {
+			Config: NewConfig(utils.NewUint64(3)),
+		},
+		"invalid cannot activated before Durango activation": {
+			Config: NewConfig(utils.NewUint64(3)),
+			ChainConfig: func() precompileconfig.ChainConfig {
+				config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+				config.EXPECT().IsDurango(gomock.Any()).Return(false)
+				return config
+			}(),
+			ExpectedError: errSystemInfoCannotBeActivated.Error(),
+		},
+	}
+	testutils.RunVerifyTests(t, tests)
+}
+
+func TestEqualSystemInfoConfig(t *testing.T) {
+	tests := map[string]testutils.ConfigEqualTest{
+		"non-nil config and nil other": {
+			Config:   NewConfig(utils.NewUint64(3)),
+			Other:    nil,
+			Expected: false,
+		},
+		"different type": {
+			Config:   NewConfig(utils.NewUint64(3)),
+			Other:    precompileconfig.NewMockConfig(gomock.NewController(t)),
+			Expected: false,
+		},
+		"different timestamp": {
+			Config:   NewConfig(utils.NewUint64(3)),
+			Other:    NewConfig(utils.NewUint64(4)),
+			Expected: false,
+		},
+		"disabled": {
+			Config:   NewConfig(utils.NewUint64(3)),
+			Other:    NewDisableConfig(utils.NewUint64(3)),
+			Expected: false,
+		},
+		"same config": {
+			Config:   NewConfig(utils.NewUint64(3)),
+			Other:    NewConfig(utils.NewUint64(3)),
+			Expected: true,
+		},
+	}
+	testutils.RunEqualTests(t, tests)
+}
+
+func TestValidatorWeightPredicate(t *testing.T) {
+	var (
+		nodeID      = ids.GenerateTestNodeID()
+		otherNodeID = ids.GenerateTestNodeID()
+		snowCtx     = utils.TestSnowContext()
+	)
+	snowCtx.ValidatorState = &validatorstest.State{
+		GetValidatorSetF: func(ctx context.Context, height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			if subnetID != constants.PrimaryNetworkID {
+				return nil, errors.New("unexpected subnet")
+			}
+			if height == 2 {
+				return nil, errors.New("unavailable height")
+			}
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				nodeID:      {NodeID: nodeID, Weight: 100 * height},
+				otherNodeID: {NodeID: otherNodeID, Weight: 200},
+			}, nil
+		},
+	}
+	predicateContext := &precompileconfig.PredicateContext{
+		SnowCtx:            snowCtx,
+		ProposerVMBlockCtx: &block.Context{PChainHeight: 3},
+	}
+	newTest := func(predicateBytes []byte, expectedErr error) testutils.PredicateTest {
+		return testutils.PredicateTest{
+			Config:           NewConfig(utils.NewUint64(0)),
+			PredicateContext: predicateContext,
+			PredicateBytes:   predicateBytes,
+			Gas:              ValidatorWeightPredicateGasCost,
+			ExpectedErr:      expectedErr,
+		}
+	}
+
+	tests := map[string]testutils.PredicateTest{
+		"valid weight":                   newTest(NewValidatorWeight(nodeID, 300, 500, 3).Predicate(), nil),
+		"valid weight at earlier height": newTest(NewValidatorWeight(nodeID, 100, 300, 1).Predicate(), nil),
+		"valid weight of non-validator":  newTest(NewValidatorWeight(ids.GenerateTestNodeID(), 0, 500, 3).Predicate(), nil),
+		"invalid weight":                 newTest(NewValidatorWeight(nodeID, 200, 500, 3).Predicate(), errValidatorWeightMismatch),
+		"invalid total weight":           newTest(NewValidatorWeight(nodeID, 300, 400, 3).Predicate(), errValidatorWeightMismatch),
+		"future height":                  newTest(NewValidatorWeight(nodeID, 400, 600, 4).Predicate(), errFuturePChainHeight),
+		"unavailable height":             newTest(NewValidatorWeight(nodeID, 200, 400, 2).Predicate(), errCannotRetrieveValidatorSet),
+		"invalid predicate packing": {
+			Config:           NewConfig(utils.NewUint64(0)),
+			PredicateContext: predicateContext,
+			PredicateBytes:   NewValidatorWeight(nodeID, 300, 500, 3).Bytes(),
+			GasErr:           errInvalidPredicateBytes,
+		},
+		"invalid validator weight length": {
+			Config:           NewConfig(utils.NewUint64(0)),
+			PredicateContext: predicateContext,
+			PredicateBytes:   NewValidatorWeight(nodeID, 300, 500, 3).Predicate()[1:],
+			GasErr:           errInvalidPredicateBytes,
+		},
+	}
+	testutils.RunPredicateTests(t, tests)
+}
+
+func TestValidatorWeightBytes(t *testing.T) {
+	require := require.New(t)
+
+	validatorWeight := NewValidatorWeight(ids.GenerateTestNodeID(), 1, 2, 3)
+	parsed, err := ParseValidatorWeight(validatorWeight.Bytes())
+	require.NoError(err)
+	require.Equal(validatorWeight, parsed)
+
+	_, err = ParseValidatorWeight(validatorWeight.Bytes()[1:])
+	require.ErrorIs(err, errInvalidValidatorWeight)
+}
@@ -0,0 +1,262 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package systeminfo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/coreth/accounts/abi"
+	"github.com/ava-labs/coreth/precompile/contract"
+
+	_ "embed"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+)
+
+const (
+	GetVerifiedValidatorWeightBaseCost uint64 = 2   // Base cost of entering getVerifiedValidatorWeight
+	GetUpgradeActivationTimeGasCost    uint64 = 200 // Cost of reading the chain config
+	// Cost of reading the 5 slots of the daemon mint state
+	GetDaemonMintStateGasCost uint64 = 5 * contract.ReadGasCostPerSlot
+
+	// ValidatorWeightPredicateGasCost is charged as intrinsic gas for each
+	// validator weight predicate, to cover the lookup of the validator set.
+	ValidatorWeightPredicateGasCost uint64 = 20_000
+)
+
+var (
+	errInvalidIndexInput   = errors.New("invalid index to specify validator weight")
+	errInvalidUpgradeInput = errors.New("invalid getUpgradeActivationTime input")
+	errUnknownUpgrade      = errors.New("unknown network upgrade")
+)
+
+// Singleton StatefulPrecompiledContract and signatures.
+var (
+	// SystemInfoRawABI contains the raw ABI of the system info contract.
+	//go:embed contract.abi
+	SystemInfoRawABI string
+
+	SystemInfoABI = contract.ParseABI(SystemInfoRawABI)
+
+	SystemInfoPrecompile = createSystemInfoPrecompile()
+
+	getVerifiedValidatorWeightInvalidOutput []byte
+)
+
+func init() {
+	res, err := PackGetVerifiedValidatorWeightOutput(GetVerifiedValidatorWeightOutput{Valid: false})
+	if err != nil {
+		panic(err)
+	}
+	getVerifiedValidatorWeightInvalidOutput = res
+}
+
+type GetVerifiedValidatorWeightOutput struct {
+	ValidatorWeight ValidatorWeight
+	Valid           bool
+}
+
+type GetUpgradeActivationTimeOutput struct {
+	Timestamp uint64
+	Activated bool
+}
+
+// PackGetVerifiedValidatorWeight packs [index] of type uint32 into the appropriate arguments for getVerifiedValidatorWeight.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetVerifiedValidatorWeight(index uint32) ([]byte, error) {
+	return SystemInfoABI.Pack("getVerifiedValidatorWeight", index)
+}
+
+// UnpackGetVerifiedValidatorWeightInput attempts to unpack [input] into the uint32 type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetVerifiedValidatorWeightInput(input []byte) (uint32, error) {
+	res, err := SystemInfoABI.UnpackInput("getVerifiedValidatorWeight", input, false)
+	if err != nil {
+		return 0, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(uint32)).(*uint32)
+	return unpacked, nil
+}
+
+// PackGetVerifiedValidatorWeightOutput attempts to pack given [outputStruct] of type GetVerifiedValidatorWeightOutput
+// to conform the ABI outputs.
+func PackGetVerifiedValidatorWeightOutput(outputStruct GetVerifiedValidatorWeightOutput) ([]byte, error) {
+	return SystemInfoABI.PackOutput("getVerifiedValidatorWeight",
+		outputStruct.ValidatorWeight,
+		outputStruct.Valid,
+	)
+}
+
+// UnpackGetVerifiedValidatorWeightOutput attempts to unpack [output] as GetVerifiedValidatorWeightOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetVerifiedValidatorWeightOutput(output []byte) (GetVerifiedValidatorWeightOutput, error) {
+	outputStruct := GetVerifiedValidatorWeightOutput{}
+	err := SystemInfoABI.UnpackIntoInterface(&outputStruct, "getVerifiedValidatorWeight", output)
+
+	return outputStruct, err
+}
+
+// getVerifiedValidatorWeight retrieves the pre-verified validator weight from the predicate storage slots and returns
+// the expected ABI encoding of the weight to the caller.
+func getVerifiedValidatorWeight(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetVerifiedValidatorWeightBaseCost); err != nil {
+		return nil, 0, err
+	}
+	indexInput, err := UnpackGetVerifiedValidatorWeightInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidIndexInput, err)
+	}
+	if indexInput > math.MaxInt32 {
+		return nil, remainingGas, fmt.Errorf("%w: larger than MaxInt32", errInvalidIndexInput)
+	}
+	index := int(indexInput) // This conversion is safe even if int is 32 bits because we checked above.
+	state := accessibleState.GetStateDB()
+	predicateBytes, exists := state.GetPredicateStorageSlots(ContractAddress, index)
+	predicateResults := accessibleState.GetBlockContext().GetPredicateResults(state.GetTxHash(), ContractAddress)
+	valid := exists && !set.BitsFromBytes(predicateResults).Contains(index)
+	if !valid {
+		return getVerifiedValidatorWeightInvalidOutput, remainingGas, nil
+	}
+	// Note: since the predicate is verified in advance of execution, the precompile should not
+	// hit an error during execution.
+	validatorWeight, err := parseValidatorWeightPredicate(predicateBytes)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	packedOutput, err := PackGetVerifiedValidatorWeightOutput(GetVerifiedValidatorWeightOutput{
+		ValidatorWeight: validatorWeight,
+		Valid:           true,
+	})
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackGetUpgradeActivationTime packs [upgrade] of type string into the appropriate arguments for getUpgradeActivationTime.
+// the packed bytes include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetUpgradeActivationTime(upgrade string) ([]byte, error) {
+	return SystemInfoABI.Pack("getUpgradeActivationTime", upgrade)
+}
+
+// UnpackGetUpgradeActivationTimeInput attempts to unpack [input] into the string type argument
+// assumes that [input] does not include selector (omits first 4 func signature bytes)
+func UnpackGetUpgradeActivationTimeInput(input []byte) (string, error) {
+	res, err := SystemInfoABI.UnpackInput("getUpgradeActivationTime", input, false)
+	if err != nil {
+		return "", err
+	}
+	unpacked := *abi.ConvertType(res[0], new(string)).(*string)
+	return unpacked, nil
+}
+
+// PackGetUpgradeActivationTimeOutput attempts to pack given [outputStruct] of type GetUpgradeActivationTimeOutput
+// to conform the ABI outputs.
+func PackGetUpgradeActivationTimeOutput(outputStruct GetUpgradeActivationTimeOutput) ([]byte, error) {
+	return SystemInfoABI.PackOutput("getUpgradeActivationTime",
+		outputStruct.Timestamp,
+		outputStruct.Activated,
+	)
+}
+
+// UnpackGetUpgradeActivationTimeOutput attempts to unpack [output] as GetUpgradeActivationTimeOutput
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetUpgradeActivationTimeOutput(output []byte) (GetUpgradeActivationTimeOutput, error) {
+	outputStruct := GetUpgradeActivationTimeOutput{}
+	err := SystemInfoABI.UnpackIntoInterface(&outputStruct, "getUpgradeActivationTime", output)
+
+	return outputStruct, err
+}
+
+// getUpgradeActivationTime returns the activation timestamp of a network upgrade of the chain config,
+// if the upgrade is activated at the block timestamp. The timestamp of an activated upgrade cannot
+// change, as the chain config is rejected if it is incompatible with the activated upgrades, while
+// the schedule of upgrades still to be activated may differ between node versions.
+func getUpgradeActivationTime(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetUpgradeActivationTimeGasCost); err != nil {
+		return nil, 0, err
+	}
+	upgrade, err := UnpackGetUpgradeActivationTimeInput(input)
+	if err != nil {
+		return nil, remainingGas, fmt.Errorf("%w: %s", errInvalidUpgradeInput, err)
+	}
+	timestamp, ok := accessibleState.GetChainConfig().UpgradeTimestamp(upgrade)
+	if !ok {
+		return nil, remainingGas, fmt.Errorf("%w: %q", errUnknownUpgrade, upgrade)
+	}
+	output := GetUpgradeActivationTimeOutput{}
+	if timestamp != nil && *timestamp <= accessibleState.GetBlockContext().Timestamp() {
+		output.Timestamp, output.Activated = *timestamp, true
+	}
+	packedOutput, err := PackGetUpgradeActivationTimeOutput(output)
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// PackGetDaemonMintState packs the include selector (first 4 func signature bytes).
+// This function is mostly used for tests.
+func PackGetDaemonMintState() ([]byte, error) {
+	return SystemInfoABI.Pack("getDaemonMintState")
+}
+
+// PackGetDaemonMintStateOutput attempts to pack given [outputStruct] of type DaemonMintState
+// to conform the ABI outputs.
+func PackGetDaemonMintStateOutput(outputStruct DaemonMintState) ([]byte, error) {
+	return SystemInfoABI.PackOutput("getDaemonMintState", outputStruct)
+}
+
+// UnpackGetDaemonMintStateOutput attempts to unpack [output] as DaemonMintState
+// assumes that [output] does not include selector (omits first 4 func signature bytes)
+func UnpackGetDaemonMintStateOutput(output []byte) (DaemonMintState, error) {
+	res, err := SystemInfoABI.Unpack("getDaemonMintState", output)
+	if err != nil {
+		return DaemonMintState{}, err
+	}
+	unpacked := *abi.ConvertType(res[0], new(DaemonMintState)).(*DaemonMintState)
+	return unpacked, nil
+}
+
+// getDaemonMintState returns the daemon mint state recorded since the precompile was activated.
+func getDaemonMintState(accessibleState contract.AccessibleState, caller common.Address, addr common.Address, input []byte, suppliedGas uint64, readOnly bool) (ret []byte, remainingGas uint64, err error) {
+	if remainingGas, err = contract.DeductGas(suppliedGas, GetDaemonMintStateGasCost); err != nil {
+		return nil, 0, err
+	}
+	packedOutput, err := PackGetDaemonMintStateOutput(GetDaemonMintState(accessibleState.GetStateDB()))
+	if err != nil {
+		return nil, remainingGas, err
+	}
+	return packedOutput, remainingGas, nil
+}
+
+// createSystemInfoPrecompile returns a StatefulPrecompiledContract with getters for the precompile.
+func createSystemInfoPrecompile() contract.StatefulPrecompiledContract {
+	var functions []*contract.StatefulPrecompileFunction
+
+	abiFunctionMap := map[string]contract.RunStatefulPrecompileFunc{
+		"getDaemonMintState":         getDaemonMintState,
+		"getUpgradeActivationTime":   getUpgradeActivationTime,
+		"getVerifiedValidatorWeight": getVerifiedValidatorWeight,
+	}
+
+	for name, function := range abiFunctionMap {
+		method, ok := SystemInfoABI.Methods[name]
+		if !ok {
+			panic(fmt.Errorf("given method (%s) does not exist in the ABI", name))
+		}
+		functions = append(functions, contract.NewStatefulPrecompileFunction(method.ID, function))
+	}
+	// Construct the contract with no fallback function.
+	statefulContract, err := contract.NewStatefulPrecompileContract(nil, functions)
+	if err != nil {
+		panic(err)
+	}
+	return statefulContract
+}
@@ -0,0 +1,264 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package systeminfo
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ava-labs/coreth/precompile/precompileconfig"
+	"github.com/ava-labs/coreth/precompile/testutils"
+	"github.com/ava-labs/coreth/utils"
+	"github.com/ava-labs/coreth/vmerrs"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetVerifiedValidatorWeight(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+	validatorWeight := NewValidatorWeight(ids.GenerateTestNodeID(), 100, 1000, 5)
+	getVerifiedValidatorWeight, err := PackGetVerifiedValidatorWeight(0)
+	require.NoError(t, err)
+	noFailures := set.NewBits().Bytes()
+	require.Len(t, noFailures, 0)
+	invalidOutput, err := PackGetVerifiedValidatorWeightOutput(GetVerifiedValidatorWeightOutput{Valid: false})
+	require.NoError(t, err)
+
+	tests := map[string]testutils.PrecompileTest{
+		"get validator weight success": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedValidatorWeight },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{validatorWeight.Predicate()})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedValidatorWeightBaseCost,
+			ReadOnly:    true,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedValidatorWeightOutput(GetVerifiedValidatorWeightOutput{
+					ValidatorWeight: validatorWeight,
+					Valid:           true,
+				})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get validator weight success non-zero index": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetVerifiedValidatorWeight(1)
+				require.NoError(t, err)
+				return input
+			},
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{{}, validatorWeight.Predicate()})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+			},
+			SuppliedGas: GetVerifiedValidatorWeightBaseCost,
+			ExpectedRes: func() []byte {
+				res, err := PackGetVerifiedValidatorWeightOutput(GetVerifiedValidatorWeightOutput{
+					ValidatorWeight: validatorWeight,
+					Valid:           true,
+				})
+				if err != nil {
+					panic(err)
+				}
+				return res
+			}(),
+		},
+		"get validator weight failed verification": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getVerifiedValidatorWeight },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{validatorWeight.Predicate()})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(set.NewBits(0).Bytes())
+			},
+			SuppliedGas: GetVerifiedValidatorWeightBaseCost,
+			ExpectedRes: invalidOutput,
+		},
+		"get validator weight out of bounds": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetVerifiedValidatorWeight(1)
+				require.NoError(t, err)
+				return input
+			},
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				state.SetPredicateStorageSlots(ContractAddress, [][]byte{validatorWeight.Predicate()})
+			},
+			SetupBlockContext: func(mbc *contract.MockBlockContext) {
+				mbc.EXPECT().GetPredicateResults(common.Hash{}, ContractAddress).Return(noFailures)
+			},
+			SuppliedGas: GetVerifiedValidatorWeightBaseCost,
+			ExpectedRes: invalidOutput,
+		},
+		"get validator weight index larger than MaxInt32": {
+			Caller: callerAddr,
+			InputFn: func(t testing.TB) []byte {
+				input, err := PackGetVerifiedValidatorWeight(math.MaxInt32 + 1)
+				require.NoError(t, err)
+				return input
+			},
+			SuppliedGas: GetVerifiedValidatorWeightBaseCost,
+			ExpectedErr: errInvalidIndexInput.Error(),
+		},
+		"get validator weight insufficient gas": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return getVerifiedValidatorWeight },
+			SuppliedGas: GetVerifiedValidatorWeightBaseCost - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestGetUpgradeActivationTime(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+	chainConfig := func(t testing.TB) precompileconfig.ChainConfig {
+		config := precompileconfig.NewMockChainConfig(gomock.NewController(t))
+		config.EXPECT().UpgradeTimestamp("durangoBlockTimestamp").Return(utils.NewUint64(1_000), true).AnyTimes()
+		config.EXPECT().UpgradeTimestamp("fortunaTimestamp").Return(nil, true).AnyTimes()
+		config.EXPECT().UpgradeTimestamp("unknown").Return(nil, false).AnyTimes()
+		return config
+	}
+	packInput := func(upgrade string) func(t testing.TB) []byte {
+		return func(t testing.TB) []byte {
+			input, err := PackGetUpgradeActivationTime(upgrade)
+			require.NoError(t, err)
+			return input
+		}
+	}
+	packOutput := func(output GetUpgradeActivationTimeOutput) []byte {
+		res, err := PackGetUpgradeActivationTimeOutput(output)
+		require.NoError(t, err)
+		return res
+	}
+
+	blockTime := func(timestamp uint64) func(*contract.MockBlockContext) {
+		return func(mbc *contract.MockBlockContext) {
+			mbc.EXPECT().Timestamp().Return(timestamp).AnyTimes()
+		}
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"activated upgrade": {
+			Caller:            callerAddr,
+			InputFn:           packInput("durangoBlockTimestamp"),
+			ChainConfig:       chainConfig(t),
+			SetupBlockContext: blockTime(1_000),
+			SuppliedGas:       GetUpgradeActivationTimeGasCost,
+			ReadOnly:          true,
+			ExpectedRes:       packOutput(GetUpgradeActivationTimeOutput{Timestamp: 1_000, Activated: true}),
+		},
+		"upgrade scheduled after the block": {
+			Caller:            callerAddr,
+			InputFn:           packInput("durangoBlockTimestamp"),
+			ChainConfig:       chainConfig(t),
+			SetupBlockContext: blockTime(999),
+			SuppliedGas:       GetUpgradeActivationTimeGasCost,
+			ExpectedRes:       packOutput(GetUpgradeActivationTimeOutput{}),
+		},
+		"unscheduled upgrade": {
+			Caller:            callerAddr,
+			InputFn:           packInput("fortunaTimestamp"),
+			ChainConfig:       chainConfig(t),
+			SetupBlockContext: blockTime(1_000),
+			SuppliedGas:       GetUpgradeActivationTimeGasCost,
+			ExpectedRes:       packOutput(GetUpgradeActivationTimeOutput{}),
+		},
+		"unknown upgrade": {
+			Caller:      callerAddr,
+			InputFn:     packInput("unknown"),
+			ChainConfig: chainConfig(t),
+			SuppliedGas: GetUpgradeActivationTimeGasCost,
+			ExpectedErr: errUnknownUpgrade.Error(),
+		},
+		"insufficient gas": {
+			Caller:      callerAddr,
+			InputFn:     packInput("durangoBlockTimestamp"),
+			ChainConfig: chainConfig(t),
+			SuppliedGas: GetUpgradeActivationTimeGasCost - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
+
+func TestGetDaemonMintState(t *testing.T) {
+	callerAddr := common.HexToAddress("0x0123")
+	daemon := common.HexToAddress("0x1000000000000000000000000000000000000002")
+	getDaemonMintState, err := PackGetDaemonMintState()
+	require.NoError(t, err)
+	packOutput := func(output DaemonMintState) []byte {
+		res, err := PackGetDaemonMintStateOutput(output)
+		require.NoError(t, err)
+		return res
+	}
+
+	tests := map[string]testutils.PrecompileTest{
+		"no daemon invocation": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return getDaemonMintState },
+			SuppliedGas: GetDaemonMintStateGasCost,
+			ReadOnly:    true,
+			ExpectedRes: packOutput(DaemonMintState{
+				LastMintRequest: new(big.Int),
+				LastMinted:      new(big.Int),
+				TotalMinted:     new(big.Int),
+			}),
+		},
+		"daemon invocations": {
+			Caller:  callerAddr,
+			InputFn: func(t testing.TB) []byte { return getDaemonMintState },
+			BeforeHook: func(t testing.TB, state contract.StateDB) {
+				StoreDaemonMint(state, daemon, big.NewInt(10), big.NewInt(10), 1)
+				// A rejected mint request does not mint.
+				StoreDaemonMint(state, daemon, big.NewInt(30), new(big.Int), 2)
+				StoreDaemonMint(state, daemon, big.NewInt(20), big.NewInt(20), 3)
+				// Invocations without a mint request are not recorded.
+				StoreDaemonMint(state, daemon, new(big.Int), new(big.Int), 4)
+			},
+			SuppliedGas: GetDaemonMintStateGasCost,
+			ReadOnly:    true,
+			ExpectedRes: packOutput(DaemonMintState{
+				Daemon:          daemon,
+				LastMintRequest: big.NewInt(20),
+				LastMinted:      big.NewInt(20),
+				TotalMinted:     big.NewInt(30),
+				LastMintBlock:   3,
+			}),
+			AfterHook: func(t testing.TB, state contract.StateDB) {
+				res, err := PackGetDaemonMintStateOutput(GetDaemonMintState(state))
+				require.NoError(t, err)
+				unpacked, err := UnpackGetDaemonMintStateOutput(res)
+				require.NoError(t, err)
+				require.Equal(t, GetDaemonMintState(state), unpacked)
+			},
+		},
+		"insufficient gas": {
+			Caller:      callerAddr,
+			InputFn:     func(t testing.TB) []byte { return getDaemonMintState },
+			SuppliedGas: GetDaemonMintStateGasCost - 1,
+			ExpectedErr: vmerrs.ErrOutOfGas.Error(),
+		},
+	}
+
+	testutils.RunPrecompileTests(t, Module, state.NewTestStateDB, tests)
+}
@@ -0,0 +1,64 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package systeminfo
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/coreth/precompile/contract"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Storage keys of the daemon mint state in the precompile account.
+var (
+	daemonContractKey  = common.Hash{'d', 'c'}
+	lastMintRequestKey = common.Hash{'l', 'm', 'r'}
+	lastMintedKey      = common.Hash{'l', 'm'}
+	totalMintedKey     = common.Hash{'t', 'm'}
+	lastMintBlockKey   = common.Hash{'l', 'm', 'b'}
+)
+
+// DaemonMintState is the state of the inflation daemon, as returned by
+// getDaemonMintState. It describes the last daemon invocation requesting a
+// mint.
+type DaemonMintState struct {
+	Daemon          common.Address
+	LastMintRequest *big.Int
+	LastMinted      *big.Int
+	TotalMinted     *big.Int
+	LastMintBlock   uint64
+}
+
+// StoreDaemonMint records an invocation of the [daemon] contract that
+// requested [mintRequest] and minted [minted] in the block [blockNumber].
+// Invocations not requesting a mint, including failed ones, are not recorded,
+// so that the state keeps describing the last mint request.
+// It must only be called while the precompile is enabled, as the state of a
+// disabled precompile is not kept.
+func StoreDaemonMint(state contract.StateDB, daemon common.Address, mintRequest *big.Int, minted *big.Int, blockNumber uint64) {
+	if mintRequest.Sign() <= 0 {
+		return
+	}
+	if daemonHash := common.BytesToHash(daemon.Bytes()); state.GetState(ContractAddress, daemonContractKey) != daemonHash {
+		state.SetState(ContractAddress, daemonContractKey, daemonHash)
+	}
+	state.SetState(ContractAddress, lastMintRequestKey, common.BigToHash(mintRequest))
+	state.SetState(ContractAddress, lastMintedKey, common.BigToHash(minted))
+	if minted.Sign() > 0 {
+		totalMinted := new(big.Int).Add(state.GetState(ContractAddress, totalMintedKey).Big(), minted)
+		state.SetState(ContractAddress, totalMintedKey, common.BigToHash(totalMinted))
+	}
+	state.SetState(ContractAddress, lastMintBlockKey, common.BigToHash(new(big.Int).SetUint64(blockNumber)))
+}
+
+// GetDaemonMintState returns the daemon mint state stored in [state].
+func GetDaemonMintState(state contract.StateDB) DaemonMintState {
+	return DaemonMintState{
+		Daemon:          common.BytesToAddress(state.GetState(ContractAddress, daemonContractKey).Bytes()),
+		LastMintRequest: state.GetState(ContractAddress, lastMintRequestKey).Big(),
+		LastMinted:      state.GetState(ContractAddress, lastMintedKey).Big(),
+		TotalMinted:     state.GetState(ContractAddress, totalMintedKey).Big(),
+		LastMintBlock:   state.GetState(ContractAddress, lastMintBlockKey).Big().Uint64(),
+	}
+}
@@ -0,0 +1,78 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package systeminfo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/coreth/predicate"
+)
+
+// validatorWeightLen is the length of an encoded [ValidatorWeight].
+const validatorWeightLen = ids.NodeIDLen + 3*8
+
+var (
+	errInvalidPredicateBytes  = errors.New("cannot unpack predicate bytes")
+	errInvalidValidatorWeight = errors.New("cannot parse validator weight")
+)
+
+// ValidatorWeight is the weight of a P-chain validator and the total weight of
+// the validator set at a P-chain height, as returned by getVerifiedValidatorWeight.
+type ValidatorWeight struct {
+	NodeID       [ids.NodeIDLen]byte
+	Weight       uint64
+	TotalWeight  uint64
+	PChainHeight uint64
+}
+
+// NewValidatorWeight returns the claim that [nodeID] has [weight] out of
+// [totalWeight] at [pChainHeight].
+func NewValidatorWeight(nodeID ids.NodeID, weight, totalWeight, pChainHeight uint64) ValidatorWeight {
+	return ValidatorWeight{
+		NodeID:       [ids.NodeIDLen]byte(nodeID),
+		Weight:       weight,
+		TotalWeight:  totalWeight,
+		PChainHeight: pChainHeight,
+	}
+}
+
+// Bytes returns the binary encoding of [v].
+func (v ValidatorWeight) Bytes() []byte {
+	b := make([]byte, 0, validatorWeightLen)
+	b = append(b, v.NodeID[:]...)
+	b = binary.BigEndian.AppendUint64(b, v.Weight)
+	b = binary.BigEndian.AppendUint64(b, v.TotalWeight)
+	return binary.BigEndian.AppendUint64(b, v.PChainHeight)
+}
+
+// Predicate returns the predicate bytes to include in the access list of a
+// transaction under [ContractAddress] for [v] to be verified.
+func (v ValidatorWeight) Predicate() []byte {
+	return predicate.PackPredicate(v.Bytes())
+}
+
+// ParseValidatorWeight parses the binary encoding of a [ValidatorWeight].
+func ParseValidatorWeight(b []byte) (ValidatorWeight, error) {
+	if len(b) != validatorWeightLen {
+		return ValidatorWeight{}, fmt.Errorf("%w: expected %d bytes, got %d", errInvalidValidatorWeight, validatorWeightLen, len(b))
+	}
+	var v ValidatorWeight
+	copy(v.NodeID[:], b)
+	b = b[ids.NodeIDLen:]
+	v.Weight = binary.BigEndian.Uint64(b)
+	v.TotalWeight = binary.BigEndian.Uint64(b[8:])
+	v.PChainHeight = binary.BigEndian.Uint64(b[16:])
+	return v, nil
+}
+
+func parseValidatorWeightPredicate(predicateBytes []byte) (ValidatorWeight, error) {
+	unpackedPredicateBytes, err := predicate.UnpackPredicate(predicateBytes)
+	if err != nil {
+		return ValidatorWeight{}, fmt.Errorf("%w: %w", errInvalidPredicateBytes, err)
+	}
+	return ParseValidatorWeight(unpackedPredicateBytes)
+}
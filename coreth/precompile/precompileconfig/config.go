@@ -76,4 +76,8 @@ type Accepter interface {
 type ChainConfig interface {
 	// IsDurango returns true if the time is after Durango.
 	IsDurango(time uint64) bool
+	// UpgradeTimestamp returns the activation timestamp of the network upgrade
+	// configured by the json field [name] and whether [name] is a known
+	// network upgrade.
+	UpgradeTimestamp(name string) (*uint64, bool)
 }
@@ -201,6 +201,21 @@ func (mr *MockChainConfigMockRecorder) IsDurango(time any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDurango", reflect.TypeOf((*MockChainConfig)(nil).IsDurango), time)
 }
 
+// UpgradeTimestamp mocks base method.
+func (m *MockChainConfig) UpgradeTimestamp(name string) (*uint64, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpgradeTimestamp", name)
+	ret0, _ := ret[0].(*uint64)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// UpgradeTimestamp indicates an expected call of UpgradeTimestamp.
+func (mr *MockChainConfigMockRecorder) UpgradeTimestamp(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeTimestamp", reflect.TypeOf((*MockChainConfig)(nil).UpgradeTimestamp), name)
+}
+
 // MockAccepter is a mock of Accepter interface.
 type MockAccepter struct {
 	ctrl     *gomock.Controller
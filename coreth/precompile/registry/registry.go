@@ -7,5 +7,6 @@ package registry
 // Force imports of each precompile to ensure each precompile's init function runs and registers itself
 // with the registry.
 import (
+	_ "github.com/ava-labs/coreth/precompile/contracts/systeminfo"
 	_ "github.com/ava-labs/coreth/precompile/contracts/warp"
 )
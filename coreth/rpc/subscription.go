@@ -118,6 +118,7 @@ type Notifier struct {
 	buffer       []any
 	callReturned bool
 	activated    bool
+	unsubscribed bool
 }
 
 // CreateSubscription returns a new subscription that is coupled to the
@@ -148,6 +149,9 @@ func (n *Notifier) Notify(id ID, data any) error {
 	} else if n.sub.ID != id {
 		panic("Notify with wrong ID")
 	}
+	if n.unsubscribed {
+		return ErrSubscriptionNotFound
+	}
 	if n.activated {
 		return n.send(n.sub, data)
 	}
@@ -155,18 +159,46 @@ func (n *Notifier) Notify(id ID, data any) error {
 	return nil
 }
 
+// Unsubscribe ends the subscription from the server side, as if the client
+// unsubscribed: it is removed from the RPC connection and [err] is delivered on
+// its error channel. Notifications are no longer sent.
+func (n *Notifier) Unsubscribe(err error) {
+	n.h.subLock.Lock()
+	defer n.h.subLock.Unlock()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.sub == nil || n.unsubscribed {
+		return
+	}
+	if n.callReturned {
+		// The subscription was already ended by the client or the connection
+		// if it is no longer held by the connection.
+		if _, ok := n.h.serverSubs[n.sub.ID]; !ok {
+			return
+		}
+		delete(n.h.serverSubs, n.sub.ID)
+	}
+	n.unsubscribed = true
+	n.sub.err <- err
+	close(n.sub.err)
+}
+
 // Closed returns a channel that is closed when the RPC connection is closed.
 // Deprecated: use subscription error channel
 func (n *Notifier) Closed() <-chan interface{} {
 	return n.h.conn.closed()
 }
 
-// takeSubscription returns the subscription (if one has been created). No subscription can
-// be created after this call.
+// takeSubscription returns the subscription (if one has been created and was not
+// unsubscribed). No subscription can be created after this call.
 func (n *Notifier) takeSubscription() *Subscription {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 	n.callReturned = true
+	if n.unsubscribed {
+		return nil
+	}
 	return n.sub
 }
 
@@ -188,6 +188,38 @@ func TestServerUnsubscribe(t *testing.T) {
 	}
 }
 
+// This test checks that the server can end a subscription.
+func TestNotifierUnsubscribe(t *testing.T) {
+	server := newTestServer()
+	defer server.Stop()
+	service := &notificationTestService{failed: make(chan error, 1)}
+	server.RegisterName("nftest2", service)
+	client := DialInProc(server)
+	defer client.Close()
+
+	sub, err := client.Subscribe(context.Background(), "nftest2", make(chan int), "failingSubscription")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	select {
+	case err := <-service.failed:
+		if err != errSubscriptionFailed {
+			t.Fatalf("wrong subscription error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not ended")
+	}
+
+	// The subscription is no longer held by the connection.
+	var ok bool
+	err = client.Call(&ok, "nftest2_unsubscribe", sub.subid)
+	if err == nil || err.Error() != ErrSubscriptionNotFound.Error() {
+		t.Fatalf("wrong unsubscribe error: %v", err)
+	}
+}
+
 type subConfirmation struct {
 	reqid int
 	subid ID
@@ -173,8 +173,11 @@ func (s *testService) Subscription(ctx context.Context) (*Subscription, error) {
 	return nil, nil
 }
 
+var errSubscriptionFailed = errors.New("subscription failed")
+
 type notificationTestService struct {
 	unsubscribed            chan string
+	failed                  chan error
 	gotHangSubscriptionReq  chan struct{}
 	unblockHangSubscription chan struct{}
 }
@@ -216,6 +219,23 @@ func (s *notificationTestService) SomeSubscription(ctx context.Context, n, val i
 	return subscription, nil
 }
 
+// FailingSubscription ends its subscription from the server side with an error.
+func (s *notificationTestService) FailingSubscription(ctx context.Context) (*Subscription, error) {
+	notifier, supported := NotifierFromContext(ctx)
+	if !supported {
+		return nil, ErrNotificationsUnsupported
+	}
+	subscription := notifier.CreateSubscription()
+	go func() {
+		notifier.Unsubscribe(errSubscriptionFailed)
+		err := <-subscription.Err()
+		if s.failed != nil {
+			s.failed <- err
+		}
+	}()
+	return subscription, nil
+}
+
 // HangSubscription blocks on s.unblockHangSubscription before sending anything.
 func (s *notificationTestService) HangSubscription(ctx context.Context, val int) (*Subscription, error) {
 	notifier, supported := NotifierFromContext(ctx)
@@ -33,6 +33,7 @@ import (
 
 const (
 	failedRequestSleepInterval = 10 * time.Millisecond
+	maxThrottledSleepInterval  = 10 * time.Second
 
 	epsilon = 1e-6 // small amount to add to time to avoid division by 0
 )
@@ -345,15 +346,27 @@ func (c *client) get(ctx context.Context, request message.Request, parseFn parse
 		metric.UpdateRequestLatency(time.Since(start))
 
 		if err != nil {
-			ctx := make([]interface{}, 0, 8)
+			logCtx := make([]interface{}, 0, 8)
 			if nodeID != ids.EmptyNodeID {
-				ctx = append(ctx, "nodeID", nodeID)
+				logCtx = append(logCtx, "nodeID", nodeID)
 			}
-			ctx = append(ctx, "attempt", attempt, "request", request, "err", err)
-			log.Debug("request failed, retrying", ctx...)
+			logCtx = append(logCtx, "attempt", attempt, "request", request, "err", err)
+			log.Debug("request failed, retrying", logCtx...)
 			metric.IncFailed()
 			c.networkClient.TrackBandwidth(nodeID, 0)
-			time.Sleep(failedRequestSleepInterval)
+			sleepInterval := failedRequestSleepInterval
+			var throttledErr *peer.ThrottledError
+			if errors.As(err, &throttledErr) {
+				// Honour the backoff of the throttling peer, up to a limit so
+				// that a peer can't stall the sync.
+				lastErr = err
+				sleepInterval = min(max(throttledErr.RetryAfter, failedRequestSleepInterval), maxThrottledSleepInterval)
+			}
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("request failed after %d attempts with last error %w and ctx error %s", attempt+1, err, ctx.Err())
+			case <-time.After(sleepInterval):
+			}
 			continue
 		} else {
 			responseIntf, numElements, err = parseFn(c.codec, request, response)
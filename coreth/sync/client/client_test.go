@@ -10,6 +10,7 @@ import (
 	"math/rand"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/ava-labs/coreth/core/rawdb"
 	"github.com/ava-labs/coreth/core/types"
 	"github.com/ava-labs/coreth/params"
+	"github.com/ava-labs/coreth/peer"
 	"github.com/ava-labs/coreth/plugin/evm/message"
 	clientstats "github.com/ava-labs/coreth/sync/client/stats"
 	"github.com/ava-labs/coreth/sync/handlers"
@@ -854,6 +856,51 @@ func TestGetLeafsRetries(t *testing.T) {
 	assert.True(t, strings.Contains(err.Error(), context.Canceled.Error()))
 }
 
+func TestGetLeafsHonoursThrottling(t *testing.T) {
+	trieDB := triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil)
+	root, _, _ := syncutils.GenerateTrie(t, trieDB, 1_000, common.HashLength)
+
+	handler := handlers.NewLeafsRequestHandler(trieDB, nil, message.Codec, handlerstats.NewNoopHandlerStats())
+	mockNetClient := &mockNetwork{}
+	client := NewClient(&ClientConfig{
+		NetworkClient: mockNetClient,
+		Codec:         message.Codec,
+		Stats:         clientstats.NewNoOpStats(),
+		BlockParser:   mockBlockParser,
+	})
+
+	request := message.LeafsRequest{
+		Root:     root,
+		Start:    bytes.Repeat([]byte{0x00}, common.HashLength),
+		End:      bytes.Repeat([]byte{0xff}, common.HashLength),
+		Limit:    1024,
+		NodeType: message.StateTrieNode,
+	}
+	goodResponse, err := handler.OnLeafsRequest(context.Background(), ids.GenerateTestNodeID(), 1, request)
+	assert.NoError(t, err)
+
+	// The request is retried once the peer's backoff has passed.
+	const retryAfter = 100 * time.Millisecond
+	mockNetClient.mockResponses(nil, nil, goodResponse)
+	mockNetClient.requestErr = []error{fmt.Errorf("%w: %w", peer.ErrRequestFailed, &peer.ThrottledError{RetryAfter: retryAfter})}
+
+	start := time.Now()
+	res, err := client.GetLeafs(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Len(t, res.Keys, 1000)
+	assert.GreaterOrEqual(t, time.Since(start), retryAfter)
+	assert.EqualValues(t, 2, mockNetClient.numCalls)
+
+	// The backoff doesn't outlive the request context.
+	mockNetClient.mockResponses(nil, nil, goodResponse)
+	mockNetClient.requestErr = []error{fmt.Errorf("%w: %w", peer.ErrRequestFailed, &peer.ThrottledError{RetryAfter: time.Hour})}
+	ctx, cancel := context.WithTimeout(context.Background(), retryAfter)
+	defer cancel()
+	_, err = client.GetLeafs(ctx, request)
+	assert.ErrorContains(t, err, context.DeadlineExceeded.Error())
+	assert.EqualValues(t, 1, mockNetClient.numCalls)
+}
+
 func TestStateSyncNodes(t *testing.T) {
 	mockNetClient := &mockNetwork{}
 
@@ -42,6 +42,11 @@ type MockHandlerStats struct {
 	SnapshotReadTime,
 	GenerateRangeProofTime,
 	LeafRequestProcessingTimeSum time.Duration
+
+	ThrottledRequestCount,
+	ValidatorRequestCount uint32
+	PeerBytesServedSum uint64
+	PeerTimeServedSum  time.Duration
 }
 
 func (m *MockHandlerStats) Reset() {
@@ -73,6 +78,10 @@ func (m *MockHandlerStats) Reset() {
 	m.SnapshotReadTime = 0
 	m.GenerateRangeProofTime = 0
 	m.LeafRequestProcessingTimeSum = 0
+	m.ThrottledRequestCount = 0
+	m.ValidatorRequestCount = 0
+	m.PeerBytesServedSum = 0
+	m.PeerTimeServedSum = 0
 }
 
 func (m *MockHandlerStats) IncBlockRequest() {
@@ -230,3 +239,27 @@ func (m *MockHandlerStats) IncSnapshotSegmentInvalid() {
 	defer m.lock.Unlock()
 	m.SnapshotSegmentInvalidCount++
 }
+
+func (m *MockHandlerStats) IncThrottledRequest() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ThrottledRequestCount++
+}
+
+func (m *MockHandlerStats) IncValidatorRequest() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.ValidatorRequestCount++
+}
+
+func (m *MockHandlerStats) UpdatePeerBytesServed(bytes uint64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.PeerBytesServedSum += bytes
+}
+
+func (m *MockHandlerStats) UpdatePeerTimeServed(duration time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.PeerTimeServedSum += duration
+}
@@ -14,6 +14,7 @@ type HandlerStats interface {
 	BlockRequestHandlerStats
 	CodeRequestHandlerStats
 	LeafsRequestHandlerStats
+	ThrottlerStats
 }
 
 type BlockRequestHandlerStats interface {
@@ -51,6 +52,13 @@ type LeafsRequestHandlerStats interface {
 	IncSnapshotSegmentInvalid()
 }
 
+type ThrottlerStats interface {
+	IncThrottledRequest()
+	IncValidatorRequest()
+	UpdatePeerBytesServed(bytes uint64)
+	UpdatePeerTimeServed(duration time.Duration)
+}
+
 type handlerStats struct {
 	// BlockRequestHandler metrics
 	blockRequest               metrics.Counter
@@ -83,6 +91,12 @@ type handlerStats struct {
 	snapshotReadSuccess        metrics.Counter
 	snapshotSegmentValid       metrics.Counter
 	snapshotSegmentInvalid     metrics.Counter
+
+	// Throttler stats
+	throttledRequest metrics.Counter
+	validatorRequest metrics.Counter
+	peerBytesServed  metrics.Histogram
+	peerTimeServed   metrics.Timer
 }
 
 func (h *handlerStats) IncBlockRequest() {
@@ -166,6 +180,17 @@ func (h *handlerStats) IncSnapshotReadSuccess()    { h.snapshotReadSuccess.Inc(1
 func (h *handlerStats) IncSnapshotSegmentValid()   { h.snapshotSegmentValid.Inc(1) }
 func (h *handlerStats) IncSnapshotSegmentInvalid() { h.snapshotSegmentInvalid.Inc(1) }
 
+func (h *handlerStats) IncThrottledRequest() { h.throttledRequest.Inc(1) }
+func (h *handlerStats) IncValidatorRequest() { h.validatorRequest.Inc(1) }
+
+func (h *handlerStats) UpdatePeerBytesServed(bytes uint64) {
+	h.peerBytesServed.Update(int64(bytes))
+}
+
+func (h *handlerStats) UpdatePeerTimeServed(duration time.Duration) {
+	h.peerTimeServed.Update(duration)
+}
+
 func NewHandlerStats(enabled bool) HandlerStats {
 	if !enabled {
 		return NewNoopHandlerStats()
@@ -202,6 +227,12 @@ func NewHandlerStats(enabled bool) HandlerStats {
 		snapshotReadSuccess:        metrics.GetOrRegisterCounter("leafs_request_snapshot_read_success", nil),
 		snapshotSegmentValid:       metrics.GetOrRegisterCounter("leafs_request_snapshot_segment_valid", nil),
 		snapshotSegmentInvalid:     metrics.GetOrRegisterCounter("leafs_request_snapshot_segment_invalid", nil),
+
+		// initialize throttler stats
+		throttledRequest: metrics.GetOrRegisterCounter("sync_request_throttled", nil),
+		validatorRequest: metrics.GetOrRegisterCounter("sync_request_validator", nil),
+		peerBytesServed:  metrics.GetOrRegisterHistogram("sync_request_peer_bytes_served", nil, metrics.NewExpDecaySample(1028, 0.015)),
+		peerTimeServed:   metrics.GetOrRegisterTimer("sync_request_peer_time_served", nil),
 	}
 }
 
@@ -239,3 +270,7 @@ func (n *noopHandlerStats) IncSnapshotReadAttempt()
 func (n *noopHandlerStats) IncSnapshotReadSuccess()                             {}
 func (n *noopHandlerStats) IncSnapshotSegmentValid()                            {}
 func (n *noopHandlerStats) IncSnapshotSegmentInvalid()                          {}
+func (n *noopHandlerStats) IncThrottledRequest()                                {}
+func (n *noopHandlerStats) IncValidatorRequest()                                {}
+func (n *noopHandlerStats) UpdatePeerBytesServed(uint64)                        {}
+func (n *noopHandlerStats) UpdatePeerTimeServed(time.Duration)                  {}
@@ -0,0 +1,171 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/p2p"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ava-labs/coreth/peer"
+	"github.com/ava-labs/coreth/sync/handlers/stats"
+)
+
+// ThrottlerConfig configures the budgets of the requests of each peer served
+// by a Throttler.
+type ThrottlerConfig struct {
+	// Bytes is the number of response bytes served to a peer per Period,
+	// 0 for no limit.
+	Bytes uint64
+	// Time is the time spent handling the requests of a peer per Period,
+	// 0 for no limit.
+	Time time.Duration
+	// Period is the length of the windows the budgets are accounted over.
+	Period time.Duration
+	// ValidatorMultiplier scales the budgets of validators, prioritizing their
+	// requests over the requests of other peers.
+	ValidatorMultiplier uint64
+}
+
+// peerUsage is the usage of its budgets by a peer in the window starting at
+// [start].
+type peerUsage struct {
+	start time.Time
+	bytes uint64
+	time  time.Duration
+	// requests is the number of requests served in the window.
+	requests uint64
+	// inFlight is the number of requests being served, whose cost is reserved
+	// from the budgets until they complete.
+	inFlight uint64
+}
+
+// Throttler accounts the bytes and time spent serving the state sync requests
+// of each peer, rejecting the requests of the peers exceeding their budgets
+// until their window ends.
+// The requests being served are charged the average cost of the requests of
+// the peer, so that concurrent requests can't overshoot the budgets.
+type Throttler struct {
+	config     ThrottlerConfig
+	validators p2p.ValidatorSet
+	stats      stats.ThrottlerStats
+	clock      mockable.Clock
+
+	lock      sync.Mutex
+	peers     map[ids.NodeID]*peerUsage
+	lastSweep time.Time
+	// served is the usage of all the requests served, estimating the cost of
+	// the requests of the peers with no requests served in their window.
+	served peerUsage
+}
+
+// NewThrottler returns a Throttler enforcing the budgets of [config], scaling
+// the budgets of the peers in [validators].
+func NewThrottler(config ThrottlerConfig, validators p2p.ValidatorSet, stats stats.ThrottlerStats) *Throttler {
+	return &Throttler{
+		config:     config,
+		validators: validators,
+		stats:      stats,
+		peers:      make(map[ids.NodeID]*peerUsage),
+	}
+}
+
+// Throttle serves the request of [nodeID] with [handle] if [nodeID] is within
+// its budgets, accounting the response bytes and the time spent. Otherwise,
+// it returns a [peer.ThrottledError] with the time left in the window of
+// [nodeID].
+func (t *Throttler) Throttle(ctx context.Context, nodeID ids.NodeID, handle func() ([]byte, error)) ([]byte, error) {
+	if t.config.Bytes == 0 && t.config.Time == 0 {
+		return handle()
+	}
+	if retryAfter, ok := t.reserve(ctx, nodeID); !ok {
+		t.stats.IncThrottledRequest()
+		log.Debug("throttling state sync request", "nodeID", nodeID, "retryAfter", retryAfter)
+		return nil, &peer.ThrottledError{RetryAfter: retryAfter}
+	}
+
+	start := t.clock.Time()
+	response, err := handle()
+	elapsed := t.clock.Time().Sub(start)
+	t.stats.UpdatePeerBytesServed(uint64(len(response)))
+	t.stats.UpdatePeerTimeServed(elapsed)
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	// Replace the reservation with the actual cost of the request.
+	usage := t.usage(nodeID)
+	usage.inFlight--
+	for _, usage := range []*peerUsage{usage, &t.served} {
+		usage.bytes += uint64(len(response))
+		usage.time += elapsed
+		usage.requests++
+	}
+	return response, err
+}
+
+// reserve returns whether [nodeID] is within its budgets, including the
+// estimated cost of its requests in flight, reserving the cost of a request
+// if so. Otherwise, it returns the time left in the window of [nodeID].
+func (t *Throttler) reserve(ctx context.Context, nodeID ids.NodeID) (time.Duration, bool) {
+	bytesBudget, timeBudget := t.config.Bytes, t.config.Time
+	if t.config.ValidatorMultiplier > 1 && t.validators.Has(ctx, nodeID) {
+		t.stats.IncValidatorRequest()
+		bytesBudget *= t.config.ValidatorMultiplier
+		timeBudget *= time.Duration(t.config.ValidatorMultiplier)
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	usage := t.usage(nodeID)
+	estimate := usage
+	if estimate.requests == 0 {
+		estimate = &t.served
+	}
+	var reservedBytes uint64
+	var reservedTime time.Duration
+	if estimate.requests > 0 {
+		reservedBytes = usage.inFlight * (estimate.bytes / estimate.requests)
+		reservedTime = time.Duration(usage.inFlight) * (estimate.time / time.Duration(estimate.requests))
+	}
+	if (bytesBudget == 0 || usage.bytes+reservedBytes < bytesBudget) && (timeBudget == 0 || usage.time+reservedTime < timeBudget) {
+		usage.inFlight++
+		return 0, true
+	}
+	return usage.start.Add(t.config.Period).Sub(t.clock.Time()), false
+}
+
+// usage returns the usage of [nodeID] in its current window, starting a new
+// window if the previous one ended.
+// Assumes [t.lock] is held.
+func (t *Throttler) usage(nodeID ids.NodeID) *peerUsage {
+	now := t.clock.Time()
+	if now.Sub(t.lastSweep) >= t.config.Period {
+		// Drop the ended windows so that the peers no longer making requests
+		// are not retained.
+		for nodeID, usage := range t.peers {
+			if usage.inFlight == 0 && now.Sub(usage.start) >= t.config.Period {
+				delete(t.peers, nodeID)
+			}
+		}
+		t.lastSweep = now
+	}
+
+	usage, ok := t.peers[nodeID]
+	if !ok {
+		usage = &peerUsage{start: now}
+		t.peers[nodeID] = usage
+	} else if now.Sub(usage.start) >= t.config.Period {
+		// The requests in flight are charged to the new window.
+		usage = &peerUsage{start: now, inFlight: usage.inFlight}
+		t.peers[nodeID] = usage
+	}
+	return usage
+}
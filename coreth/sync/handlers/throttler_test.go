@@ -0,0 +1,177 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/peer"
+	"github.com/ava-labs/coreth/sync/handlers/stats"
+)
+
+type testValidatorSet struct {
+	validators set.Set[ids.NodeID]
+}
+
+func (v *testValidatorSet) Has(_ context.Context, nodeID ids.NodeID) bool {
+	return v.validators.Contains(nodeID)
+}
+
+func TestThrottler(t *testing.T) {
+	var (
+		peerID      = ids.GenerateTestNodeID()
+		otherPeerID = ids.GenerateTestNodeID()
+		validatorID = ids.GenerateTestNodeID()
+		now         = time.Unix(1_700_000_000, 0)
+		errHandler  = errors.New("handler failed")
+	)
+	tests := map[string]struct {
+		config ThrottlerConfig
+		// serve is called with the throttler and a function serving a request
+		// of [nodeID] taking [duration] and returning [response] bytes.
+		serve func(t *testing.T, throttler *Throttler, serve func(nodeID ids.NodeID, response int, duration time.Duration) ([]byte, error))
+		// expected stats
+		throttled, validator uint32
+		bytesServed          uint64
+	}{
+		"no budgets": {
+			config: ThrottlerConfig{Period: time.Minute},
+			serve: func(t *testing.T, _ *Throttler, serve func(ids.NodeID, int, time.Duration) ([]byte, error)) {
+				for i := 0; i < 10; i++ {
+					_, err := serve(peerID, 1024, time.Second)
+					require.NoError(t, err)
+				}
+			},
+		},
+		"bytes budget": {
+			config: ThrottlerConfig{Bytes: 100, Period: time.Minute},
+			serve: func(t *testing.T, throttler *Throttler, serve func(ids.NodeID, int, time.Duration) ([]byte, error)) {
+				response, err := serve(peerID, 60, 0)
+				require.NoError(t, err)
+				require.Len(t, response, 60)
+				// The budget is exceeded by the last request served.
+				_, err = serve(peerID, 60, 0)
+				require.NoError(t, err)
+
+				throttler.clock.Set(now.Add(15 * time.Second))
+				_, err = serve(peerID, 60, 0)
+				var throttledErr *peer.ThrottledError
+				require.ErrorAs(t, err, &throttledErr)
+				require.Equal(t, 45*time.Second, throttledErr.RetryAfter)
+
+				// Other peers have their own budgets.
+				_, err = serve(otherPeerID, 60, 0)
+				require.NoError(t, err)
+
+				// The budget is renewed when the window ends.
+				throttler.clock.Set(now.Add(time.Minute))
+				_, err = serve(peerID, 60, 0)
+				require.NoError(t, err)
+			},
+			throttled:   1,
+			bytesServed: 240,
+		},
+		"time budget": {
+			config: ThrottlerConfig{Time: time.Second, Period: time.Minute},
+			serve: func(t *testing.T, _ *Throttler, serve func(ids.NodeID, int, time.Duration) ([]byte, error)) {
+				_, err := serve(peerID, 0, 600*time.Millisecond)
+				require.NoError(t, err)
+				_, err = serve(peerID, 0, 600*time.Millisecond)
+				require.NoError(t, err)
+				_, err = serve(peerID, 0, 0)
+				var throttledErr *peer.ThrottledError
+				require.ErrorAs(t, err, &throttledErr)
+				require.Equal(t, time.Minute-1200*time.Millisecond, throttledErr.RetryAfter)
+			},
+			throttled: 1,
+		},
+		"validators are prioritized": {
+			config: ThrottlerConfig{Bytes: 100, Period: time.Minute, ValidatorMultiplier: 3},
+			serve: func(t *testing.T, _ *Throttler, serve func(ids.NodeID, int, time.Duration) ([]byte, error)) {
+				for i := 0; i < 3; i++ {
+					_, err := serve(validatorID, 100, 0)
+					require.NoError(t, err)
+				}
+				_, err := serve(validatorID, 100, 0)
+				require.ErrorAs(t, err, new(*peer.ThrottledError))
+
+				_, err = serve(peerID, 100, 0)
+				require.NoError(t, err)
+				_, err = serve(peerID, 100, 0)
+				require.ErrorAs(t, err, new(*peer.ThrottledError))
+			},
+			throttled:   2,
+			validator:   4,
+			bytesServed: 400,
+		},
+		"requests in flight are reserved": {
+			config: ThrottlerConfig{Bytes: 100, Period: time.Minute},
+			serve: func(t *testing.T, throttler *Throttler, serve func(ids.NodeID, int, time.Duration) ([]byte, error)) {
+				_, err := serve(peerID, 50, 0)
+				require.NoError(t, err)
+
+				// A request in flight is charged the average cost of the
+				// requests of the peer, 50 bytes, until it completes.
+				started, release := make(chan struct{}), make(chan struct{})
+				done := make(chan error)
+				go func() {
+					_, err := throttler.Throttle(context.Background(), peerID, func() ([]byte, error) {
+						close(started)
+						<-release
+						return make([]byte, 60), nil
+					})
+					done <- err
+				}()
+				<-started
+				_, err = serve(peerID, 40, 0)
+				require.ErrorAs(t, err, new(*peer.ThrottledError))
+
+				close(release)
+				require.NoError(t, <-done)
+				_, err = serve(peerID, 40, 0)
+				require.ErrorAs(t, err, new(*peer.ThrottledError))
+			},
+			throttled:   2,
+			bytesServed: 110,
+		},
+		"handler errors are returned": {
+			config: ThrottlerConfig{Bytes: 100, Period: time.Minute},
+			serve: func(t *testing.T, throttler *Throttler, _ func(ids.NodeID, int, time.Duration) ([]byte, error)) {
+				_, err := throttler.Throttle(context.Background(), peerID, func() ([]byte, error) {
+					return nil, errHandler
+				})
+				require.ErrorIs(t, err, errHandler)
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			mockStats := &stats.MockHandlerStats{}
+			throttler := NewThrottler(test.config, &testValidatorSet{validators: set.Of(validatorID)}, mockStats)
+			throttler.clock.Set(now)
+			serve := func(nodeID ids.NodeID, response int, duration time.Duration) ([]byte, error) {
+				return throttler.Throttle(context.Background(), nodeID, func() ([]byte, error) {
+					throttler.clock.Set(throttler.clock.Time().Add(duration))
+					return make([]byte, response), nil
+				})
+			}
+			test.serve(t, throttler, serve)
+
+			require.Equal(test.throttled, mockStats.ThrottledRequestCount)
+			require.Equal(test.validator, mockStats.ValidatorRequestCount)
+			if test.config.Bytes > 0 {
+				require.Equal(test.bytesServed, mockStats.PeerBytesServedSum)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package syncfile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/peer"
+	"github.com/ava-labs/coreth/plugin/evm/message"
+	"github.com/ava-labs/coreth/sync/handlers"
+	"github.com/ava-labs/coreth/sync/handlers/stats"
+	"github.com/ava-labs/coreth/triedb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+var (
+	errMissingData        = errors.New("state sync file is missing the requested data")
+	errUnsupportedRequest = errors.New("request not supported by state sync files")
+
+	_ peer.NetworkClient     = (*networkClient)(nil)
+	_ message.RequestHandler = (*requestHandler)(nil)
+)
+
+// networkClient serves the requests of a state sync client with the handlers
+// serving them to peers, reading the data of a state sync file loaded into a
+// database. The responses are verified by the state sync client exactly as
+// the responses of peers.
+type networkClient struct {
+	codec   codec.Manager
+	handler message.RequestHandler
+	cancel  context.CancelCauseFunc
+}
+
+// NewNetworkClient returns a [peer.NetworkClient] serving state sync requests
+// from the state sync file loaded into [db] by [Reader.Load].
+// The state sync client retries failed requests until its context is done,
+// while a request failing on the data of a file always fails. Hence, [cancel]
+// is called with the error of the first failed request to stop the sync.
+func NewNetworkClient(db ethdb.Database, codec codec.Manager, cancel context.CancelCauseFunc) peer.NetworkClient {
+	handlerStats := stats.NewNoopHandlerStats()
+	return &networkClient{
+		codec: codec,
+		handler: &requestHandler{
+			leafsRequestHandler: handlers.NewLeafsRequestHandler(triedb.NewDatabase(db, nil), nil, codec, handlerStats),
+			blockRequestHandler: handlers.NewBlockRequestHandler(&blockProvider{db: db}, codec, handlerStats),
+			codeRequestHandler:  handlers.NewCodeRequestHandler(db, codec, handlerStats),
+		},
+		cancel: cancel,
+	}
+}
+
+func (c *networkClient) SendAppRequestAny(ctx context.Context, _ *version.Application, request []byte) ([]byte, ids.NodeID, error) {
+	response, err := c.SendAppRequest(ctx, ids.EmptyNodeID, request)
+	return response, ids.EmptyNodeID, err
+}
+
+func (c *networkClient) SendAppRequest(ctx context.Context, nodeID ids.NodeID, requestBytes []byte) ([]byte, error) {
+	request, err := message.BytesToRequest(c.codec, requestBytes)
+	if err != nil {
+		return nil, err
+	}
+	response, err := request.Handle(ctx, nodeID, 0, c.handler)
+	if err == nil && len(response) == 0 {
+		err = fmt.Errorf("%w: %s", errMissingData, request)
+	}
+	if err != nil {
+		c.cancel(err)
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *networkClient) TrackBandwidth(ids.NodeID, float64) {}
+
+// requestHandler handles the state sync requests served from a state sync file.
+type requestHandler struct {
+	leafsRequestHandler *handlers.LeafsRequestHandler
+	blockRequestHandler *handlers.BlockRequestHandler
+	codeRequestHandler  *handlers.CodeRequestHandler
+}
+
+func (h *requestHandler) HandleStateTrieLeafsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, leafsRequest message.LeafsRequest) ([]byte, error) {
+	return h.leafsRequestHandler.OnLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+}
+
+// HandleAtomicTrieLeafsRequest serves the atomic trie with the same handler as
+// the state trie, as the trie nodes of a state sync file are keyed by hash.
+func (h *requestHandler) HandleAtomicTrieLeafsRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, leafsRequest message.LeafsRequest) ([]byte, error) {
+	return h.leafsRequestHandler.OnLeafsRequest(ctx, nodeID, requestID, leafsRequest)
+}
+
+func (h *requestHandler) HandleBlockRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, blockRequest message.BlockRequest) ([]byte, error) {
+	return h.blockRequestHandler.OnBlockRequest(ctx, nodeID, requestID, blockRequest)
+}
+
+func (h *requestHandler) HandleCodeRequest(ctx context.Context, nodeID ids.NodeID, requestID uint32, codeRequest message.CodeRequest) ([]byte, error) {
+	return h.codeRequestHandler.OnCodeRequest(ctx, nodeID, requestID, codeRequest)
+}
+
+func (*requestHandler) HandleMessageSignatureRequest(context.Context, ids.NodeID, uint32, message.MessageSignatureRequest) ([]byte, error) {
+	return nil, errUnsupportedRequest
+}
+
+func (*requestHandler) HandleBlockSignatureRequest(context.Context, ids.NodeID, uint32, message.BlockSignatureRequest) ([]byte, error) {
+	return nil, errUnsupportedRequest
+}
+
+// blockProvider reads the blocks of a state sync file loaded into [db].
+type blockProvider struct {
+	db ethdb.Reader
+}
+
+func (p *blockProvider) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return rawdb.ReadBlock(p.db, hash, number)
+}
@@ -0,0 +1,302 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+// Package syncfile implements state sync files: portable snapshots of the
+// state at a sync summary, which a node can state sync from instead of
+// fetching the state from its peers.
+//
+// A state sync file is an RLP stream of a header holding the sync summary,
+// followed by records of the trie nodes of the account trie, the storage tries
+// and the atomic trie, the code of the accounts, and the block of the summary
+// with its parents, and an end record. Trie nodes and code are keyed by their
+// hash when loaded, so the data of a file can only be served for the roots of
+// its summary, and the block of the summary must commit to its state root.
+package syncfile
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/plugin/evm/message"
+	"github.com/ava-labs/coreth/sync/handlers"
+	"github.com/ava-labs/coreth/trie"
+	"github.com/ava-labs/coreth/triedb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Version is the version of the state sync files written by Export.
+const Version = 1
+
+const (
+	trieNodeRecord uint8 = iota
+	codeRecord
+	blockRecord
+	endRecord
+)
+
+var (
+	errUnsupportedVersion = errors.New("unsupported state sync file version")
+	errUnknownRecord      = errors.New("unknown state sync file record")
+	errMissingCode        = errors.New("missing code")
+	errMissingBlock       = errors.New("missing block")
+	errRecordCount        = errors.New("state sync file record count mismatch")
+	errRootMismatch       = errors.New("summary state root does not match its block")
+)
+
+type header struct {
+	Version uint64
+	Summary []byte
+}
+
+type record struct {
+	Kind uint8
+	Data []byte
+}
+
+// ExportConfig holds the data exported to a state sync file.
+type ExportConfig struct {
+	Summary      message.SyncSummary
+	StateTrieDB  *triedb.Database     // trie database of the account and storage tries
+	CodeReader   ethdb.KeyValueReader // reader of the code of the accounts
+	AtomicTrieDB *triedb.Database     // trie database of the atomic trie
+	Blocks       handlers.BlockProvider
+	NumBlocks    int // number of blocks exported, starting with the block of [Summary]
+}
+
+type exporter struct {
+	ctx       context.Context
+	config    *ExportConfig
+	w         io.Writer
+	records   uint64
+	storage   map[common.Hash]struct{} // storage roots exported
+	code      map[common.Hash]struct{} // code hashes exported
+	nodes     uint64
+	codeBytes uint64
+	blocks    uint64
+}
+
+// Export writes the state sync file of [config.Summary] to [w].
+func Export(ctx context.Context, w io.Writer, config *ExportConfig) error {
+	bw := bufio.NewWriter(w)
+	e := &exporter{
+		ctx:     ctx,
+		config:  config,
+		w:       bw,
+		storage: make(map[common.Hash]struct{}),
+		code:    make(map[common.Hash]struct{}),
+	}
+	if err := rlp.Encode(bw, &header{Version: Version, Summary: config.Summary.Bytes()}); err != nil {
+		return err
+	}
+
+	summary := config.Summary
+	if err := e.exportTrie(config.StateTrieDB, trie.StateTrieID(summary.BlockRoot), e.exportAccount); err != nil {
+		return fmt.Errorf("failed to export state trie %s: %w", summary.BlockRoot, err)
+	}
+	if err := e.exportTrie(config.AtomicTrieDB, trie.TrieID(summary.AtomicRoot), nil); err != nil {
+		return fmt.Errorf("failed to export atomic trie %s: %w", summary.AtomicRoot, err)
+	}
+	if err := e.exportBlocks(); err != nil {
+		return err
+	}
+	count, err := rlp.EncodeToBytes(e.records)
+	if err != nil {
+		return err
+	}
+	if err := e.write(endRecord, count); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	log.Info("exported state sync file", "summary", summary, "trieNodes", e.nodes, "code", len(e.code), "codeBytes", e.codeBytes, "blocks", e.blocks)
+	return nil
+}
+
+func (e *exporter) write(kind uint8, data []byte) error {
+	e.records++
+	return rlp.Encode(e.w, &record{Kind: kind, Data: data})
+}
+
+// exportTrie writes the nodes of the trie [id] in [db], calling [onLeaf] with
+// the key and value of each of its leafs if non-nil.
+func (e *exporter) exportTrie(db *triedb.Database, id *trie.ID, onLeaf func(key, value []byte) error) error {
+	if id.Root == (common.Hash{}) || id.Root == types.EmptyRootHash {
+		return nil
+	}
+	t, err := trie.New(id, db)
+	if err != nil {
+		return err
+	}
+	it, err := t.NodeIterator(nil)
+	if err != nil {
+		return err
+	}
+	for it.Next(true) {
+		if err := e.ctx.Err(); err != nil {
+			return err
+		}
+		// Nodes embedded in their parent have no hash and are not stored.
+		if it.Hash() != (common.Hash{}) {
+			if err := e.write(trieNodeRecord, it.NodeBlob()); err != nil {
+				return err
+			}
+			e.nodes++
+		}
+		if it.Leaf() && onLeaf != nil {
+			if err := onLeaf(it.LeafKey(), it.LeafBlob()); err != nil {
+				return err
+			}
+		}
+	}
+	return it.Error()
+}
+
+// exportAccount writes the storage trie and the code of the account with
+// hash [key], unless they were already written for another account.
+func (e *exporter) exportAccount(key, value []byte) error {
+	var acc types.StateAccount
+	if err := rlp.DecodeBytes(value, &acc); err != nil {
+		return err
+	}
+	if _, ok := e.storage[acc.Root]; !ok {
+		e.storage[acc.Root] = struct{}{}
+		id := trie.StorageTrieID(e.config.Summary.BlockRoot, common.BytesToHash(key), acc.Root)
+		if err := e.exportTrie(e.config.StateTrieDB, id, nil); err != nil {
+			return fmt.Errorf("failed to export storage trie %s: %w", acc.Root, err)
+		}
+	}
+
+	codeHash := common.BytesToHash(acc.CodeHash)
+	if _, ok := e.code[codeHash]; ok || bytes.Equal(acc.CodeHash, types.EmptyCodeHash[:]) {
+		return nil
+	}
+	e.code[codeHash] = struct{}{}
+	code := rawdb.ReadCode(e.config.CodeReader, codeHash)
+	if len(code) == 0 {
+		return fmt.Errorf("%w: %s", errMissingCode, codeHash)
+	}
+	e.codeBytes += uint64(len(code))
+	return e.write(codeRecord, code)
+}
+
+// exportBlocks writes [e.config.NumBlocks] blocks, starting with the block of
+// the summary and followed by its parents, stopping at the genesis block.
+func (e *exporter) exportBlocks() error {
+	hash, height := e.config.Summary.BlockHash, e.config.Summary.BlockNumber
+	for i := 0; i < e.config.NumBlocks && hash != (common.Hash{}); i++ {
+		block := e.config.Blocks.GetBlock(hash, height)
+		if block == nil {
+			return fmt.Errorf("%w: %s (%d)", errMissingBlock, hash, height)
+		}
+		data, err := rlp.EncodeToBytes(block)
+		if err != nil {
+			return err
+		}
+		if err := e.write(blockRecord, data); err != nil {
+			return err
+		}
+		e.blocks++
+		hash = block.ParentHash()
+		height--
+	}
+	return nil
+}
+
+// Reader reads a state sync file.
+type Reader struct {
+	stream  *rlp.Stream
+	summary message.SyncSummary
+}
+
+// NewReader returns a Reader of the state sync file read from [r], reading
+// its header.
+func NewReader(r io.Reader) (*Reader, error) {
+	stream := rlp.NewStream(bufio.NewReader(r), 0)
+	var h header
+	if err := stream.Decode(&h); err != nil {
+		return nil, fmt.Errorf("failed to read state sync file header: %w", err)
+	}
+	if h.Version != Version {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedVersion, h.Version)
+	}
+	summary, err := message.NewSyncSummaryFromBytes(h.Summary, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse state sync file summary: %w", err)
+	}
+	return &Reader{stream: stream, summary: summary}, nil
+}
+
+// Summary returns the sync summary of the file.
+func (r *Reader) Summary() message.SyncSummary {
+	return r.summary
+}
+
+// Load writes the data of the file to [db] the way it is served by
+// [NewNetworkClient], and checks the block of the summary commits to its
+// state root.
+func (r *Reader) Load(db ethdb.Database) error {
+	batch := db.NewBatch()
+	for records := uint64(0); ; records++ {
+		var rec record
+		if err := r.stream.Decode(&rec); err != nil {
+			return fmt.Errorf("failed to read state sync file record %d: %w", records, err)
+		}
+		switch rec.Kind {
+		case trieNodeRecord:
+			rawdb.WriteLegacyTrieNode(batch, crypto.Keccak256Hash(rec.Data), rec.Data)
+		case codeRecord:
+			rawdb.WriteCode(batch, crypto.Keccak256Hash(rec.Data), rec.Data)
+		case blockRecord:
+			block := new(types.Block)
+			if err := rlp.DecodeBytes(rec.Data, block); err != nil {
+				return fmt.Errorf("failed to decode state sync file block: %w", err)
+			}
+			rawdb.WriteBlock(batch, block)
+		case endRecord:
+			var count uint64
+			if err := rlp.DecodeBytes(rec.Data, &count); err != nil {
+				return err
+			}
+			if count != records {
+				return fmt.Errorf("%w: expected %d, read %d", errRecordCount, count, records)
+			}
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			log.Info("loaded state sync file", "summary", r.summary, "records", records)
+			return r.checkSummaryBlock(db)
+		default:
+			return fmt.Errorf("%w: %d", errUnknownRecord, rec.Kind)
+		}
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+}
+
+// checkSummaryBlock checks the block of the summary loaded into [db] has the
+// hash and the state root of the summary.
+func (r *Reader) checkSummaryBlock(db ethdb.Reader) error {
+	block := rawdb.ReadBlock(db, r.summary.BlockHash, r.summary.BlockNumber)
+	if block == nil || block.Hash() != r.summary.BlockHash {
+		return fmt.Errorf("%w: %s (%d)", errMissingBlock, r.summary.BlockHash, r.summary.BlockNumber)
+	}
+	if block.Root() != r.summary.BlockRoot {
+		return fmt.Errorf("%w: block root %s, summary root %s", errRootMismatch, block.Root(), r.summary.BlockRoot)
+	}
+	return nil
+}
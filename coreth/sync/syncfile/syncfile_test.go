@@ -0,0 +1,156 @@
+// (c) 2025, Flare Networks Limited. All rights reserved.
+// Please see the file LICENSE for licensing terms.
+
+package syncfile
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/coreth/core/rawdb"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/plugin/evm/message"
+	statesyncclient "github.com/ava-labs/coreth/sync/client"
+	clientstats "github.com/ava-labs/coreth/sync/client/stats"
+	"github.com/ava-labs/coreth/sync/handlers"
+	"github.com/ava-labs/coreth/sync/statesync"
+	"github.com/ava-labs/coreth/sync/syncutils"
+	"github.com/ava-labs/coreth/triedb"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+)
+
+type testBlockParser struct{}
+
+func (*testBlockParser) ParseEthBlock(b []byte) (*types.Block, error) {
+	block := new(types.Block)
+	return block, rlp.DecodeBytes(b, block)
+}
+
+func TestExportLoadSync(t *testing.T) {
+	require := require.New(t)
+	r := rand.New(rand.NewSource(1))
+
+	// Build the state, the atomic trie and the chain of the summary.
+	serverDB := rawdb.NewMemoryDatabase()
+	serverTrieDB := triedb.NewDatabase(serverDB, nil)
+	root, _ := syncutils.FillAccounts(t, serverTrieDB, common.Hash{}, 500, func(t *testing.T, i int, account types.StateAccount) types.StateAccount {
+		if i%2 == 0 {
+			code := make([]byte, 128)
+			r.Read(code)
+			codeHash := crypto.Keccak256Hash(code)
+			rawdb.WriteCode(serverDB, codeHash, code)
+			account.CodeHash = codeHash[:]
+		}
+		if i%5 == 0 {
+			account.Root, _, _ = syncutils.GenerateTrie(t, serverTrieDB, 16, common.HashLength)
+		}
+		return account
+	})
+	atomicTrieDB := triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil)
+	atomicRoot, atomicKeys, _ := syncutils.GenerateTrie(t, atomicTrieDB, 100, wrappers.LongLen+common.HashLength)
+
+	blocks := make(map[common.Hash]*types.Block)
+	var parent *types.Block
+	for i := int64(0); i <= 10; i++ {
+		header := &types.Header{Number: big.NewInt(i), Root: root}
+		if parent != nil {
+			header.ParentHash = parent.Hash()
+		}
+		parent = types.NewBlockWithHeader(header)
+		blocks[parent.Hash()] = parent
+	}
+	summary, err := message.NewSyncSummary(parent.Hash(), parent.NumberU64(), root, atomicRoot)
+	require.NoError(err)
+
+	export := func(summary message.SyncSummary) *bytes.Buffer {
+		var file bytes.Buffer
+		require.NoError(Export(context.Background(), &file, &ExportConfig{
+			Summary:      summary,
+			StateTrieDB:  serverTrieDB,
+			CodeReader:   serverDB,
+			AtomicTrieDB: atomicTrieDB,
+			Blocks: &handlers.TestBlockProvider{GetBlockFn: func(hash common.Hash, _ uint64) *types.Block {
+				return blocks[hash]
+			}},
+			NumBlocks: 5,
+		}))
+		return &file
+	}
+	file := export(summary)
+
+	// The block of the summary must commit to its state root.
+	invalidSummary, err := message.NewSyncSummary(parent.Hash(), parent.NumberU64(), types.EmptyRootHash, atomicRoot)
+	require.NoError(err)
+	reader, err := NewReader(export(invalidSummary))
+	require.NoError(err)
+	require.ErrorIs(reader.Load(rawdb.NewMemoryDatabase()), errRootMismatch)
+
+	// A truncated file is not loaded.
+	reader, err = NewReader(bytes.NewReader(file.Bytes()[:file.Len()-1]))
+	require.NoError(err)
+	require.Error(reader.Load(rawdb.NewMemoryDatabase()))
+
+	reader, err = NewReader(file)
+	require.NoError(err)
+	require.Equal(summary.Bytes(), reader.Summary().Bytes())
+	fileDB := rawdb.NewMemoryDatabase()
+	require.NoError(reader.Load(fileDB))
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+	client := statesyncclient.NewClient(&statesyncclient.ClientConfig{
+		NetworkClient: NewNetworkClient(fileDB, message.Codec, cancel),
+		Codec:         message.Codec,
+		Stats:         clientstats.NewNoOpStats(),
+		BlockParser:   &testBlockParser{},
+	})
+
+	// Sync the state from the file, as from the network.
+	clientDB := rawdb.NewMemoryDatabase()
+	syncer, err := statesync.NewStateSyncer(&statesync.StateSyncerConfig{
+		Client:                   client,
+		Root:                     root,
+		DB:                       clientDB,
+		BatchSize:                1000,
+		NumCodeFetchingWorkers:   statesync.DefaultNumCodeFetchingWorkers,
+		MaxOutstandingCodeHashes: statesync.DefaultMaxOutstandingCodeHashes,
+		RequestSize:              1024,
+	})
+	require.NoError(err)
+	require.NoError(syncer.Start(ctx))
+	require.NoError(<-syncer.Done())
+	syncutils.AssertTrieConsistency(t, root, serverTrieDB, triedb.NewDatabase(clientDB, nil), func(_, val []byte) error {
+		var acc types.StateAccount
+		require.NoError(rlp.DecodeBytes(val, &acc))
+		if codeHash := common.BytesToHash(acc.CodeHash); codeHash != types.EmptyCodeHash {
+			require.Equal(rawdb.ReadCode(serverDB, codeHash), rawdb.ReadCode(clientDB, codeHash))
+		}
+		return nil
+	})
+
+	leafsResponse, err := client.GetLeafs(ctx, message.LeafsRequest{
+		Root:     atomicRoot,
+		Limit:    1024,
+		NodeType: message.AtomicTrieNode,
+	})
+	require.NoError(err)
+	require.Equal(atomicKeys, leafsResponse.Keys)
+
+	syncedBlocks, err := client.GetBlocks(ctx, parent.Hash(), parent.NumberU64(), 5)
+	require.NoError(err)
+	require.Len(syncedBlocks, 5)
+	require.Equal(parent.Hash(), syncedBlocks[0].Hash())
+
+	// Requesting data missing from the file stops the sync.
+	missing := syncedBlocks[4].ParentHash()
+	_, err = client.GetBlocks(ctx, missing, syncedBlocks[4].NumberU64()-1, 1)
+	require.Error(err)
+	require.ErrorIs(context.Cause(ctx), errMissingData)
+}